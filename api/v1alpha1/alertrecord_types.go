@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Delivery statuses of an AlertRecord
+const (
+	DeliveryDelivered = "Delivered"
+	DeliveryFailed    = "Failed"
+)
+
+// AlertDelivery is the outcome of sending an alert to one notifier
+type AlertDelivery struct {
+	// Sink is the notifier the alert was sent to, e.g. slack
+	Sink string `json:"sink"`
+
+	// Status is Delivered, or Failed once the retries of the notifier were used up
+	// +kubebuilder:validation:Enum=Delivered;Failed
+	Status string `json:"status"`
+
+	// Error is the last delivery error
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// Time is when delivery succeeded or was given up
+	Time metav1.Time `json:"time"`
+}
+
+// AlertRecordSpec describes an alert the operator sent and how it was delivered
+type AlertRecordSpec struct {
+	// Key is the dedup key of the alert
+	Key string `json:"key"`
+
+	// Cluster the alert was raised in, if named
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+
+	// Namespace of the pod the alert was raised for
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Pod is the name of the pod the alert was raised for
+	// +optional
+	Pod string `json:"pod,omitempty"`
+
+	// Container is the failing container
+	// +optional
+	Container string `json:"container,omitempty"`
+
+	// Workload owning the pod, e.g. Deployment payments-api
+	// +optional
+	Workload string `json:"workload,omitempty"`
+
+	// Reason is the failure reason that triggered the alert
+	Reason string `json:"reason"`
+
+	// Severity of the alert
+	// +optional
+	Severity string `json:"severity,omitempty"`
+
+	// Message is the failure message of the alert
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Channel is the channel the alert was routed to, empty for the default destination
+	// +optional
+	Channel string `json:"channel,omitempty"`
+
+	// Team is the team the alert was attributed to
+	// +optional
+	Team string `json:"team,omitempty"`
+
+	// DetectedAt is when the failure was detected
+	DetectedAt metav1.Time `json:"detectedAt"`
+
+	// Deliveries lists the outcome for each notifier the alert was routed to
+	// +optional
+	Deliveries []AlertDelivery `json:"deliveries,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.spec.namespace`,description="Namespace of the pod"
+// +kubebuilder:printcolumn:name="Pod",type=string,JSONPath=`.spec.pod`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.spec.reason`
+// +kubebuilder:printcolumn:name="Channel",type=string,JSONPath=`.spec.channel`
+// +kubebuilder:printcolumn:name="Delivery",type=string,JSONPath=`.metadata.labels.slackgenie\.io/delivery`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// AlertRecord is the Schema for the alertrecords API, an audit record of an alert the operator
+// sent, kept for the configured retention
+type AlertRecord struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec describes the alert and its deliveries
+	// +required
+	Spec AlertRecordSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// AlertRecordList contains a list of AlertRecord
+type AlertRecordList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AlertRecord `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AlertRecord{}, &AlertRecordList{})
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// GenieConfig condition types
+const (
+	// ConditionParsed reports whether the configuration could be decoded
+	ConditionParsed = "Parsed"
+	// ConditionTemplatesCompiled reports whether all message templates parse
+	ConditionTemplatesCompiled = "TemplatesCompiled"
+	// ConditionRulesCompiled reports whether routing rules and settings are valid
+	ConditionRulesCompiled = "RulesCompiled"
+	// ConditionRoutesConflicting is True when an ownership rule is shadowed by an earlier one
+	ConditionRoutesConflicting = "RoutesConflicting"
+	// ConditionBackendsReachable reports whether the endpoints of enabled notifiers accept connections
+	ConditionBackendsReachable = "BackendsReachable"
+	// ConditionAccepted summarizes whether the configuration would be accepted by the operator
+	ConditionAccepted = "Accepted"
+	// ConditionApplied reports whether the running operator uses this generation of the configuration
+	ConditionApplied = "Applied"
+)
+
+// GenieConfigSpec holds the operator configuration
+type GenieConfigSpec struct {
+	// Config is the operator configuration, in the same format as the --config file
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	Config runtime.RawExtension `json:"config"`
+}
+
+// GenieConfigStatus reports whether the operator accepted the configuration
+type GenieConfigStatus struct {
+	// ObservedGeneration is the generation the conditions were computed for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the validation results of the configuration
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Accepted",type=string,JSONPath=`.status.conditions[?(@.type=="Accepted")].status`
+// +kubebuilder:printcolumn:name="Applied",type=string,JSONPath=`.status.conditions[?(@.type=="Applied")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Accepted")].reason`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// GenieConfig is the Schema for the genieconfigs API, an alternative to the --config file
+// whose status shows whether the operator accepted a change
+type GenieConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec holds the operator configuration
+	// +required
+	Spec GenieConfigSpec `json:"spec"`
+
+	// status reports validation results
+	// +optional
+	Status GenieConfigStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// GenieConfigList contains a list of GenieConfig
+type GenieConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GenieConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GenieConfig{}, &GenieConfigList{})
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GenieRouteTest condition types
+const (
+	// ConditionPassed reports whether the sample alert was routed as expected
+	ConditionPassed = "Passed"
+	// ConditionSent reports whether the sample alert was sent as a test alert
+	ConditionSent = "Sent"
+)
+
+// SampleAlert describes the pod failure a route test routes
+type SampleAlert struct {
+	// Namespace of the sample pod. Its channel annotation is read from the cluster.
+	// +required
+	Namespace string `json:"namespace"`
+
+	// Reason is the failure reason, e.g. CrashLoopBackOff
+	// +required
+	Reason string `json:"reason"`
+
+	// Container is the failing container, e.g. istio-proxy
+	// +optional
+	Container string `json:"container,omitempty"`
+
+	// Labels of the sample pod
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations of the sample pod, e.g. slackgenie.io/channel
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// NodeLabels of the node the sample pod runs on
+	// +optional
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+
+	// NodeTaints of the node the sample pod runs on
+	// +optional
+	NodeTaints []SampleTaint `json:"nodeTaints,omitempty"`
+}
+
+// SampleTaint is a taint of the sample pod's node
+type SampleTaint struct {
+	// +required
+	Key string `json:"key"`
+	// +optional
+	Value string `json:"value,omitempty"`
+	// +kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+	// +optional
+	Effect string `json:"effect,omitempty"`
+}
+
+// RouteExpectation is the routing outcome a sample alert must get. Fields that are not set
+// are not checked.
+type RouteExpectation struct {
+	// Channel the alert must be sent to; an empty string expects the default destination
+	// +optional
+	Channel *string `json:"channel,omitempty"`
+
+	// Team the alert must be attributed to; an empty string expects no team
+	// +optional
+	Team *string `json:"team,omitempty"`
+
+	// Severity the alert must have
+	// +kubebuilder:validation:Enum=info;warning;critical
+	// +optional
+	Severity string `json:"severity,omitempty"`
+}
+
+// GenieRouteTestSpec declares a sample alert and where it must be routed
+type GenieRouteTestSpec struct {
+	// Alert is the sample alert to route
+	// +required
+	Alert SampleAlert `json:"alert"`
+
+	// Expect is the expected routing outcome
+	// +required
+	Expect RouteExpectation `json:"expect"`
+
+	// SendTestAlert sends the sample alert, marked as a test, to the channel it is routed to
+	// through the configured notifiers, once for each generation of the spec. Sink filters and
+	// message templates apply as for real alerts.
+	// +optional
+	SendTestAlert bool `json:"sendTestAlert,omitempty"`
+}
+
+// RouteResult is how the operator routed the sample alert
+type RouteResult struct {
+	// Channel the alert was sent to; empty means the default destination
+	// +optional
+	Channel string `json:"channel,omitempty"`
+
+	// Team the alert was attributed to
+	// +optional
+	Team string `json:"team,omitempty"`
+
+	// Severity of the alert
+	// +optional
+	Severity string `json:"severity,omitempty"`
+
+	// Trace lists the routing decisions, as in alert routing traces
+	// +optional
+	Trace []string `json:"trace,omitempty"`
+}
+
+// GenieRouteTestStatus reports the outcome of the latest evaluation
+type GenieRouteTestStatus struct {
+	// ObservedGeneration is the generation the result was computed for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// SentGeneration is the generation a test alert was last sent for
+	// +optional
+	SentGeneration int64 `json:"sentGeneration,omitempty"`
+
+	// Result is how the sample alert was routed
+	// +optional
+	Result *RouteResult `json:"result,omitempty"`
+
+	// Conditions report whether the test passed and the test alert was sent
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Passed",type=string,JSONPath=`.status.conditions[?(@.type=="Passed")].status`
+// +kubebuilder:printcolumn:name="Channel",type=string,JSONPath=`.status.result.channel`
+// +kubebuilder:printcolumn:name="Sent",type=string,JSONPath=`.status.conditions[?(@.type=="Sent")].status`,priority=1
+// +kubebuilder:printcolumn:name="Team",type=string,JSONPath=`.status.result.team`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// GenieRouteTest is the Schema for the genieroutetests API, a sample alert with its expected
+// routing that the operator evaluates against the current configuration
+type GenieRouteTest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec declares the sample alert and the expected routing
+	// +required
+	Spec GenieRouteTestSpec `json:"spec"`
+
+	// status reports the outcome of the latest evaluation
+	// +optional
+	Status GenieRouteTestStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// GenieRouteTestList contains a list of GenieRouteTest
+type GenieRouteTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GenieRouteTest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GenieRouteTest{}, &GenieRouteTestList{})
+}
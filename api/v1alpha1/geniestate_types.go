@@ -0,0 +1,232 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AlertHistoryEntry records a single alert that was sent
+type AlertHistoryEntry struct {
+	// Key is the dedup key of the alert
+	Key string `json:"key"`
+
+	// Namespace of the pod the alert was raised for
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Pod is the name of the pod the alert was raised for
+	// +optional
+	Pod string `json:"pod,omitempty"`
+
+	// Reason is the failure reason that triggered the alert
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Channel is the channel the alert was routed to, empty for the default destination
+	// +optional
+	Channel string `json:"channel,omitempty"`
+
+	// Timestamp is when the alert was sent
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+// Silence suppresses alerts matching its matchers between StartsAt and EndsAt.
+// Empty matchers match everything.
+type Silence struct {
+	// ID uniquely identifies the silence
+	ID string `json:"id"`
+
+	// Namespace matcher
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Pod name matcher
+	// +optional
+	Pod string `json:"pod,omitempty"`
+
+	// Reason matcher
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Selector is a label selector matching the pod's labels, e.g. app=web,tier!=db
+	// +optional
+	Selector string `json:"selector,omitempty"`
+
+	// StartsAt is when the silence becomes active
+	StartsAt metav1.Time `json:"startsAt"`
+
+	// EndsAt is when the silence expires
+	EndsAt metav1.Time `json:"endsAt"`
+
+	// CreatedBy identifies who created the silence
+	// +optional
+	CreatedBy string `json:"createdBy,omitempty"`
+
+	// CreatedAt is when the silence was created
+	// +optional
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+
+	// Comment explains why the silence exists
+	// +optional
+	Comment string `json:"comment,omitempty"`
+}
+
+// DeadLetter records a delivery that still failed after all retries
+type DeadLetter struct {
+	// Kind of delivery: pod, meta or resolve
+	Kind string `json:"kind"`
+
+	// Key is the dedup key of the pod alert
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// Namespace of the pod the alert was raised for
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Pod is the name of the pod the alert was raised for
+	// +optional
+	Pod string `json:"pod,omitempty"`
+
+	// Reason is the failure reason that triggered the alert
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Title of the meta-alert
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// Error is the final delivery error
+	Error string `json:"error"`
+
+	// FailedAt is when delivery was given up
+	FailedAt metav1.Time `json:"failedAt"`
+}
+
+// OpenAlert records a sent alert that has not been resolved yet
+type OpenAlert struct {
+	// Key is the dedup key of the alert
+	Key string `json:"key"`
+
+	// Cluster is the name of the cluster the alert was raised in
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+
+	// Namespace of the pod the alert was raised for
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Pod is the name of the pod the alert was raised for
+	// +optional
+	Pod string `json:"pod,omitempty"`
+
+	// Container is the name of the failing container
+	// +optional
+	Container string `json:"container,omitempty"`
+
+	// WorkloadKind is the kind of the workload owning the pod, e.g. Deployment
+	// +optional
+	WorkloadKind string `json:"workloadKind,omitempty"`
+
+	// WorkloadName is the name of the workload owning the pod
+	// +optional
+	WorkloadName string `json:"workloadName,omitempty"`
+
+	// Reason is the failure reason that triggered the alert
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Severity overrides the severity of the reason, e.g. for alerts forwarded from Alertmanager
+	// +optional
+	Severity string `json:"severity,omitempty"`
+
+	// Channel is the channel the alert was routed to, empty for the default destination
+	// +optional
+	Channel string `json:"channel,omitempty"`
+
+	// Team is the team the alert was attributed to
+	// +optional
+	Team string `json:"team,omitempty"`
+
+	// Timestamp is when the alert was sent
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// OpenedAt is when the first alert of the incident was sent
+	// +optional
+	OpenedAt *metav1.Time `json:"openedAt,omitempty"`
+
+	// Escalated lists the indexes of the escalation rules that already fired
+	// +optional
+	Escalated []int `json:"escalated,omitempty"`
+}
+
+// GenieStateSpec holds the persisted alerting state of the operator
+type GenieStateSpec struct {
+	// Alerts maps alert dedup keys to the time the last alert was sent
+	// +optional
+	Alerts map[string]metav1.Time `json:"alerts,omitempty"`
+
+	// History lists recently sent alerts, oldest first
+	// +optional
+	History []AlertHistoryEntry `json:"history,omitempty"`
+
+	// Silences lists configured alert silences
+	// +optional
+	Silences []Silence `json:"silences,omitempty"`
+
+	// DeadLetters lists alerts that could not be delivered, oldest first
+	// +optional
+	DeadLetters []DeadLetter `json:"deadLetters,omitempty"`
+
+	// OpenAlerts lists sent alerts that have not been resolved yet
+	// +optional
+	OpenAlerts []OpenAlert `json:"openAlerts,omitempty"`
+
+	// KeyVersion is the format of the alert keys, so keys written by an earlier
+	// version of the operator are migrated on load
+	// +optional
+	KeyVersion int `json:"keyVersion,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GenieState is the Schema for the geniestates API, used by the "crd" state store backend
+type GenieState struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec holds the persisted alerting state
+	// +optional
+	Spec GenieStateSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// GenieStateList contains a list of GenieState
+type GenieStateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GenieState `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GenieState{}, &GenieStateList{})
+}
@@ -17,29 +17,73 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"net/url"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	geniev1alpha1 "github.com/ahmadrazalab/kube-slackgenie-operator/api/v1alpha1"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
 	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/controller"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/cost"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/export"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/forecast"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/github"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/loglink"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/oncall"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/quota"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/silences"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/slackapp"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/stateapi"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/store"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/cloudevents"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/discord"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/email"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/filesink"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/googlechat"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/httpwebhook"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/kafka"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/mattermost"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/pagerduty"
 	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/slack"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/sns"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/teams"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/telegram"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/version"
 	// +kubebuilder:scaffold:imports
 )
 
+// eventDrivenSyncPeriod is long enough that the informer resync never runs in practice
+const eventDrivenSyncPeriod = 365 * 24 * time.Hour
+
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -48,6 +92,8 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
+	utilruntime.Must(geniev1alpha1.AddToScheme(scheme))
+
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -60,6 +106,15 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var stateOpts store.Options
+	var slackSecretName, slackSecretNamespace string
+	var slackProxy string
+	var configPath string
+	var genieConfigName, genieConfigNamespace string
+	var debounceWindow time.Duration
+	var migrateState bool
+	var dryRun bool
+	var logDecisions bool
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -78,6 +133,36 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&stateOpts.Backend, "state-store", store.BackendMemory,
+		"Backend for alert state, history and silences: memory, configmap, crd, file or bbolt.")
+	flag.StringVar(&stateOpts.Namespace, "state-namespace", os.Getenv("POD_NAMESPACE"),
+		"Namespace of the ConfigMap or GenieState used by the configmap and crd state stores.")
+	flag.StringVar(&stateOpts.Name, "state-name", "slackgenie-state",
+		"Name of the ConfigMap or GenieState used by the configmap and crd state stores.")
+	flag.StringVar(&stateOpts.Path, "state-file", "",
+		"Path of the state file used by the file state store, or of the database used by the bbolt state store.")
+	flag.BoolVar(&migrateState, "migrate-state", false,
+		"Convert the alert keys of the persisted state to the current format and exit, e.g. from a pre-upgrade Job.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"Run the full alerting pipeline but log the rendered messages instead of sending them.")
+	flag.BoolVar(&logDecisions, "log-decisions", false,
+		"Log the outcome of every alert evaluation as JSON, to find out why a pod was or was not alerted on.")
+	flag.StringVar(&configPath, "config", "", "Path to the operator configuration file (routing rules etc.).")
+	flag.StringVar(&genieConfigName, "genie-config-name", "",
+		"Name of a GenieConfig resource to load the operator configuration from, instead of --config. "+
+			"Its status reports whether the configuration was accepted.")
+	flag.StringVar(&genieConfigNamespace, "genie-config-namespace", os.Getenv("POD_NAMESPACE"),
+		"Namespace of the GenieConfig referenced by --genie-config-name.")
+	flag.StringVar(&slackSecretName, "slack-secret-name", "",
+		"Name of a Secret holding webhook-url, bot-token and channel keys. When set, Slack credentials are read "+
+			"from the Secret instead of SLACK_* environment variables and reloaded whenever it changes.")
+	flag.StringVar(&slackSecretNamespace, "slack-secret-namespace", os.Getenv("POD_NAMESPACE"),
+		"Namespace of the Secret referenced by --slack-secret-name.")
+	flag.StringVar(&slackProxy, "slack-proxy", "",
+		"URL of the proxy Slack requests are sent through, e.g. http://proxy.corp:3128. "+
+			"Overrides HTTPS_PROXY and NO_PROXY for Slack only.")
+	flag.DurationVar(&debounceWindow, "debounce-window", 0,
+		"Default debounce window for repeat alerts, overriding debounce.window from the configuration.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -86,6 +171,47 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	buildInfo := version.Get()
+	setupLog.Info("Kube-SlackGenie",
+		"version", buildInfo.Version,
+		"commit", buildInfo.Commit,
+		"buildDate", buildInfo.BuildDate,
+		"platform", buildInfo.Platform,
+	)
+	metrics.Registry.MustRegister(version.Collector())
+
+	var cfg *config.Config
+	var genieConfig geniev1alpha1.GenieConfig
+	genieConfigKey := types.NamespacedName{Namespace: genieConfigNamespace, Name: genieConfigName}
+	if genieConfigName != "" {
+		if configPath != "" {
+			setupLog.Error(nil, "--config and --genie-config-name are mutually exclusive")
+			os.Exit(1)
+		}
+		configClient, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+		if err != nil {
+			setupLog.Error(err, "unable to create client for GenieConfig")
+			os.Exit(1)
+		}
+		if err := configClient.Get(context.Background(), genieConfigKey, &genieConfig); err != nil {
+			setupLog.Error(err, "unable to read GenieConfig", "genieConfig", genieConfigKey)
+			os.Exit(1)
+		}
+		if cfg, err = config.Parse(genieConfig.Spec.Config.Raw); err != nil {
+			setupLog.Error(err, "unable to load configuration", "genieConfig", genieConfigKey)
+			os.Exit(1)
+		}
+	} else {
+		var err error
+		if cfg, err = config.Load(configPath); err != nil {
+			setupLog.Error(err, "unable to load configuration", "path", configPath)
+			os.Exit(1)
+		}
+	}
+	if debounceWindow > 0 {
+		cfg.Debounce.Window.Duration = debounceWindow
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -136,6 +262,17 @@ func main() {
 		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
 	}
 
+	// The state API is served with the authn/authz of the metrics endpoint. It is filled in once
+	// the store and pod reconciler exist, before the manager starts serving.
+	stateAPI := &stateapi.API{}
+	if cfg.StateAPI.Enabled {
+		if !secureMetrics || metricsAddr == "0" {
+			setupLog.Error(nil, "stateAPI requires the metrics endpoint to be served with --metrics-secure")
+			os.Exit(1)
+		}
+		metricsServerOptions.ExtraHandlers = stateAPI.Handlers()
+	}
+
 	// If the certificate is not specified, controller-runtime will automatically
 	// generate self-signed certificates for the metrics server. While convenient for development and testing,
 	// this setup is not recommended for production.
@@ -153,46 +290,752 @@ func main() {
 		metricsServerOptions.KeyName = metricsCertKey
 	}
 
+	// Cached objects are trimmed to what alerting reads, so memory scales with the cluster size
+	// rather than the size of pod specs
+	cacheOptions := cache.Options{
+		DefaultTransform: controller.TrimCachedObject,
+		ByObject:         make(map[client.Object]cache.ByObject),
+	}
+	// Only cache the single Secret holding Slack credentials, rather than every Secret in the cluster
+	slackSecretKey := types.NamespacedName{Namespace: slackSecretNamespace, Name: slackSecretName}
+	if slackSecretName != "" {
+		cacheOptions.ByObject[&corev1.Secret{}] = cache.ByObject{
+			Namespaces: map[string]cache.Config{slackSecretNamespace: {}},
+			Field:      fields.OneTermEqualSelector("metadata.name", slackSecretName),
+		}
+	}
+	if len(cfg.Watch.Namespaces) > 0 {
+		cacheOptions.DefaultNamespaces = make(map[string]cache.Config)
+		for _, namespace := range cfg.Watch.Namespaces {
+			cacheOptions.DefaultNamespaces[namespace] = cache.Config{}
+		}
+		// The GenieConfig is read from the operator's namespace even if it is not watched
+		if genieConfigName != "" {
+			cacheOptions.ByObject[&geniev1alpha1.GenieConfig{}] = cache.ByObject{
+				Namespaces: map[string]cache.Config{genieConfigNamespace: {}},
+			}
+		}
+	}
+	if cfg.Watch.PodLabelSelector != "" || cfg.Watch.PodFieldSelector != "" {
+		// Validated with the configuration
+		podLabels, _ := labels.Parse(cfg.Watch.PodLabelSelector)
+		podFields, _ := fields.ParseSelector(cfg.Watch.PodFieldSelector)
+		cacheOptions.ByObject[&corev1.Pod{}] = cache.ByObject{Label: podLabels, Field: podFields}
+	}
+
+	// Event-driven detection relies on the watch stream alone, so disable the periodic resync
+	if cfg.Detection.Mode == config.DetectionEventDriven {
+		syncPeriod := eventDrivenSyncPeriod
+		cacheOptions.SyncPeriod = &syncPeriod
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  cacheOptions,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "f1e63bc4.slackgenie.io",
-		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
-		// when the Manager ends. This requires the binary to immediately end when the
-		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
-		// speeds up voluntary leader transitions as the new leader don't have to wait
-		// LeaseDuration time first.
-		//
-		// In the default scaffold provided, the program ends immediately after
-		// the manager stops, so would be fine to enable this option. However,
-		// if you are doing or is intended to do any operation such as perform cleanups
-		// after the manager stops then its usage might be unsafe.
-		// LeaderElectionReleaseOnCancel: true,
+		// The leader steps down as soon as the manager stops, so a rolling update hands over to
+		// a standby without waiting out the lease. This is safe because the program ends right
+		// after the manager stops, and alert state is written through to the state store.
+		LeaderElectionReleaseOnCancel: true,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
-	// Initialize Slack notifier
-	slackNotifier, err := slack.NewNotifier(setupLog)
+	// Dry-run messages are logged as JSON whatever the log format, so they can be inspected with jq
+	var dryRunLog logr.Logger
+	if dryRun {
+		dryRunLog = zap.New(zap.UseFlagOptions(&opts), zap.JSONEncoder()).WithName("dry-run")
+		setupLog.Info("dry run: messages are logged instead of sent")
+	}
+	// Decisions are logged as JSON whatever the log format, for the same reason
+	var decisionLog logr.Logger
+	if logDecisions {
+		decisionLog = zap.New(zap.UseFlagOptions(&opts), zap.JSONEncoder()).WithName("decision")
+	}
+	var slackProxyURL *url.URL
+	if slackProxy != "" {
+		parsed, err := url.Parse(slackProxy)
+		if err != nil || parsed.Host == "" {
+			setupLog.Error(err, "invalid --slack-proxy, expected a URL such as http://proxy:3128")
+			os.Exit(1)
+		}
+		slackProxyURL = parsed
+		setupLog.Info("sending Slack requests through proxy", "proxy", slackProxyURL.Redacted())
+	}
+
+	// Initialize the notifiers selected in the configuration
+	var sinks []notify.Sink
+	var slackNotifier *slack.Notifier
+	for _, name := range cfg.EnabledNotifiers() {
+		sinkCfg := cfg.Sinks[name]
+		tlsOpts := notify.TLSOptions{
+			CAFile:   sinkCfg.TLS.CAFile,
+			CertFile: sinkCfg.TLS.CertFile,
+			KeyFile:  sinkCfg.TLS.KeyFile,
+		}
+		if sinkCfg.TLS.MinVersion == "1.3" {
+			tlsOpts.MinVersion = tls.VersionTLS13
+		}
+
+		var sender notify.Notifier
+		switch name {
+		case config.NotifierTeams:
+			teamsNotifier, err := teams.NewNotifier(setupLog)
+			if err != nil {
+				setupLog.Error(err, "unable to initialize Teams notifier")
+				os.Exit(1)
+			}
+			sender = teamsNotifier
+		case config.NotifierDiscord:
+			discordNotifier, err := discord.NewNotifier(setupLog)
+			if err != nil {
+				setupLog.Error(err, "unable to initialize Discord notifier")
+				os.Exit(1)
+			}
+			sender = discordNotifier
+		case config.NotifierPagerDuty:
+			pagerDutyNotifier, err := pagerduty.NewNotifier(setupLog)
+			if err != nil {
+				setupLog.Error(err, "unable to initialize PagerDuty notifier")
+				os.Exit(1)
+			}
+			sender = pagerDutyNotifier
+		case config.NotifierWebhook:
+			webhookNotifier, err := httpwebhook.NewNotifier(setupLog, httpwebhook.Options{
+				URL:          cfg.Webhook.URL,
+				Method:       cfg.Webhook.Method,
+				Headers:      cfg.Webhook.Headers,
+				Template:     cfg.Webhook.Template,
+				MetaTemplate: cfg.Webhook.MetaTemplate,
+			})
+			if err != nil {
+				setupLog.Error(err, "unable to initialize webhook notifier")
+				os.Exit(1)
+			}
+			sender = webhookNotifier
+		case config.NotifierEmail:
+			emailNotifier, err := email.NewNotifier(setupLog, email.Options{
+				Host:                cfg.Email.Host,
+				Port:                cfg.Email.Port,
+				TLS:                 cfg.Email.TLS,
+				From:                cfg.Email.From,
+				To:                  cfg.Email.To,
+				NamespaceRecipients: cfg.Email.Namespaces,
+			})
+			if err != nil {
+				setupLog.Error(err, "unable to initialize email notifier")
+				os.Exit(1)
+			}
+			sender = emailNotifier
+		case config.NotifierTelegram:
+			telegramNotifier, err := telegram.NewNotifier(setupLog)
+			if err != nil {
+				setupLog.Error(err, "unable to initialize Telegram notifier")
+				os.Exit(1)
+			}
+			sender = telegramNotifier
+		case config.NotifierMattermost:
+			mattermostNotifier, err := mattermost.NewNotifier(setupLog)
+			if err != nil {
+				setupLog.Error(err, "unable to initialize Mattermost notifier")
+				os.Exit(1)
+			}
+			sender = mattermostNotifier
+		case config.NotifierGoogleChat:
+			googleChatNotifier, err := googlechat.NewNotifier(setupLog)
+			if err != nil {
+				setupLog.Error(err, "unable to initialize Google Chat notifier")
+				os.Exit(1)
+			}
+			sender = googleChatNotifier
+		case config.NotifierFile:
+			fileNotifier, err := filesink.NewNotifier(setupLog, cfg.File.Path)
+			if err != nil {
+				setupLog.Error(err, "unable to initialize file notifier")
+				os.Exit(1)
+			}
+			sender = fileNotifier
+		case config.NotifierSNS:
+			severityTopics := make(map[notify.Severity]string, len(cfg.SNS.Severities))
+			for severity, topic := range cfg.SNS.Severities {
+				severityTopics[notify.Severity(severity)] = topic
+			}
+			snsNotifier, err := sns.NewNotifier(setupLog, sns.Options{
+				TopicARN:   cfg.SNS.TopicARN,
+				Namespaces: cfg.SNS.Namespaces,
+				Severities: severityTopics,
+				Region:     cfg.SNS.Region,
+			})
+			if err != nil {
+				setupLog.Error(err, "unable to initialize SNS notifier")
+				os.Exit(1)
+			}
+			sender = snsNotifier
+		case config.NotifierCloudEvents:
+			cloudEventsNotifier, err := cloudevents.NewNotifier(setupLog, cloudevents.Options{
+				URL:     cfg.CloudEvents.URL,
+				Source:  cfg.CloudEvents.Source,
+				Mode:    cfg.CloudEvents.Mode,
+				Headers: cfg.CloudEvents.Headers,
+			})
+			if err != nil {
+				setupLog.Error(err, "unable to initialize CloudEvents notifier")
+				os.Exit(1)
+			}
+			sender = cloudEventsNotifier
+		case config.NotifierKafka:
+			kafkaOpts := kafka.Options{
+				Brokers: cfg.Kafka.Brokers,
+				Topic:   cfg.Kafka.Topic,
+				SASL:    cfg.Kafka.SASL,
+			}
+			if cfg.Kafka.TLS {
+				tlsConfig, err := notify.NewTLSConfig(tlsOpts)
+				if err != nil {
+					setupLog.Error(err, "unable to set up Kafka TLS")
+					os.Exit(1)
+				}
+				kafkaOpts.TLS = tlsConfig
+			}
+			kafkaNotifier, err := kafka.NewNotifier(setupLog, kafkaOpts)
+			if err != nil {
+				setupLog.Error(err, "unable to initialize Kafka notifier")
+				os.Exit(1)
+			}
+			sender = kafkaNotifier
+		default:
+			slackCreds := slack.CredentialsFromEnv()
+			if slackSecretName != "" {
+				var secret corev1.Secret
+				if err := mgr.GetAPIReader().Get(context.Background(), slackSecretKey, &secret); err != nil {
+					setupLog.Error(err, "unable to read Slack credentials Secret", "secret", slackSecretKey)
+					os.Exit(1)
+				}
+				slackCreds = slack.CredentialsFromSecret(&secret)
+			}
+
+			var err error
+			slackNotifier, err = slack.NewNotifierWithCredentials(setupLog, slackCreds)
+			if err != nil {
+				setupLog.Error(err, "unable to initialize Slack notifier")
+				os.Exit(1)
+			}
+			sender = slackNotifier
+
+			if cfg.Experiment.Name != "" {
+				experiment, err := slack.NewExperiment(cfg.Experiment.Name, cfg.Experiment.Percentage, cfg.Experiment.Template)
+				if err != nil {
+					setupLog.Error(err, "unable to set up alert template experiment")
+					os.Exit(1)
+				}
+				slackNotifier.SetExperiment(experiment)
+				if err := mgr.Add(manager.RunnableFunc(slackNotifier.RunAckPoller)); err != nil {
+					setupLog.Error(err, "unable to set up experiment acknowledgement poller")
+					os.Exit(1)
+				}
+			}
+
+			if slackSecretName != "" {
+				if err := (&controller.SlackSecretReconciler{
+					Client:        mgr.GetClient(),
+					SlackNotifier: slackNotifier,
+					SecretKey:     slackSecretKey,
+				}).SetupWithManager(mgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "SlackSecret")
+					os.Exit(1)
+				}
+			}
+		}
+
+		var proxyURL *url.URL
+		if name == config.NotifierSlack {
+			proxyURL = slackProxyURL
+		}
+		if transportSetter, ok := sender.(notify.TransportSetter); ok && (proxyURL != nil || sinkCfg.TLS != (config.SinkTLSConfig{})) {
+			transport, err := notify.NewTransport(tlsOpts, proxyURL)
+			if err != nil {
+				setupLog.Error(err, "unable to set up notifier transport", "notifier", name)
+				os.Exit(1)
+			}
+			transportSetter.SetTransport(transport)
+		}
+
+		if dryRun {
+			if transportSetter, ok := sender.(notify.TransportSetter); ok {
+				transportSetter.SetTransport(&notify.DryRunTransport{Logger: dryRunLog, Sink: name})
+			} else {
+				sender = &notify.DryRunNotifier{Logger: dryRunLog, Sink: name}
+			}
+		}
+
+		sinks = append(sinks, notify.Sink{
+			Name:     name,
+			Notifier: sender,
+			Route: notify.Route{
+				MinSeverity: notify.Severity(sinkCfg.MinSeverity),
+				Namespaces:  sinkCfg.Namespaces,
+				Reasons:     sinkCfg.Reasons,
+			},
+			Retry: notify.Retry{
+				Attempts: sinkCfg.Retry.Attempts,
+				Backoff:  sinkCfg.Retry.Backoff.Duration,
+			},
+		})
+	}
+	// Initialize the alert state store
+	stateOpts.Cluster = cfg.Cluster
+	stateStore, err := store.New(mgr.GetClient(), mgr.GetAPIReader(), stateOpts)
 	if err != nil {
-		setupLog.Error(err, "unable to initialize Slack notifier")
+		setupLog.Error(err, "unable to initialize state store")
+		os.Exit(1)
+	}
+	if enableLeaderElection && (stateOpts.Backend == "" || stateOpts.Backend == store.BackendMemory) {
+		setupLog.Info("leader election is enabled with the memory state store; a new leader alerts again " +
+			"on pods that are still failing, use a persistent --state-store to hand over alert state")
+	}
+	// A new leader reads the alert state its predecessor persisted, not what it loaded as a standby
+	if reloader, ok := stateStore.(store.Reloader); ok {
+		if err := mgr.Add(manager.RunnableFunc(func(context.Context) error {
+			reloader.Reload()
+			return nil
+		})); err != nil {
+			setupLog.Error(err, "unable to set up state store reload")
+			os.Exit(1)
+		}
+	}
+	if migrateState {
+		migrator, ok := stateStore.(store.KeyMigrator)
+		if !ok {
+			setupLog.Error(nil, "state store does not support key migration", "backend", stateOpts.Backend)
+			os.Exit(1)
+		}
+		result, err := migrator.MigrateKeys(context.Background())
+		if err != nil {
+			setupLog.Error(err, "unable to migrate state")
+			os.Exit(1)
+		}
+		setupLog.Info("migrated state", "from", result.From, "to", result.To,
+			"migrated", result.Migrated, "dropped", result.Dropped)
+		os.Exit(0)
+	}
+
+	dispatcher := notify.NewDispatcher(ctrl.Log.WithName("notify"), sinks...)
+	dispatcher.LogDecisions(decisionLog)
+	eventRecorder := mgr.GetEventRecorderFor("slackgenie")
+	dispatcher.ObserveDeliveries(&controller.AlertEvents{Client: mgr.GetClient(), Recorder: eventRecorder})
+	if cfg.Audit.Enabled {
+		if cfg.Audit.Namespace == "" {
+			cfg.Audit.Namespace = os.Getenv("POD_NAMESPACE")
+		}
+		if cfg.Audit.Namespace == "" {
+			setupLog.Error(nil, "audit.namespace must be set when POD_NAMESPACE is not")
+			os.Exit(1)
+		}
+		auditTrail := &controller.AuditTrail{
+			Client: mgr.GetClient(),
+			Reader: mgr.GetAPIReader(),
+			Config: cfg.Audit,
+			Logger: ctrl.Log.WithName("audit"),
+		}
+		dispatcher.ObserveDeliveries(auditTrail)
+		if err := mgr.Add(auditTrail); err != nil {
+			setupLog.Error(err, "unable to set up alert audit trail")
+			os.Exit(1)
+		}
+	}
+
+	deadLetters := &controller.DeadLetterRecorder{
+		Client:   mgr.GetClient(),
+		Store:    stateStore,
+		Recorder: eventRecorder,
+		Logger:   ctrl.Log.WithName("deadletter"),
+	}
+	if slackNotifier != nil {
+		// Alerts coalesced by the Slack rate limit are delivered after the queue moved on
+		slackNotifier.SetDeadLetters(deadLetters)
+	}
+
+	// Deliver asynchronously so slow backends do not hold up reconciliation
+	notifier := notify.NewQueue(ctrl.Log.WithName("notify"),
+		dispatcher,
+		notify.QueueOptions{
+			Size:        cfg.Queue.Size,
+			Workers:     cfg.Queue.Workers,
+			Overflow:    cfg.Queue.Overflow,
+			DeadLetters: deadLetters,
+		})
+	if err := mgr.Add(notifier); err != nil {
+		setupLog.Error(err, "unable to set up send queue")
 		os.Exit(1)
 	}
 
-	// Initialize Pod controller with Slack notifier
-	if err := controller.NewPodReconciler(
+	// Initialize Pod controller with the notifier
+	podReconciler := controller.NewPodReconciler(
 		mgr.GetClient(),
 		mgr.GetScheme(),
-		slackNotifier,
-	).SetupWithManager(mgr); err != nil {
+		notifier,
+		stateStore,
+		cfg,
+	)
+	podReconciler.Recorder = eventRecorder
+	podReconciler.Decisions = decisionLog
+	stateAPI.Store, stateAPI.Alerts = stateStore, podReconciler
+
+	if cfg.LogLinks.Enabled {
+		clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+		if err != nil {
+			setupLog.Error(err, "unable to create clientset for log links")
+			os.Exit(1)
+		}
+		logLinks, err := loglink.NewServer(clientset, cfg.LogLinks, []byte(os.Getenv("LOG_LINK_SIGNING_KEY")))
+		if err != nil {
+			setupLog.Error(err, "unable to set up log links")
+			os.Exit(1)
+		}
+		if err := mgr.Add(logLinks); err != nil {
+			setupLog.Error(err, "unable to set up log link server")
+			os.Exit(1)
+		}
+		podReconciler.LogLinks = logLinks
+	}
+
+	if cfg.Cost.URL != "" {
+		podReconciler.Costs = cost.NewClient(cfg.Cost)
+	}
+
+	if cfg.Enrichment.LogTailLines > 0 || cfg.Throttling.Enabled {
+		clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+		if err != nil {
+			setupLog.Error(err, "unable to create clientset")
+			os.Exit(1)
+		}
+		podReconciler.Clientset = clientset
+	}
+	if cfg.Throttling.Enabled {
+		podReconciler.Throttling = controller.NewThrottlingMonitor(mgr.GetClient(), podReconciler.Clientset, cfg.Throttling)
+	}
+
+	var issues *github.Client
+	if cfg.GitHub.Repository != "" {
+		issues = github.NewClient(cfg.GitHub, os.Getenv("GITHUB_TOKEN"))
+		podReconciler.Issues = issues
+	}
+
+	if onCall := cfg.Routing.OnCall; onCall.Provider != "" {
+		token := os.Getenv("PAGERDUTY_API_TOKEN")
+		if onCall.Provider == config.OnCallOpsgenie {
+			token = os.Getenv("OPSGENIE_API_KEY")
+		}
+		// Users missing from the configured map are looked up in Slack when it is enabled
+		var slackUsers oncall.UserLookup
+		if slackNotifier != nil {
+			slackUsers = slackNotifier
+		}
+		podReconciler.OnCall = oncall.NewResolver(onCall, token, slackUsers)
+	}
+
+	// Escalations go to Slack channels only, so other backends do not open duplicate incidents
+	if len(cfg.Escalation.Rules) > 0 && slackNotifier != nil {
+		if err := mgr.Add(&controller.Escalator{
+			Alerts:   podReconciler,
+			Notifier: slackNotifier,
+			Acks:     slackNotifier,
+			Rules:    cfg.Escalation.Rules,
+			Store:    stateStore,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up escalation")
+			os.Exit(1)
+		}
+	}
+
+	silenceManager := &silences.Manager{Store: stateStore}
+	if cfg.Silences.API.Enabled {
+		api := &silences.API{
+			Manager: silenceManager,
+			Client:  mgr.GetClient(),
+			Config:  cfg.Silences.API,
+			TLSOpts: tlsOpts,
+		}
+		// Without certificates of its own, the API shares those of the metrics server
+		switch {
+		case cfg.Silences.API.CertDir != "":
+			api.CertDir, api.CertName, api.KeyName = cfg.Silences.API.CertDir, "tls.crt", "tls.key"
+		case metricsCertPath != "":
+			api.CertDir, api.CertName, api.KeyName = metricsCertPath, metricsCertName, metricsCertKey
+		}
+		if err := mgr.Add(api); err != nil {
+			setupLog.Error(err, "unable to set up silence API")
+			os.Exit(1)
+		}
+	}
+
+	// The App Home tab, compact alert details, the /silence command and the Create issue button
+	// share the Slack app endpoint
+	createIssues := issues != nil && cfg.GitHub.CreateButton
+	if cfg.AppHome.Enabled || (slackNotifier != nil && slackNotifier.CompactMode()) || cfg.Silences.SlashCommand || createIssues {
+		signingSecret := os.Getenv("SLACK_SIGNING_SECRET")
+		if signingSecret == "" {
+			setupLog.Error(nil, "SLACK_SIGNING_SECRET must be set for the App Home tab, compact alerts, the /silence command and the Create issue button")
+			os.Exit(1)
+		}
+
+		slackApp := &slackapp.Server{
+			BindAddress:   cfg.SlackApp.BindAddress,
+			SigningSecret: signingSecret,
+		}
+		if cfg.AppHome.Enabled {
+			slackApp.Home = &slackapp.Home{
+				Alerts:    podReconciler,
+				Publisher: slackNotifier,
+				Store:     stateStore,
+				Config:    cfg.AppHome,
+			}
+		}
+		if slackNotifier != nil && slackNotifier.CompactMode() {
+			clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+			if err != nil {
+				setupLog.Error(err, "unable to create clientset for alert details")
+				os.Exit(1)
+			}
+			slackApp.Details = &slackapp.Details{
+				Clientset: clientset,
+				Slack:     slackNotifier,
+			}
+		}
+		if createIssues {
+			slackApp.Issues = &slackapp.Issues{
+				Creator: issues,
+				Slack:   slackNotifier,
+			}
+		}
+		if cfg.Silences.SlashCommand {
+			slackApp.Commands = &slackapp.Commands{
+				Silences: silenceManager,
+				Users:    cfg.Silences.SlackUsers,
+			}
+		}
+		if err := mgr.Add(slackApp); err != nil {
+			setupLog.Error(err, "unable to set up Slack app server")
+			os.Exit(1)
+		}
+	}
+
+	if cfg.Alertmanager.Silences.Enabled {
+		amSilences := &silences.AlertmanagerSync{
+			Config: cfg.Alertmanager.Silences,
+			Token:  os.Getenv("ALERTMANAGER_API_TOKEN"),
+		}
+		if err := mgr.Add(amSilences); err != nil {
+			setupLog.Error(err, "unable to set up Alertmanager silence sync")
+			os.Exit(1)
+		}
+		podReconciler.ExternalSilences = amSilences
+	}
+	if cfg.Alertmanager.Receiver.Enabled {
+		token := os.Getenv("ALERTMANAGER_WEBHOOK_TOKEN")
+		if token == "" {
+			if !cfg.Alertmanager.Receiver.AllowUnauthenticated {
+				setupLog.Error(nil, "ALERTMANAGER_WEBHOOK_TOKEN is required by the Alertmanager receiver, "+
+					"set alertmanager.receiver.allowUnauthenticated to accept unauthenticated alerts")
+				os.Exit(1)
+			}
+			setupLog.Info("the Alertmanager receiver accepts alerts without authentication")
+		}
+		if err := mgr.Add(&controller.AlertmanagerReceiver{
+			Pods:   podReconciler,
+			Config: cfg.Alertmanager.Receiver,
+			Token:  token,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up Alertmanager receiver")
+			os.Exit(1)
+		}
+	}
+
+	if err := podReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Pod")
 		os.Exit(1)
 	}
+
+	if genieConfigName != "" {
+		if err := (&controller.GenieConfigReconciler{
+			Client:           mgr.GetClient(),
+			Active:           genieConfigKey,
+			ActiveGeneration: genieConfig.Generation,
+			SlackProxy:       slackProxyURL,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "GenieConfig")
+			os.Exit(1)
+		}
+	}
+
+	routeTests := &controller.GenieRouteTestReconciler{
+		Client:   mgr.GetClient(),
+		Config:   cfg,
+		Notifier: podReconciler.Notifier,
+	}
+	if genieConfigName != "" {
+		routeTests.ActiveConfig = genieConfigKey
+	}
+	if err := routeTests.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GenieRouteTest")
+		os.Exit(1)
+	}
+
+	if cfg.Rollbacks.Enabled {
+		if err := (&controller.RollbackReconciler{
+			Client:   mgr.GetClient(),
+			Notifier: notifier,
+			Store:    stateStore,
+			Config:   cfg.Rollbacks,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Rollback")
+			os.Exit(1)
+		}
+	}
+
+	if cfg.Rollouts.Enabled {
+		if err := (&controller.RolloutReconciler{
+			Client:   mgr.GetClient(),
+			Notifier: notifier,
+			Config:   cfg.Rollouts,
+			Routing:  cfg.Routing,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Rollout")
+			os.Exit(1)
+		}
+	}
+
+	if cfg.Jobs.Suppress {
+		if err := (&controller.JobReconciler{
+			Client:   mgr.GetClient(),
+			Notifier: notifier,
+			Routing:  cfg.Routing,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Job")
+			os.Exit(1)
+		}
+	}
+
+	if cfg.CronJobs.Enabled {
+		if err := (&controller.CronJobReconciler{
+			Client:   mgr.GetClient(),
+			Notifier: notifier,
+			Config:   cfg.CronJobs,
+			Routing:  cfg.Routing,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CronJob")
+			os.Exit(1)
+		}
+	}
+
+	if cfg.Nodes.Pressure.Enabled || cfg.Nodes.NotReady.Enabled {
+		if err := (&controller.NodeReconciler{
+			Client:   mgr.GetClient(),
+			Notifier: notifier,
+			Config:   cfg.Nodes,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Node")
+			os.Exit(1)
+		}
+	}
+
+	if cfg.PDBs.Enabled {
+		if err := (&controller.PDBReconciler{
+			Client:   mgr.GetClient(),
+			Notifier: notifier,
+			Config:   cfg.PDBs,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "PodDisruptionBudget")
+			os.Exit(1)
+		}
+	}
+
+	if cfg.Certificates.Enabled {
+		if err := (&controller.CertificateReconciler{
+			Client:   mgr.GetClient(),
+			Notifier: notifier,
+			Config:   cfg.Certificates,
+			Routing:  cfg.Routing,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Certificate")
+			os.Exit(1)
+		}
+	}
+
+	if cfg.Backends.Enabled {
+		if err := (&controller.BackendReconciler{
+			Client:   mgr.GetClient(),
+			Notifier: notifier,
+			Config:   cfg.Backends,
+			Routing:  cfg.Routing,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Backend")
+			os.Exit(1)
+		}
+	}
+
+	if cfg.Finalizers.Enabled {
+		if err := mgr.Add(&controller.FinalizerMonitor{
+			Reader:   mgr.GetAPIReader(),
+			Notifier: notifier,
+			Config:   cfg.Finalizers,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up stuck finalizer monitor")
+			os.Exit(1)
+		}
+	}
+
+	if cfg.Forecast.Enabled {
+		if err := mgr.Add(&forecast.Forecaster{
+			Client:   mgr.GetClient(),
+			Notifier: notifier,
+			Store:    stateStore,
+			Config:   cfg.Forecast,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up capacity forecaster")
+			os.Exit(1)
+		}
+	}
+
+	if cfg.Quotas.Enabled {
+		if err := (&controller.QuotaReconciler{
+			Client:   mgr.GetClient(),
+			Notifier: notifier,
+			Config:   cfg.Quotas,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ResourceQuota")
+			os.Exit(1)
+		}
+	}
+
+	if cfg.QuotaReport.Enabled {
+		if err := mgr.Add(&quota.Reporter{
+			Client:   mgr.GetClient(),
+			Notifier: notifier,
+			Store:    stateStore,
+			Config:   cfg.QuotaReport,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up quota report")
+			os.Exit(1)
+		}
+	}
+
+	if cfg.HistoryExport.Enabled {
+		exporter, err := export.New(stateStore, cfg.HistoryExport)
+		if err == nil {
+			err = mgr.Add(exporter)
+		}
+		if err != nil {
+			setupLog.Error(err, "unable to set up history export")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -203,6 +1046,17 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if cfg.Readiness.Slack.Enabled && slackNotifier != nil {
+		slackCheck := slack.NewReadinessCheck(slackNotifier, cfg.Readiness.Slack.Interval.Duration)
+		if err := mgr.Add(slackCheck); err != nil {
+			setupLog.Error(err, "unable to set up Slack ready check")
+			os.Exit(1)
+		}
+		if err := mgr.AddReadyzCheck("slack", slackCheck.Check); err != nil {
+			setupLog.Error(err, "unable to set up Slack ready check")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
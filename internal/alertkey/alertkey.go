@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package alertkey builds and parses the keys that identify alert incidents in the debounce
+// cache, the persisted state and notifier threads, and converts keys of earlier formats.
+package alertkey
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Version is the current key format. Persisted state records the version its keys were
+// written with, so a format change can be migrated on load.
+const Version = 2
+
+// Owner kinds that are not workloads
+const (
+	// KindPod is the owner of alerts deduplicated per pod
+	KindPod = "Pod"
+	// KindNode is the owner of alerts about a node, such as spot interruptions
+	KindNode = "Node"
+	// KindAlert is the owner of alerts forwarded from Alertmanager, named by their fingerprint
+	KindAlert = "Alert"
+)
+
+// Records kept in the alert state that are not alerts. They are not in the key format, and
+// key migrations keep them as they are.
+const (
+	// QuotaReport records when the last quota report was posted
+	QuotaReport = "quota-report/monthly"
+	// HistoryExport records the end of the last history export
+	HistoryExport = "history-export/last"
+)
+
+// IsRecord reports whether s names one of the records kept in the alert state rather than
+// an alert
+func IsRecord(s string) bool {
+	return s == QuotaReport || s == HistoryExport
+}
+
+// Key identifies an incident: a failure reason of a pod, workload or node in a cluster.
+// Its string form is "v2/<cluster>/<namespace>/<ownerKind>/<ownerName>/<reason>", with each
+// segment path-escaped, so keys of one owner share a prefix no other owner's keys start with.
+type Key struct {
+	Cluster   string
+	Namespace string
+	OwnerKind string
+	OwnerName string
+	Reason    string
+}
+
+// String renders the key
+func (k Key) String() string {
+	return OwnerPrefix(k.Cluster, k.Namespace, k.OwnerKind, k.OwnerName) + url.PathEscape(k.Reason)
+}
+
+// OwnerPrefix returns the prefix shared by every key of an owner
+func OwnerPrefix(cluster, namespace, kind, name string) string {
+	return fmt.Sprintf("v%d/%s/%s/%s/%s/", Version,
+		url.PathEscape(cluster), url.PathEscape(namespace), url.PathEscape(kind), url.PathEscape(name))
+}
+
+// IsWorkload reports whether the key's owner is a workload rather than a pod, node or
+// forwarded alert
+func (k Key) IsWorkload() bool {
+	return k.OwnerKind != KindPod && k.OwnerKind != KindNode && k.OwnerKind != KindAlert
+}
+
+// Parse parses a key of the current format. Suffixes appended to the reason, such as those of
+// escalation keys, are returned as part of the reason.
+func Parse(s string) (Key, error) {
+	parts := strings.SplitN(s, "/", 6)
+	if len(parts) != 6 || parts[0] != fmt.Sprintf("v%d", Version) {
+		return Key{}, fmt.Errorf("alert key %q is not in format v%d", s, Version)
+	}
+
+	var key Key
+	for i, field := range []*string{&key.Cluster, &key.Namespace, &key.OwnerKind, &key.OwnerName, &key.Reason} {
+		value, err := url.PathUnescape(parts[i+1])
+		if err != nil {
+			return Key{}, fmt.Errorf("alert key %q: %w", s, err)
+		}
+		*field = value
+	}
+	return key, nil
+}
+
+// FromV1 converts a key of the first format: "namespace/pod-reason" for pods,
+// "namespace/Kind/name-reason" for workloads and "spot/node/name-reason" for spot
+// interruptions. Names and reasons may both contain dashes, so the reason must be known; it
+// is typically taken from the alert history. ok is false if the key does not end in the reason.
+func FromV1(old, cluster, reason string) (Key, bool) {
+	base, found := strings.CutSuffix(old, "-"+reason)
+	if !found || reason == "" {
+		return Key{}, false
+	}
+
+	parts := strings.Split(base, "/")
+	switch {
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return Key{Cluster: cluster, Namespace: parts[0], OwnerKind: KindPod, OwnerName: parts[1], Reason: reason}, true
+	case len(parts) == 3 && parts[0] == "spot" && parts[1] == "node" && parts[2] != "":
+		return Key{Cluster: cluster, OwnerKind: KindNode, OwnerName: parts[2], Reason: reason}, true
+	case len(parts) == 3 && parts[0] != "" && parts[1] != "" && parts[2] != "":
+		return Key{Cluster: cluster, Namespace: parts[0], OwnerKind: parts[1], OwnerName: parts[2], Reason: reason}, true
+	default:
+		return Key{}, false
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertkey
+
+import "testing"
+
+// TestParse checks that keys round-trip through String and Parse, and that keys of other
+// formats are rejected
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		want    Key
+		wantErr bool
+	}{
+		{
+			name: "pod",
+			key:  "v2/prod/payments/Pod/api-0/CrashLoopBackOff",
+			want: Key{Cluster: "prod", Namespace: "payments", OwnerKind: KindPod, OwnerName: "api-0", Reason: "CrashLoopBackOff"},
+		},
+		{
+			name: "workload",
+			key:  "v2/prod/payments/Deployment/api/OOMKilled",
+			want: Key{Cluster: "prod", Namespace: "payments", OwnerKind: "Deployment", OwnerName: "api", Reason: "OOMKilled"},
+		},
+		{
+			name: "node without namespace or cluster",
+			key:  "v2///Node/ip-10-0-0-1/SpotInterruption",
+			want: Key{OwnerKind: KindNode, OwnerName: "ip-10-0-0-1", Reason: "SpotInterruption"},
+		},
+		{
+			name: "escaped segments",
+			key:  "v2/eu%2Fwest/payments/Pod/api-0/Init%2FError",
+			want: Key{Cluster: "eu/west", Namespace: "payments", OwnerKind: KindPod, OwnerName: "api-0", Reason: "Init/Error"},
+		},
+		{
+			name: "escalation suffix stays in the reason",
+			key:  "v2/prod/payments/Pod/api-0/CrashLoopBackOff-escalation-0",
+			want: Key{Cluster: "prod", Namespace: "payments", OwnerKind: KindPod, OwnerName: "api-0", Reason: "CrashLoopBackOff-escalation-0"},
+		},
+		{name: "first format", key: "payments/api-0-CrashLoopBackOff", wantErr: true},
+		{name: "other version", key: "v3/prod/payments/Pod/api-0/CrashLoopBackOff", wantErr: true},
+		{name: "too few segments", key: "v2/prod/payments/Pod/api-0", wantErr: true},
+		{name: "invalid escape", key: "v2/prod/payments/Pod/api%zz/CrashLoopBackOff", wantErr: true},
+		{name: "record", key: QuotaReport, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.key)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %+v, want an error", tt.key, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.key, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.key, got, tt.want)
+			}
+			if got.String() != tt.key {
+				t.Errorf("String() = %q, want %q", got.String(), tt.key)
+			}
+		})
+	}
+}
+
+// TestFromV1 checks the conversion of keys of the first format for every owner, including
+// names that contain dashes or the reason itself
+func TestFromV1(t *testing.T) {
+	tests := []struct {
+		name   string
+		old    string
+		reason string
+		want   Key
+		wantOK bool
+	}{
+		{
+			name:   "pod",
+			old:    "payments/api-7d9f-x2x-CrashLoopBackOff",
+			reason: "CrashLoopBackOff",
+			want:   Key{Cluster: "prod", Namespace: "payments", OwnerKind: KindPod, OwnerName: "api-7d9f-x2x", Reason: "CrashLoopBackOff"},
+			wantOK: true,
+		},
+		{
+			name:   "workload",
+			old:    "payments/Deployment/api-OOMKilled",
+			reason: "OOMKilled",
+			want:   Key{Cluster: "prod", Namespace: "payments", OwnerKind: "Deployment", OwnerName: "api", Reason: "OOMKilled"},
+			wantOK: true,
+		},
+		{
+			name:   "spot interruption",
+			old:    "spot/node/ip-10-0-0-1-SpotInterruption",
+			reason: "SpotInterruption",
+			want:   Key{Cluster: "prod", OwnerKind: KindNode, OwnerName: "ip-10-0-0-1", Reason: "SpotInterruption"},
+			wantOK: true,
+		},
+		{
+			name:   "name containing the reason",
+			old:    "payments/Error-Error",
+			reason: "Error",
+			want:   Key{Cluster: "prod", Namespace: "payments", OwnerKind: KindPod, OwnerName: "Error", Reason: "Error"},
+			wantOK: true,
+		},
+		{
+			name:   "reason containing a dash",
+			old:    "payments/api-0-InitContainer-CrashLoopBackOff",
+			reason: "InitContainer-CrashLoopBackOff",
+			want:   Key{Cluster: "prod", Namespace: "payments", OwnerKind: KindPod, OwnerName: "api-0", Reason: "InitContainer-CrashLoopBackOff"},
+			wantOK: true,
+		},
+		{name: "other reason", old: "payments/api-0-OOMKilled", reason: "CrashLoopBackOff"},
+		{name: "empty reason", old: "payments/api-0-", reason: ""},
+		{name: "empty name", old: "payments/-CrashLoopBackOff", reason: "CrashLoopBackOff"},
+		{name: "no namespace", old: "api-0-CrashLoopBackOff", reason: "CrashLoopBackOff"},
+		{name: "too many segments", old: "a/b/c/api-0-CrashLoopBackOff", reason: "CrashLoopBackOff"},
+		{name: "record", old: QuotaReport, reason: "monthly"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FromV1(tt.old, "prod", tt.reason)
+			if ok != tt.wantOK {
+				t.Fatalf("FromV1(%q, %q) ok = %v, want %v", tt.old, tt.reason, ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("FromV1(%q, %q) = %+v, want %+v", tt.old, tt.reason, got, tt.want)
+			}
+		})
+	}
+}
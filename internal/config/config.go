@@ -0,0 +1,1733 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config loads the operator configuration file.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+)
+
+// Supported notifiers
+const (
+	NotifierSlack       = "slack"
+	NotifierTeams       = "teams"
+	NotifierDiscord     = "discord"
+	NotifierPagerDuty   = "pagerduty"
+	NotifierWebhook     = "webhook"
+	NotifierEmail       = "email"
+	NotifierTelegram    = "telegram"
+	NotifierMattermost  = "mattermost"
+	NotifierFile        = "file"
+	NotifierKafka       = "kafka"
+	NotifierCloudEvents = "cloudevents"
+	NotifierSNS         = "sns"
+	NotifierGoogleChat  = "googlechat"
+)
+
+// Config is the operator configuration, typically mounted from a ConfigMap
+type Config struct {
+	// Cluster names the cluster in alert keys, so alerts of several clusters sharing a state
+	// store or a notifier stay apart. It may be empty for a single cluster.
+	Cluster string `json:"cluster,omitempty"`
+
+	// Notifier selects a single notification backend: slack (default), teams, discord, pagerduty, webhook,
+	// email, telegram, mattermost or file.
+	// Deprecated: use Notifiers.
+	Notifier string `json:"notifier,omitempty"`
+
+	// Notifiers selects one or more notification backends; every alert is sent to each of them
+	Notifiers []string `json:"notifiers,omitempty"`
+
+	// Sinks restricts which alerts each notifier receives and how failed deliveries are retried,
+	// keyed by notifier name. Notifiers without an entry receive every alert.
+	Sinks map[string]SinkConfig `json:"sinks,omitempty"`
+
+	// Queue configures asynchronous delivery of alerts
+	Queue QueueConfig `json:"queue,omitempty"`
+
+	// Detection selects how failing pods are re-examined
+	Detection DetectionConfig `json:"detection,omitempty"`
+
+	// Watch restricts which pods the operator watches, to bound its memory in large clusters
+	Watch WatchConfig `json:"watch,omitempty"`
+
+	// Readiness adds checks of the notification backends to the readiness probe
+	Readiness ReadinessConfig `json:"readiness,omitempty"`
+
+	// Audit records every sent alert as an AlertRecord resource
+	Audit AuditConfig `json:"audit,omitempty"`
+
+	// StateAPI serves alert history and state as JSON on the metrics endpoint
+	StateAPI StateAPIConfig `json:"stateAPI,omitempty"`
+
+	// HistoryExport periodically exports alert history to a directory or an S3-compatible bucket
+	HistoryExport HistoryExportConfig `json:"historyExport,omitempty"`
+
+	// Startup controls alerts for pods that are already failing when the operator starts
+	Startup StartupConfig `json:"startup,omitempty"`
+
+	// Debounce controls how long repeat alerts for the same pod and reason are suppressed
+	Debounce DebounceConfig `json:"debounce,omitempty"`
+
+	// Reminders re-alert for pods that are still failing on an escalating schedule
+	Reminders RemindersConfig `json:"reminders,omitempty"`
+
+	// Escalation re-sends alerts nobody acknowledged or resolved to escalation channels
+	Escalation EscalationConfig `json:"escalation,omitempty"`
+
+	// Throttling alerts when heavy CPU throttling coincides with liveness probe failures
+	Throttling ThrottlingConfig `json:"throttling,omitempty"`
+
+	// Pending alerts on pods that stay Pending too long, e.g. waiting on a volume attach
+	Pending PendingConfig `json:"pending,omitempty"`
+
+	// Probes alerts on containers failing their liveness or readiness probes
+	Probes ProbesConfig `json:"probes,omitempty"`
+
+	// Terminating alerts on pods stuck terminating past their grace period
+	Terminating TerminatingConfig `json:"terminating,omitempty"`
+
+	// Rollbacks posts a follow-up when a Deployment is rolled back after failure alerts
+	Rollbacks RollbacksConfig `json:"rollbacks,omitempty"`
+
+	// Rollouts alerts when a Deployment rollout exceeds its progress deadline
+	Rollouts RolloutsConfig `json:"rollouts,omitempty"`
+
+	// CronJobs alerts when a CronJob misses its scheduled runs
+	CronJobs CronJobsConfig `json:"cronJobs,omitempty"`
+
+	// Finalizers flags resources stuck deleting because of their finalizers
+	Finalizers FinalizersConfig `json:"finalizers,omitempty"`
+
+	// PDBs alerts on PodDisruptionBudgets that block voluntary disruptions such as node drains
+	PDBs PDBsConfig `json:"pdbs,omitempty"`
+
+	// Certificates alerts on cert-manager Certificates that are failing or about to expire
+	Certificates CertificatesConfig `json:"certificates,omitempty"`
+
+	// Backends alerts when Services behind an Ingress lose all their ready endpoints
+	Backends BackendsConfig `json:"backends,omitempty"`
+
+	// Nodes alerts on node conditions that affect every pod on the node
+	Nodes NodesConfig `json:"nodes,omitempty"`
+
+	// Webhook configures the generic templated webhook notifier
+	Webhook WebhookConfig `json:"webhook,omitempty"`
+
+	// Email configures the SMTP email notifier
+	Email EmailConfig `json:"email,omitempty"`
+
+	// File configures the JSON lines file notifier
+	File FileConfig `json:"file,omitempty"`
+
+	// Kafka configures the notifier publishing alerts to a Kafka topic
+	Kafka KafkaConfig `json:"kafka,omitempty"`
+
+	// CloudEvents configures the notifier sending alerts as CloudEvents over HTTP
+	CloudEvents CloudEventsConfig `json:"cloudEvents,omitempty"`
+
+	// SNS configures the notifier publishing alerts to Amazon SNS topics
+	SNS SNSConfig `json:"sns,omitempty"`
+
+	// SlackApp configures the endpoint receiving Slack Events API and interactivity requests
+	SlackApp SlackAppConfig `json:"slackApp,omitempty"`
+
+	// AppHome configures the Slack App Home tab
+	AppHome AppHomeConfig `json:"appHome,omitempty"`
+
+	// Silences configures batch silence management over HTTP and the /silence slash command
+	Silences SilencesConfig `json:"silences,omitempty"`
+
+	// Alertmanager configures the Prometheus Alertmanager integration
+	Alertmanager AlertmanagerConfig `json:"alertmanager,omitempty"`
+
+	// Chaos controls how alerts caused by running chaos experiments are handled
+	Chaos ChaosConfig `json:"chaos,omitempty"`
+
+	// Jobs controls how failures of pods owned by Jobs are handled
+	Jobs JobsConfig `json:"jobs,omitempty"`
+
+	// Spot controls how failures caused by spot node interruptions are handled
+	Spot SpotConfig `json:"spot,omitempty"`
+
+	// Drain controls how failures of pods stopped by the drain of a cordoned node are handled
+	Drain DrainConfig `json:"drain,omitempty"`
+
+	// Routing controls which channel and team alerts are attributed to
+	Routing RoutingConfig `json:"routing,omitempty"`
+
+	// Forecast configures predictive capacity warnings
+	Forecast ForecastConfig `json:"forecast,omitempty"`
+
+	// QuotaReport configures the monthly namespace quota utilization report
+	QuotaReport QuotaReportConfig `json:"quotaReport,omitempty"`
+
+	// Quotas alerts when ResourceQuotas near their hard limits or reject pods
+	Quotas QuotasConfig `json:"quotas,omitempty"`
+
+	// Experiment configures an A/B test of the Slack alert message template
+	Experiment ExperimentConfig `json:"experiment,omitempty"`
+
+	// Enrichment caps the context attached to each alert
+	Enrichment EnrichmentConfig `json:"enrichment,omitempty"`
+
+	// LogLinks configures signed links to recent container logs in alerts
+	LogLinks LogLinksConfig `json:"logLinks,omitempty"`
+
+	// Dashboards adds links to dashboards showing the failing pod, e.g. in Grafana or Kibana,
+	// to alerts
+	Dashboards []DashboardConfig `json:"dashboards,omitempty"`
+
+	// Cost adds workload cost from OpenCost or Kubecost to critical alerts
+	Cost CostConfig `json:"cost,omitempty"`
+
+	// GitHub links alerts to open GitHub issues tracking the same failure
+	GitHub GitHubConfig `json:"github,omitempty"`
+}
+
+// Ways of handling alerts for failures with a known GitHub issue
+const (
+	// KnownIssueLink sends the alert with a link to the issue
+	KnownIssueLink = "link"
+	// KnownIssueSuppress drops the alert
+	KnownIssueSuppress = "suppress"
+)
+
+// GitHubConfig points at the GitHub repository tracking known failures
+type GitHubConfig struct {
+	// Repository in owner/name form. Empty disables issue lookups.
+	Repository string `json:"repository,omitempty"`
+	// APIURL is the GitHub API endpoint, e.g. https://github.example.com/api/v3 for GitHub
+	// Enterprise Server (default https://api.github.com)
+	APIURL string `json:"apiURL,omitempty"`
+	// KnownIssues is link (default) or suppress
+	KnownIssues string `json:"knownIssues,omitempty"`
+	// CreateButton offers a button on Slack alerts without a known issue that opens one
+	CreateButton bool `json:"createButton,omitempty"`
+	// Labels are added to issues created from Slack
+	Labels []string `json:"labels,omitempty"`
+}
+
+// CostConfig points at the allocation API of OpenCost or Kubecost
+type CostConfig struct {
+	// URL of the OpenCost API, e.g. http://opencost.opencost:9003, or of the Kubecost model API,
+	// e.g. http://kubecost-cost-analyzer.kubecost:9090/model. Empty disables cost context.
+	URL string `json:"url,omitempty"`
+	// Window is the period the hourly cost is averaged over (default 24h)
+	Window metav1.Duration `json:"window,omitempty"`
+}
+
+// EnrichmentConfig limits the size of the context (logs, events, describe output) attached to
+// an alert, in total and per source
+type EnrichmentConfig struct {
+	// MaxBytes is the hard cap on all enrichment of a single alert (default 2048). Slack alerts
+	// are cut at 3000 characters, so larger caps only benefit other notifiers.
+	MaxBytes int `json:"maxBytes,omitempty"`
+	// Budgets are the per-source caps within MaxBytes
+	Budgets EnrichmentBudgets `json:"budgets,omitempty"`
+	// LogTailLines attaches the last lines of the failing container's log to alerts; 0 disables
+	LogTailLines int64 `json:"logTailLines,omitempty"`
+	// RecentEvents attaches the pod's most recent warning events to alerts; 0 disables
+	RecentEvents int `json:"recentEvents,omitempty"`
+}
+
+// EnrichmentBudgets are byte budgets per enrichment source
+type EnrichmentBudgets struct {
+	// Logs caps container log output; the tail is kept
+	Logs int `json:"logs,omitempty"`
+	// Events caps Kubernetes events; the newest are kept
+	Events int `json:"events,omitempty"`
+	// Describe caps resource descriptions; the beginning is kept
+	Describe int `json:"describe,omitempty"`
+}
+
+// DashboardConfig is a dashboard link added to alerts
+type DashboardConfig struct {
+	// Name labels the link, e.g. "Grafana"
+	Name string `json:"name"`
+	// URL is a Go text/template rendering the link from the alert, e.g.
+	// https://grafana.example.com/d/pods?var-namespace={{.Namespace}}&var-pod={{.Pod}}&from={{.From}}&to={{.To}}
+	URL string `json:"url"`
+}
+
+// LogLinksConfig configures the HTTP endpoint serving container logs behind signed URLs
+type LogLinksConfig struct {
+	// Enabled adds a log link to every Slack alert. Links are signed with the key in
+	// LOG_LINK_SIGNING_KEY, at least 32 bytes, which is required: every replica must use the
+	// same key, and keep it across restarts, to serve links signed by another.
+	Enabled bool `json:"enabled,omitempty"`
+	// ExternalURL is the base URL responders reach the endpoint on, e.g. through an ingress
+	ExternalURL string `json:"externalURL,omitempty"`
+	// BindAddress is the address the endpoint listens on
+	BindAddress string `json:"bindAddress,omitempty"`
+	// TailLines is the number of log lines served
+	TailLines int64 `json:"tailLines,omitempty"`
+	// TTL is how long a link stays valid
+	TTL metav1.Duration `json:"ttl,omitempty"`
+}
+
+// SlackAppConfig configures the endpoint serving the App Home tab, the details of compact
+// alerts and the /silence slash command. It runs when any of them is enabled.
+type SlackAppConfig struct {
+	// BindAddress is the address the endpoint listens on
+	BindAddress string `json:"bindAddress,omitempty"`
+}
+
+// AppHomeConfig configures the Slack App Home tab
+type AppHomeConfig struct {
+	// Enabled publishes the App Home tab when a user opens it
+	Enabled bool `json:"enabled,omitempty"`
+	// Teams maps the team names used by ownership rules to the Slack user IDs of their
+	// members. Users in a team only see alerts for their teams; everyone else sees all alerts.
+	Teams map[string][]string `json:"teams,omitempty"`
+	// SilenceDuration is how long the silence quick action mutes an alert
+	SilenceDuration metav1.Duration `json:"silenceDuration,omitempty"`
+}
+
+// SilencesConfig configures programmatic silence management
+type SilencesConfig struct {
+	// API serves batch silence management over HTTP
+	API SilenceAPIConfig `json:"api,omitempty"`
+	// SlashCommand answers the /silence slash command on the Slack app endpoint
+	SlashCommand bool `json:"slashCommand,omitempty"`
+	// SlackUsers are the Slack user IDs allowed to use the slash command. Empty allows everyone
+	// in the workspace.
+	SlackUsers []string `json:"slackUsers,omitempty"`
+}
+
+// SilenceAPIConfig configures the silence API endpoint
+type SilenceAPIConfig struct {
+	// Enabled serves the API
+	Enabled bool `json:"enabled,omitempty"`
+	// BindAddress is the address the endpoint listens on
+	BindAddress string `json:"bindAddress,omitempty"`
+	// CertDir holds the serving certificate tls.crt and its key tls.key. The API is always
+	// served over TLS; without certificates it uses those of the metrics server, or a
+	// self-signed certificate.
+	CertDir string `json:"certDir,omitempty"`
+	// Audience is the audience caller tokens must be issued for, so tokens minted for the API
+	// cannot be replayed against the API server
+	Audience string `json:"audience,omitempty"`
+}
+
+// DefaultSilenceAPIAudience is the default audience of silence API tokens
+const DefaultSilenceAPIAudience = "slackgenie-silences"
+
+// AlertmanagerConfig configures the Prometheus Alertmanager integration
+type AlertmanagerConfig struct {
+	// Receiver serves an Alertmanager webhook receiver endpoint
+	Receiver AlertmanagerReceiverConfig `json:"receiver,omitempty"`
+	// Silences suppresses pod alerts matching the active silences of an Alertmanager
+	Silences AlertmanagerSilencesConfig `json:"silences,omitempty"`
+}
+
+// StateAPIConfig configures the read-only state API
+type StateAPIConfig struct {
+	// Enabled serves the API under /api/v1 on the metrics endpoint
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// History export formats
+const (
+	ExportFormatJSON = "json"
+	ExportFormatCSV  = "csv"
+)
+
+// HistoryExportConfig configures the periodic export of alert history for long-term retention
+type HistoryExportConfig struct {
+	// Enabled turns on the export
+	Enabled bool `json:"enabled,omitempty"`
+	// Interval is how often new history entries are exported (default 1h)
+	Interval metav1.Duration `json:"interval,omitempty"`
+	// Format is json (JSON Lines, the default) or csv
+	Format string `json:"format,omitempty"`
+	// Path is a directory the exports are written to, e.g. a mounted PersistentVolumeClaim
+	Path string `json:"path,omitempty"`
+	// S3 uploads the exports to an S3-compatible bucket instead of a directory
+	S3 *S3ExportConfig `json:"s3,omitempty"`
+	// Retention deletes exports older than this; zero keeps them forever
+	Retention metav1.Duration `json:"retention,omitempty"`
+}
+
+// S3ExportConfig configures uploads to an S3-compatible bucket. Credentials are read from the
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables.
+type S3ExportConfig struct {
+	// Bucket is the bucket name
+	Bucket string `json:"bucket"`
+	// Region is the bucket's region (default us-east-1)
+	Region string `json:"region,omitempty"`
+	// Endpoint is the URL of an S3-compatible service, e.g. https://storage.googleapis.com or a
+	// MinIO server. Empty means AWS S3. Custom endpoints are addressed path-style.
+	Endpoint string `json:"endpoint,omitempty"`
+	// Prefix is prepended to the object names, e.g. slackgenie/prod/
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// AuditConfig configures the audit trail of sent alerts
+type AuditConfig struct {
+	// Enabled creates an AlertRecord for every alert sent
+	Enabled bool `json:"enabled,omitempty"`
+	// Namespace the records are created in (default: the operator's namespace)
+	Namespace string `json:"namespace,omitempty"`
+	// Retention is how long records are kept (default 720h)
+	Retention metav1.Duration `json:"retention,omitempty"`
+}
+
+// ReadinessConfig configures the checks of the readiness probe
+type ReadinessConfig struct {
+	// Slack reports the operator unready while Slack rejects its credentials
+	Slack SlackReadinessConfig `json:"slack,omitempty"`
+}
+
+// SlackReadinessConfig configures the Slack readiness check
+type SlackReadinessConfig struct {
+	// Enabled checks the Slack webhook URL or bot token
+	Enabled bool `json:"enabled,omitempty"`
+	// Interval is how long a check result is reused before Slack is asked again
+	Interval metav1.Duration `json:"interval,omitempty"`
+}
+
+// AlertmanagerSilencesConfig configures the synchronization of Alertmanager silences
+type AlertmanagerSilencesConfig struct {
+	// Enabled polls the silences of the Alertmanager
+	Enabled bool `json:"enabled,omitempty"`
+	// URL is the base URL of the Alertmanager, e.g. http://alertmanager.monitoring:9093
+	URL string `json:"url,omitempty"`
+	// Interval is how often silences are fetched
+	Interval metav1.Duration `json:"interval,omitempty"`
+}
+
+// AlertmanagerReceiverConfig configures the endpoint receiving Alertmanager webhook
+// notifications, which are sent like pod alerts
+type AlertmanagerReceiverConfig struct {
+	// Enabled serves the endpoint
+	Enabled bool `json:"enabled,omitempty"`
+	// BindAddress is the address the endpoint listens on
+	BindAddress string `json:"bindAddress,omitempty"`
+	// AllowUnauthenticated serves the endpoint without ALERTMANAGER_WEBHOOK_TOKEN, so anyone
+	// who can reach it can send alerts. Without it, the operator refuses to start with the
+	// receiver enabled and no token.
+	AllowUnauthenticated bool `json:"allowUnauthenticated,omitempty"`
+}
+
+// ChaosConfig controls how alerts for pods targeted by a running chaos experiment are handled.
+// By default they are sent and marked as expected.
+type ChaosConfig struct {
+	// Suppress drops such alerts for as long as the experiment runs
+	Suppress bool `json:"suppress,omitempty"`
+}
+
+// JobsConfig controls how failures of pods owned by Jobs are handled. Jobs retry failed pods up
+// to their backoffLimit, so by default each failed attempt is alerted on.
+type JobsConfig struct {
+	// Suppress drops alerts for failed attempts of a Job's pods, and instead alerts once when
+	// the Job itself fails. Alerts for pods that never start, such as image pull errors, are
+	// still sent.
+	Suppress bool `json:"suppress,omitempty"`
+}
+
+// DrainConfig controls how failures of pods stopped by the drain of a cordoned node are handled.
+// By default they are sent as one NodeDrain alert per node with info severity.
+type DrainConfig struct {
+	// Suppress drops such alerts
+	Suppress bool `json:"suppress,omitempty"`
+}
+
+// SpotConfig controls how failures of pods on interrupted spot or preemptible nodes are handled.
+// By default they are sent as one SpotInterruption alert per node with info severity.
+type SpotConfig struct {
+	// Suppress drops such alerts
+	Suppress bool `json:"suppress,omitempty"`
+	// NodeLabels identify spot nodes as key=value, in addition to the labels of EKS, Karpenter,
+	// GKE and AKS
+	NodeLabels []string `json:"nodeLabels,omitempty"`
+	// Taints mark nodes that are being interrupted, in addition to those of the AWS node
+	// termination handler, Karpenter and GKE
+	Taints []string `json:"taints,omitempty"`
+}
+
+// ExperimentConfig assigns a share of Slack alerts to an alternate message template
+type ExperimentConfig struct {
+	// Name labels the experiment in metrics. Empty disables the experiment.
+	Name string `json:"name,omitempty"`
+	// Percentage of alerts, bucketed by incident, that use the alternate template
+	Percentage int `json:"percentage,omitempty"`
+	// Template is a Go text/template rendering the alternate message from the alert
+	Template string `json:"template,omitempty"`
+}
+
+// SinkConfig holds per-notifier routing and retry settings
+type SinkConfig struct {
+	// MinSeverity drops alerts below this severity: info, warning or critical
+	MinSeverity string `json:"minSeverity,omitempty"`
+	// Namespaces limits the notifier to alerts from these namespaces
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Reasons limits the notifier to these failure reasons
+	Reasons []string `json:"reasons,omitempty"`
+	// Retry controls how often a failed delivery is retried
+	Retry RetryConfig `json:"retry,omitempty"`
+	// TLS configures the TLS connections of notifiers sending over HTTP
+	TLS SinkTLSConfig `json:"tls,omitempty"`
+}
+
+// SinkTLSConfig configures TLS for a notifier, e.g. behind a TLS-intercepting gateway or for a
+// self-hosted receiver with an internal certificate authority
+type SinkTLSConfig struct {
+	// CAFile is a PEM bundle of certificate authorities trusted in addition to the system ones
+	CAFile string `json:"caFile,omitempty"`
+	// MinVersion is the minimum TLS version: 1.2 or 1.3 (default 1.2)
+	MinVersion string `json:"minVersion,omitempty"`
+	// CertFile and KeyFile are a PEM client certificate and key presented to the receiver.
+	// They are read on each new connection, so rotated certificates are picked up.
+	CertFile string `json:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty"`
+}
+
+// QueueConfig configures the send queue that decouples delivery from reconciliation
+type QueueConfig struct {
+	// Size is the number of deliveries that can be queued (default 1000)
+	Size int `json:"size,omitempty"`
+	// Workers is the number of concurrent deliveries (default 4)
+	Workers int `json:"workers,omitempty"`
+	// Overflow is applied when the queue is full: reject (default) makes the reconciler
+	// retry later, drop-oldest discards the oldest queued delivery, block waits for room
+	Overflow string `json:"overflow,omitempty"`
+}
+
+// RemindersConfig replaces repeat alerts for pods that keep failing with reminders
+type RemindersConfig struct {
+	// Enabled turns reminders on
+	Enabled bool `json:"enabled,omitempty"`
+	// Schedule lists how long after the first alert each reminder is sent (default 30m, 1h, 4h).
+	// After the last step, reminders repeat at the interval of the last step.
+	Schedule []metav1.Duration `json:"schedule,omitempty"`
+	// Reasons that get reminders (default CrashLoopBackOff)
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// EscalationConfig lists the escalation steps of unacknowledged alerts
+type EscalationConfig struct {
+	// Rules are evaluated independently; each escalates an alert at most once per incident
+	Rules []EscalationRule `json:"rules,omitempty"`
+}
+
+// EscalationRule re-sends an alert to a channel when it is neither acknowledged nor resolved
+// within After
+type EscalationRule struct {
+	// After is how long after the first alert of an incident the rule fires
+	After metav1.Duration `json:"after"`
+	// Channel the escalated alert is sent to, e.g. sre-oncall
+	Channel string `json:"channel"`
+	// Mention is prepended to the escalated alert, e.g. <!subteam^S0123ABCD> for a Slack user group
+	Mention string `json:"mention,omitempty"`
+	// MinSeverity limits the rule to alerts at or above this severity: info, warning or critical
+	MinSeverity string `json:"minSeverity,omitempty"`
+}
+
+// ThrottlingConfig configures sampling of container CPU throttling from each node's cAdvisor
+type ThrottlingConfig struct {
+	// Enabled turns on throttling sampling
+	Enabled bool `json:"enabled,omitempty"`
+	// Interval between samples (default 1m)
+	Interval metav1.Duration `json:"interval,omitempty"`
+	// Sustained is how long a container must stay heavily throttled to count (default 5m)
+	Sustained metav1.Duration `json:"sustained,omitempty"`
+	// MinPercent is the share of CFS periods that must be throttled to count as heavy (default 50)
+	MinPercent int `json:"minPercent,omitempty"`
+}
+
+// PendingConfig configures alerts for pods stuck in the Pending phase
+type PendingConfig struct {
+	// Enabled turns on pending timeout alerts
+	Enabled bool `json:"enabled,omitempty"`
+	// Timeout is how long a pod may stay Pending before it is alerted on (default 10m)
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ProbesConfig configures alerts for liveness and readiness probe failures, sourced from the
+// kubelet's events
+type ProbesConfig struct {
+	// Enabled turns on probe failure alerts
+	Enabled bool `json:"enabled,omitempty"`
+	// LivenessRestarts is how many liveness probe restarts within the window alert (default 3)
+	LivenessRestarts int32 `json:"livenessRestarts,omitempty"`
+	// Window is how far back liveness probe restarts are counted (default 1h)
+	Window metav1.Duration `json:"window,omitempty"`
+	// ReadinessThreshold is how long a running container may stay NotReady with failing
+	// readiness probes (default 5m)
+	ReadinessThreshold metav1.Duration `json:"readinessThreshold,omitempty"`
+}
+
+// TerminatingConfig configures alerts for pods stuck in Terminating
+type TerminatingConfig struct {
+	// Enabled turns on stuck terminating alerts
+	Enabled bool `json:"enabled,omitempty"`
+	// Threshold is how long past its grace period a pod may keep terminating (default 5m)
+	Threshold metav1.Duration `json:"threshold,omitempty"`
+}
+
+// RollbacksConfig links failure alerts to the rollback of their Deployment
+type RollbacksConfig struct {
+	// Enabled turns on rollback detection
+	Enabled bool `json:"enabled,omitempty"`
+	// Window is how long before a rollback failure alerts are linked to it (default 1h)
+	Window metav1.Duration `json:"window,omitempty"`
+	// Channel receives the follow-up. Empty sends it where the latest linked alert went.
+	Channel string `json:"channel,omitempty"`
+}
+
+// RolloutsConfig configures stalled rollout alerts. Alerts are routed like pod alerts, by
+// ownership rules and slackgenie.io/channel annotations on the Deployment or its namespace.
+type RolloutsConfig struct {
+	// Enabled turns on stalled rollout alerts
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// CronJobsConfig configures missed CronJob run alerts. Alerts are routed like pod alerts, by
+// ownership rules and slackgenie.io/channel annotations on the CronJob or its namespace.
+type CronJobsConfig struct {
+	// Enabled turns on missed run alerts
+	Enabled bool `json:"enabled,omitempty"`
+	// Tolerance is how long after a scheduled time a run may start before it counts as missed
+	// (default 5m)
+	Tolerance metav1.Duration `json:"tolerance,omitempty"`
+}
+
+// FinalizersConfig configures the scan for resources stuck deleting
+type FinalizersConfig struct {
+	// Enabled turns on the scan
+	Enabled bool `json:"enabled,omitempty"`
+	// Kinds to scan as apiVersion/Kind, e.g. v1/Namespace or apps/v1/Deployment (default
+	// v1/Namespace and v1/PersistentVolumeClaim). The operator needs list and watch on them.
+	Kinds []string `json:"kinds,omitempty"`
+	// Threshold is how long a resource may be deleting before it is flagged (default 15m)
+	Threshold metav1.Duration `json:"threshold,omitempty"`
+	// Interval between scans (default 1m)
+	Interval metav1.Duration `json:"interval,omitempty"`
+	// Channel receives the alerts. Empty uses the default channel.
+	Channel string `json:"channel,omitempty"`
+}
+
+// PDBsConfig configures PodDisruptionBudget alerts
+type PDBsConfig struct {
+	// Enabled turns on PDB alerts
+	Enabled bool `json:"enabled,omitempty"`
+	// Threshold is how long a PDB may allow no disruptions before it is alerted on (default 1h).
+	// PDBs blocking the drain of a cordoned node are alerted on right away.
+	Threshold metav1.Duration `json:"threshold,omitempty"`
+	// Channel receives PDB alerts. Empty uses the default channel.
+	Channel string `json:"channel,omitempty"`
+}
+
+// CertificatesConfig configures cert-manager Certificate alerts. Alerts are routed like pod
+// alerts, by ownership rules and slackgenie.io/channel annotations on the Certificate or its
+// namespace.
+type CertificatesConfig struct {
+	// Enabled turns on Certificate alerts. They stay off when cert-manager is not installed.
+	Enabled bool `json:"enabled,omitempty"`
+	// ExpiryDays is how many days before expiry a Certificate is alerted on (default 14).
+	// cert-manager renews certificates well ahead of that, so reaching it means renewal fails.
+	ExpiryDays int `json:"expiryDays,omitempty"`
+	// Grace is how long a Certificate may be NotReady before it is alerted on, covering
+	// issuance of new certificates (default 10m)
+	Grace metav1.Duration `json:"grace,omitempty"`
+}
+
+// BackendsConfig configures empty Ingress backend alerts. Alerts are routed like pod alerts, by
+// ownership rules and slackgenie.io/channel annotations on the Service or its namespace.
+type BackendsConfig struct {
+	// Enabled turns on empty backend alerts
+	Enabled bool `json:"enabled,omitempty"`
+	// Grace is how long a Service may have no ready endpoints before it is alerted on, e.g.
+	// while a Recreate rollout replaces its pods (default 1m)
+	Grace metav1.Duration `json:"grace,omitempty"`
+}
+
+// NodesConfig configures alerts about nodes
+type NodesConfig struct {
+	// Pressure alerts when a node reports memory, disk or PID pressure
+	Pressure NodePressureConfig `json:"pressure,omitempty"`
+	// NotReady alerts when a node stays NotReady, and again when it recovers
+	NotReady NodeNotReadyConfig `json:"notReady,omitempty"`
+	// Channel receives node alerts. Empty uses the default channel.
+	Channel string `json:"channel,omitempty"`
+}
+
+// NodePressureConfig configures node pressure alerts
+type NodePressureConfig struct {
+	// Enabled turns on pressure alerts
+	Enabled bool `json:"enabled,omitempty"`
+	// Window suppresses repeat alerts for the same node and condition, e.g. when the
+	// pressure flaps as the kubelet evicts pods (default 30m)
+	Window metav1.Duration `json:"window,omitempty"`
+}
+
+// NodeNotReadyConfig configures node NotReady alerts
+type NodeNotReadyConfig struct {
+	// Enabled turns on NotReady alerts
+	Enabled bool `json:"enabled,omitempty"`
+	// Grace is how long a node may be NotReady before it is alerted on, so nodes restarted
+	// during upgrades do not alert (default 5m)
+	Grace metav1.Duration `json:"grace,omitempty"`
+}
+
+// Debounce strategies
+const (
+	// DebounceFixed suppresses repeats for the window after the last alert that was sent
+	DebounceFixed = "fixed"
+	// DebounceSliding restarts the window on every suppressed repeat, so a pod that fails
+	// continuously alerts once until it has been quiet for a whole window
+	DebounceSliding = "sliding"
+)
+
+// Debounce scopes
+const (
+	// DebounceScopePod deduplicates alerts per pod and reason
+	DebounceScopePod = "pod"
+	// DebounceScopeWorkload deduplicates alerts per owning workload and reason, so one alert
+	// covers every failing replica
+	DebounceScopeWorkload = "workload"
+)
+
+// DebounceConfig controls how long repeat alerts for the same pod and reason are suppressed
+type DebounceConfig struct {
+	// Window is the debounce window for reasons without an override (default 10m)
+	Window metav1.Duration `json:"window,omitempty"`
+	// Reasons overrides the window per failure reason, e.g. 30m for ImagePullBackOff
+	Reasons map[string]metav1.Duration `json:"reasons,omitempty"`
+	// Strategy is fixed (default) or sliding
+	Strategy string `json:"strategy,omitempty"`
+	// Scope is pod (default) or workload. Pods without a controller are always deduplicated
+	// per pod.
+	Scope string `json:"scope,omitempty"`
+	// WorkloadDelay holds the first alert of a workload incident, so replicas failing at about
+	// the same time are counted in it (default 30s)
+	WorkloadDelay metav1.Duration `json:"workloadDelay,omitempty"`
+}
+
+// WindowFor returns the debounce window of a failure reason
+func (d DebounceConfig) WindowFor(reason string) time.Duration {
+	if window, ok := d.Reasons[reason]; ok {
+		return window.Duration
+	}
+	return d.Window.Duration
+}
+
+// MaxWindow returns the longest debounce window of any reason
+func (d DebounceConfig) MaxWindow() time.Duration {
+	longest := d.Window.Duration
+	for _, window := range d.Reasons {
+		longest = max(longest, window.Duration)
+	}
+	return longest
+}
+
+// Detection modes
+const (
+	// DetectionRequeue retries through controller requeues and the periodic cache resync
+	DetectionRequeue = "requeue"
+	// DetectionEventDriven disables the periodic resync and schedules retries on an internal
+	// timer wheel, relying on the watch stream for everything else
+	DetectionEventDriven = "event-driven"
+)
+
+// Handling of failures found at startup
+const (
+	// PreExistingAlert alerts on each failing pod as usual
+	PreExistingAlert = "alert"
+	// PreExistingSkip skips pods failing at startup until they recover
+	PreExistingSkip = "skip"
+	// PreExistingSummary skips them like PreExistingSkip and sends one message listing them
+	PreExistingSummary = "summary"
+)
+
+// StartupConfig controls alerts for pods that are already failing when the operator starts,
+// which would otherwise be alerted on again after every restart
+type StartupConfig struct {
+	// PreExisting is one of alert (default), skip or summary
+	PreExisting string `json:"preExisting,omitempty"`
+}
+
+// DetectionConfig selects how failing pods are re-examined
+type DetectionConfig struct {
+	// Mode is requeue (default) or event-driven
+	Mode string `json:"mode,omitempty"`
+	// RetryInterval is how long to wait before retrying a failed delivery or resolution (default 5m)
+	RetryInterval metav1.Duration `json:"retryInterval,omitempty"`
+}
+
+// WatchConfig restricts the namespaces and pods the operator watches and caches. Pods outside
+// them are neither alerted on nor counted in workload and node summaries.
+type WatchConfig struct {
+	// Namespaces restricts the operator to these namespaces, so it only needs namespaced read
+	// access to them; empty watches the whole cluster
+	Namespaces []string `json:"namespaces,omitempty"`
+	// PodLabelSelector only watches pods matching a label selector, e.g. "tier!=batch"
+	PodLabelSelector string `json:"podLabelSelector,omitempty"`
+	// PodFieldSelector only watches pods matching a field selector, e.g.
+	// "metadata.namespace!=kube-system"
+	PodFieldSelector string `json:"podFieldSelector,omitempty"`
+}
+
+// RetryConfig configures retries of failed deliveries to a notifier
+type RetryConfig struct {
+	// Attempts is the total number of delivery attempts (default 3)
+	Attempts int `json:"attempts,omitempty"`
+	// Backoff is the delay before the first retry, doubled after each attempt (default 1s)
+	Backoff metav1.Duration `json:"backoff,omitempty"`
+}
+
+// WebhookConfig configures a generic HTTP webhook whose payload is rendered from a template
+type WebhookConfig struct {
+	// URL is the endpoint alerts are sent to
+	URL string `json:"url,omitempty"`
+	// Method is the HTTP method, POST by default
+	Method string `json:"method,omitempty"`
+	// Headers are added to every request; ${VAR} references are expanded from the environment
+	Headers map[string]string `json:"headers,omitempty"`
+	// Template is a Go text/template rendering the request body from a pod alert
+	Template string `json:"template,omitempty"`
+	// MetaTemplate renders the request body for forecasts and reports. Empty skips them.
+	MetaTemplate string `json:"metaTemplate,omitempty"`
+}
+
+// EmailConfig configures the SMTP email notifier. Credentials are read from the
+// SMTP_USERNAME and SMTP_PASSWORD environment variables.
+type EmailConfig struct {
+	// Host and Port of the SMTP server
+	Host string `json:"host,omitempty"`
+	Port int    `json:"port,omitempty"`
+	// TLS is one of starttls (default), tls or none
+	TLS string `json:"tls,omitempty"`
+	// From is the sender address
+	From string `json:"from,omitempty"`
+	// To are the default recipients
+	To []string `json:"to,omitempty"`
+	// Namespaces maps namespaces to the recipients of their alerts, replacing the defaults
+	Namespaces map[string][]string `json:"namespaces,omitempty"`
+}
+
+// FileConfig configures the notifier writing alerts as JSON lines
+type FileConfig struct {
+	// Path of the file alerts are appended to. Empty or "-" writes to stdout.
+	Path string `json:"path,omitempty"`
+}
+
+// KafkaConfig configures the Kafka notifier. SASL credentials are read from the
+// KAFKA_USERNAME and KAFKA_PASSWORD environment variables.
+type KafkaConfig struct {
+	// Brokers are the bootstrap brokers, host:port
+	Brokers []string `json:"brokers,omitempty"`
+	// Topic receives the alerts
+	Topic string `json:"topic,omitempty"`
+	// SASL is the SASL mechanism: plain, scram-sha-256 or scram-sha-512. Empty disables SASL.
+	SASL string `json:"sasl,omitempty"`
+	// TLS connects to the brokers over TLS, verified with sinks.kafka.tls if set
+	TLS bool `json:"tls,omitempty"`
+}
+
+// CloudEventsConfig configures the CloudEvents notifier
+type CloudEventsConfig struct {
+	// URL is the sink events are posted to, e.g. a Knative broker
+	URL string `json:"url,omitempty"`
+	// Source is the source attribute of the events (default /slackgenie, followed by the cluster)
+	Source string `json:"source,omitempty"`
+	// Mode is the content mode: binary (default) or structured
+	Mode string `json:"mode,omitempty"`
+	// Headers are added to every request. Values may reference environment variables.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// SNSConfig configures the Amazon SNS notifier. Credentials come from the default AWS
+// credential chain, e.g. IAM roles for service accounts.
+type SNSConfig struct {
+	// TopicARN receives alerts without a more specific topic, and operator messages
+	TopicARN string `json:"topicARN,omitempty"`
+	// Region overrides the region taken from the topic ARN
+	Region string `json:"region,omitempty"`
+	// Namespaces maps namespaces to the topic ARN of their alerts
+	Namespaces map[string]string `json:"namespaces,omitempty"`
+	// Severities maps severities (info, warning or critical) to the topic ARN of their alerts,
+	// for namespaces without their own topic
+	Severities map[string]string `json:"severities,omitempty"`
+}
+
+// ForecastConfig configures predictive capacity warnings per node pool
+type ForecastConfig struct {
+	// Enabled turns on capacity forecasting
+	Enabled bool `json:"enabled,omitempty"`
+	// Channel receives forecasts. Empty means the default destination.
+	Channel string `json:"channel,omitempty"`
+	// NodePoolLabels are node labels identifying the node pool, checked in order
+	NodePoolLabels []string `json:"nodePoolLabels,omitempty"`
+	// SampleInterval is how often node pool utilization is sampled
+	SampleInterval metav1.Duration `json:"sampleInterval,omitempty"`
+	// Horizon triggers a warning when a pool is predicted to fill up within this duration
+	Horizon metav1.Duration `json:"horizon,omitempty"`
+	// ReportInterval is how often a summary of all pools is posted
+	ReportInterval metav1.Duration `json:"reportInterval,omitempty"`
+}
+
+// QuotaReportConfig configures a monthly report of requests vs. quota vs. actual usage per namespace
+type QuotaReportConfig struct {
+	// Enabled turns on the report
+	Enabled bool `json:"enabled,omitempty"`
+	// Channel receives the report, e.g. a finance or platform channel. Empty means the default destination.
+	Channel string `json:"channel,omitempty"`
+	// DayOfMonth is the day the report is posted, 1 by default
+	DayOfMonth int `json:"dayOfMonth,omitempty"`
+}
+
+// QuotasConfig configures ResourceQuota exhaustion alerts
+type QuotasConfig struct {
+	// Enabled turns on quota alerts
+	Enabled bool `json:"enabled,omitempty"`
+	// Threshold is the share of a hard limit, in percent, whose use raises an alert (default 90)
+	Threshold int `json:"threshold,omitempty"`
+	// Window suppresses repeat alerts for the same quota and resource, and is how far back
+	// rejected pod creations are counted (default 6h)
+	Window metav1.Duration `json:"window,omitempty"`
+	// Channel receives quota alerts. Empty uses the default channel.
+	Channel string `json:"channel,omitempty"`
+}
+
+// RoutingConfig holds alert routing rules
+type RoutingConfig struct {
+	// Ownership maps platform-managed workloads to the team that owns them. Rules are evaluated
+	// in order and take precedence over slackgenie.io/channel annotations.
+	Ownership []OwnershipRule `json:"ownership,omitempty"`
+	// Trace attaches the suppression checks and the routing decision to each alert
+	Trace bool `json:"trace,omitempty"`
+	// Mentions notify the owning team of an alert in Slack
+	Mentions MentionsConfig `json:"mentions,omitempty"`
+	// OnCall mentions the engineer currently on call in a PagerDuty or Opsgenie schedule
+	OnCall OnCallConfig `json:"onCall,omitempty"`
+}
+
+// On-call schedule providers
+const (
+	OnCallPagerDuty = "pagerduty"
+	OnCallOpsgenie  = "opsgenie"
+)
+
+// OnCallConfig resolves the on-call engineer of an alert from a schedule. The API token is read
+// from PAGERDUTY_API_TOKEN or OPSGENIE_API_KEY.
+type OnCallConfig struct {
+	// Provider is pagerduty or opsgenie. Empty disables on-call mentions.
+	Provider string `json:"provider,omitempty"`
+	// APIURL overrides the provider's API, e.g. https://api.eu.opsgenie.com
+	APIURL string `json:"apiURL,omitempty"`
+	// MinSeverity is the lowest severity mentioning the on-call engineer (default critical)
+	MinSeverity string `json:"minSeverity,omitempty"`
+	// Schedule is the ID of the schedule used for alerts not matched by namespace or team
+	Schedule string `json:"schedule,omitempty"`
+	// Namespaces maps namespaces to the schedule of the team running them
+	Namespaces map[string]string `json:"namespaces,omitempty"`
+	// Teams maps teams, as attributed by ownership rules, to their schedule
+	Teams map[string]string `json:"teams,omitempty"`
+	// Users maps the email of on-call users to their Slack user ID. Users missing here are
+	// looked up by email in Slack, which needs the users:read.email scope.
+	Users map[string]string `json:"users,omitempty"`
+}
+
+// ScheduleFor returns the schedule of an alert's namespace or owning team, falling back to
+// the default schedule
+func (o OnCallConfig) ScheduleFor(namespace, team string) string {
+	if schedule, ok := o.Namespaces[namespace]; ok {
+		return schedule
+	}
+	if schedule, ok := o.Teams[team]; ok && team != "" {
+		return schedule
+	}
+	return o.Schedule
+}
+
+// MentionsConfig maps alerts to the Slack user groups or users mentioned in them
+type MentionsConfig struct {
+	// MinSeverity is the lowest severity mentioning anyone: info, warning or critical (default)
+	MinSeverity string `json:"minSeverity,omitempty"`
+	// Rules add a mention to the alerts they match. Every matching rule adds its mention.
+	Rules []MentionRule `json:"rules,omitempty"`
+}
+
+// MentionRule mentions a user group or user in matching alerts. A rule matches when any of its
+// selectors match.
+type MentionRule struct {
+	// Mention is the Slack mention, e.g. <!subteam^S0123ABCD> for a user group or <@U0123ABCD>
+	// for a user
+	Mention string `json:"mention"`
+	// Namespaces whose alerts mention the group
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Labels on the pod that select its alerts; all labels must match
+	Labels map[string]string `json:"labels,omitempty"`
+	// Teams whose alerts, as attributed by ownership rules, mention the group
+	Teams []string `json:"teams,omitempty"`
+	// MinSeverity raises or lowers mentions.minSeverity for this rule
+	MinSeverity string `json:"minSeverity,omitempty"`
+}
+
+// Matches reports whether the rule selects an alert for the given pod namespace, labels and
+// owning team
+func (m MentionRule) Matches(namespace string, labels map[string]string, team string) bool {
+	return slices.Contains(m.Namespaces, namespace) ||
+		(team != "" && slices.Contains(m.Teams, team)) ||
+		matchLabels(m.Labels, labels)
+}
+
+// OwnershipRule attributes matching alerts to a team. A rule matches when any of its
+// selectors match; a rule without selectors never matches.
+type OwnershipRule struct {
+	// Team is the owning team, shown in the alert
+	Team string `json:"team"`
+	// Channel receives alerts attributed to the team. Empty means the default destination.
+	Channel string `json:"channel,omitempty"`
+	// Namespaces owned by the team, e.g. ingress-nginx, monitoring, istio-system
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Containers owned by the team even when injected into tenant pods, e.g. istio-proxy
+	Containers []string `json:"containers,omitempty"`
+	// Labels on the pod that mark it as owned by the team; all labels must match
+	Labels map[string]string `json:"labels,omitempty"`
+	// NodeLabels on the node running the pod that mark it as owned by the team, e.g. gpu=true;
+	// all labels must match
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+	// NodeTaints on the node running the pod that mark it as owned by the team, e.g.
+	// dedicated=ci; any listed taint matches
+	NodeTaints []TaintMatcher `json:"nodeTaints,omitempty"`
+}
+
+// TaintMatcher matches a node taint. Empty value and effect match any.
+type TaintMatcher struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect,omitempty"`
+}
+
+// Matches reports whether the taint matches
+func (t TaintMatcher) Matches(taint corev1.Taint) bool {
+	return taint.Key == t.Key &&
+		(t.Value == "" || taint.Value == t.Value) &&
+		(t.Effect == "" || string(taint.Effect) == t.Effect)
+}
+
+// NeedsNode reports whether the rule matches on attributes of the pod's node
+func (o OwnershipRule) NeedsNode() bool {
+	return len(o.NodeLabels) > 0 || len(o.NodeTaints) > 0
+}
+
+// Matches reports whether the rule owns an alert for the given pod namespace, labels, container
+// and node. The node may be nil when the pod is not scheduled or the node is unknown.
+func (o OwnershipRule) Matches(namespace string, labels map[string]string, container string, node *corev1.Node) bool {
+	for _, ns := range o.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	for _, c := range o.Containers {
+		if c != "" && c == container {
+			return true
+		}
+	}
+	if node != nil {
+		for _, matcher := range o.NodeTaints {
+			for _, taint := range node.Spec.Taints {
+				if matcher.Matches(taint) {
+					return true
+				}
+			}
+		}
+		if matchLabels(o.NodeLabels, node.Labels) {
+			return true
+		}
+	}
+	return matchLabels(o.Labels, labels)
+}
+
+// matchLabels reports whether all of a non-empty set of wanted labels are present
+func matchLabels(want, have map[string]string) bool {
+	if len(want) == 0 {
+		return false
+	}
+	for key, value := range want {
+		if have[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Load reads the configuration file at path. An empty path yields the default configuration.
+func Load(path string) (*Config, error) {
+	if path == "" {
+		cfg := &Config{}
+		cfg.setDefaults()
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates a YAML or JSON configuration
+func Parse(data []byte) (*Config, error) {
+	cfg, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Decode decodes a YAML or JSON configuration and fills in defaults without validating it
+func Decode(data []byte) (*Config, error) {
+	cfg := &Config{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	cfg.setDefaults()
+	return cfg, nil
+}
+
+// setDefaults fills in defaults for unset fields
+func (c *Config) setDefaults() {
+	if len(c.Forecast.NodePoolLabels) == 0 {
+		c.Forecast.NodePoolLabels = []string{
+			"karpenter.sh/nodepool",
+			"cloud.google.com/gke-nodepool",
+			"eks.amazonaws.com/nodegroup",
+			"kubernetes.azure.com/agentpool",
+			"node.kubernetes.io/instance-type",
+		}
+	}
+	if c.Forecast.SampleInterval.Duration == 0 {
+		c.Forecast.SampleInterval.Duration = time.Hour
+	}
+	if c.Forecast.Horizon.Duration == 0 {
+		c.Forecast.Horizon.Duration = 72 * time.Hour
+	}
+	if c.Forecast.ReportInterval.Duration == 0 {
+		c.Forecast.ReportInterval.Duration = 7 * 24 * time.Hour
+	}
+	if c.Quotas.Threshold == 0 {
+		c.Quotas.Threshold = 90
+	}
+	if c.Quotas.Window.Duration == 0 {
+		c.Quotas.Window.Duration = 6 * time.Hour
+	}
+	if c.QuotaReport.DayOfMonth == 0 {
+		c.QuotaReport.DayOfMonth = 1
+	}
+	// The defaults leave room for the alert's other fields within the 3000 characters Slack
+	// accepts in a section block
+	if c.Enrichment.MaxBytes == 0 {
+		c.Enrichment.MaxBytes = 2048
+	}
+	if c.Enrichment.Budgets.Logs == 0 {
+		c.Enrichment.Budgets.Logs = 1024
+	}
+	if c.Enrichment.Budgets.Events == 0 {
+		c.Enrichment.Budgets.Events = 512
+	}
+	if c.Enrichment.Budgets.Describe == 0 {
+		c.Enrichment.Budgets.Describe = 512
+	}
+	if c.LogLinks.BindAddress == "" {
+		c.LogLinks.BindAddress = ":8082"
+	}
+	if c.LogLinks.TailLines == 0 {
+		c.LogLinks.TailLines = 100
+	}
+	if c.LogLinks.TTL.Duration == 0 {
+		c.LogLinks.TTL.Duration = time.Hour
+	}
+	if c.Queue.Size == 0 {
+		c.Queue.Size = 1000
+	}
+	if c.Queue.Workers == 0 {
+		c.Queue.Workers = 4
+	}
+	if c.Queue.Overflow == "" {
+		c.Queue.Overflow = "reject"
+	}
+	if c.Cost.Window.Duration == 0 {
+		c.Cost.Window.Duration = 24 * time.Hour
+	}
+	if len(c.Reminders.Schedule) == 0 {
+		c.Reminders.Schedule = []metav1.Duration{{Duration: 30 * time.Minute}, {Duration: time.Hour}, {Duration: 4 * time.Hour}}
+	}
+	if len(c.Reminders.Reasons) == 0 {
+		c.Reminders.Reasons = []string{"CrashLoopBackOff"}
+	}
+	if c.Throttling.Interval.Duration == 0 {
+		c.Throttling.Interval.Duration = time.Minute
+	}
+	if c.Throttling.Sustained.Duration == 0 {
+		c.Throttling.Sustained.Duration = 5 * time.Minute
+	}
+	if c.Throttling.MinPercent == 0 {
+		c.Throttling.MinPercent = 50
+	}
+	if c.Pending.Timeout.Duration == 0 {
+		c.Pending.Timeout.Duration = 10 * time.Minute
+	}
+	if c.Probes.LivenessRestarts == 0 {
+		c.Probes.LivenessRestarts = 3
+	}
+	if c.Probes.Window.Duration == 0 {
+		c.Probes.Window.Duration = time.Hour
+	}
+	if c.Probes.ReadinessThreshold.Duration == 0 {
+		c.Probes.ReadinessThreshold.Duration = 5 * time.Minute
+	}
+	if c.Terminating.Threshold.Duration == 0 {
+		c.Terminating.Threshold.Duration = 5 * time.Minute
+	}
+	if c.CronJobs.Tolerance.Duration == 0 {
+		c.CronJobs.Tolerance.Duration = 5 * time.Minute
+	}
+	if c.Rollbacks.Window.Duration == 0 {
+		c.Rollbacks.Window.Duration = time.Hour
+	}
+	if len(c.Finalizers.Kinds) == 0 {
+		c.Finalizers.Kinds = []string{"v1/Namespace", "v1/PersistentVolumeClaim"}
+	}
+	if c.Nodes.Pressure.Window.Duration == 0 {
+		c.Nodes.Pressure.Window.Duration = 30 * time.Minute
+	}
+	if c.Nodes.NotReady.Grace.Duration == 0 {
+		c.Nodes.NotReady.Grace.Duration = 5 * time.Minute
+	}
+	if c.Certificates.ExpiryDays == 0 {
+		c.Certificates.ExpiryDays = 14
+	}
+	if c.Certificates.Grace.Duration == 0 {
+		c.Certificates.Grace.Duration = 10 * time.Minute
+	}
+	if c.Backends.Grace.Duration == 0 {
+		c.Backends.Grace.Duration = time.Minute
+	}
+	if c.PDBs.Threshold.Duration == 0 {
+		c.PDBs.Threshold.Duration = time.Hour
+	}
+	if c.Finalizers.Threshold.Duration == 0 {
+		c.Finalizers.Threshold.Duration = 15 * time.Minute
+	}
+	if c.Finalizers.Interval.Duration == 0 {
+		c.Finalizers.Interval.Duration = time.Minute
+	}
+	if c.Debounce.Window.Duration == 0 {
+		c.Debounce.Window.Duration = 10 * time.Minute
+	}
+	if c.Debounce.Strategy == "" {
+		c.Debounce.Strategy = DebounceFixed
+	}
+	if c.Debounce.Scope == "" {
+		c.Debounce.Scope = DebounceScopePod
+	}
+	if c.Debounce.WorkloadDelay.Duration == 0 {
+		c.Debounce.WorkloadDelay.Duration = 30 * time.Second
+	}
+	if c.Startup.PreExisting == "" {
+		c.Startup.PreExisting = PreExistingAlert
+	}
+	if c.Detection.Mode == "" {
+		c.Detection.Mode = DetectionRequeue
+	}
+	if c.Detection.RetryInterval.Duration == 0 {
+		c.Detection.RetryInterval.Duration = 5 * time.Minute
+	}
+	if c.GitHub.APIURL == "" {
+		c.GitHub.APIURL = "https://api.github.com"
+	}
+	if c.GitHub.KnownIssues == "" {
+		c.GitHub.KnownIssues = KnownIssueLink
+	}
+	if c.SlackApp.BindAddress == "" {
+		c.SlackApp.BindAddress = ":8083"
+	}
+	if c.Silences.API.BindAddress == "" {
+		c.Silences.API.BindAddress = ":8084"
+	}
+	if c.Silences.API.Audience == "" {
+		c.Silences.API.Audience = DefaultSilenceAPIAudience
+	}
+	if c.Alertmanager.Receiver.BindAddress == "" {
+		c.Alertmanager.Receiver.BindAddress = ":8085"
+	}
+	if c.Alertmanager.Silences.Interval.Duration == 0 {
+		c.Alertmanager.Silences.Interval.Duration = time.Minute
+	}
+	if c.Audit.Retention.Duration == 0 {
+		c.Audit.Retention.Duration = 30 * 24 * time.Hour
+	}
+	if c.Routing.Mentions.MinSeverity == "" {
+		c.Routing.Mentions.MinSeverity = "critical"
+	}
+	if c.Routing.OnCall.MinSeverity == "" {
+		c.Routing.OnCall.MinSeverity = "critical"
+	}
+	if c.CloudEvents.Source == "" {
+		c.CloudEvents.Source = path.Join("/slackgenie", c.Cluster)
+	}
+	if c.HistoryExport.Interval.Duration == 0 {
+		c.HistoryExport.Interval.Duration = time.Hour
+	}
+	if c.HistoryExport.Format == "" {
+		c.HistoryExport.Format = ExportFormatJSON
+	}
+	if c.HistoryExport.S3 != nil && c.HistoryExport.S3.Region == "" {
+		c.HistoryExport.S3.Region = "us-east-1"
+	}
+	if c.Readiness.Slack.Interval.Duration == 0 {
+		c.Readiness.Slack.Interval.Duration = 5 * time.Minute
+	}
+	if c.AppHome.SilenceDuration.Duration == 0 {
+		c.AppHome.SilenceDuration.Duration = time.Hour
+	}
+	if c.Sinks == nil {
+		c.Sinks = make(map[string]SinkConfig)
+	}
+	for _, notifier := range c.EnabledNotifiers() {
+		sink := c.Sinks[notifier]
+		if sink.Retry.Attempts == 0 {
+			sink.Retry.Attempts = 3
+		}
+		if sink.Retry.Backoff.Duration == 0 {
+			sink.Retry.Backoff.Duration = time.Second
+		}
+		c.Sinks[notifier] = sink
+	}
+}
+
+// EnabledNotifiers returns the configured notification backends, defaulting to Slack
+func (c *Config) EnabledNotifiers() []string {
+	switch {
+	case len(c.Notifiers) > 0:
+		return c.Notifiers
+	case c.Notifier != "":
+		return []string{c.Notifier}
+	default:
+		return []string{NotifierSlack}
+	}
+}
+
+// Validate checks the configuration for errors
+func (c *Config) Validate() error {
+	if c.Notifier != "" && len(c.Notifiers) > 0 {
+		return fmt.Errorf("notifier and notifiers are mutually exclusive")
+	}
+	for i, notifier := range c.EnabledNotifiers() {
+		// Sinks are keyed by name, so a repeated notifier would send every alert twice
+		if slices.Contains(c.EnabledNotifiers()[:i], notifier) {
+			return fmt.Errorf("notifiers: duplicate value %q", notifier)
+		}
+		switch notifier {
+		case NotifierSlack, NotifierTeams, NotifierDiscord, NotifierPagerDuty, NotifierTelegram,
+			NotifierMattermost, NotifierFile, NotifierGoogleChat:
+		case NotifierSNS:
+			if c.SNS.TopicARN == "" {
+				return fmt.Errorf("sns: topicARN is required")
+			}
+			for severity := range c.SNS.Severities {
+				switch severity {
+				case "info", "warning", "critical":
+				default:
+					return fmt.Errorf("sns.severities: unsupported severity %q", severity)
+				}
+			}
+		case NotifierCloudEvents:
+			if c.CloudEvents.URL == "" {
+				return fmt.Errorf("cloudEvents: url is required")
+			}
+			switch c.CloudEvents.Mode {
+			case "", "binary", "structured":
+			default:
+				return fmt.Errorf("cloudEvents.mode: unsupported value %q", c.CloudEvents.Mode)
+			}
+		case NotifierKafka:
+			if len(c.Kafka.Brokers) == 0 || c.Kafka.Topic == "" {
+				return fmt.Errorf("kafka: brokers and topic are required")
+			}
+			switch c.Kafka.SASL {
+			case "", "plain", "scram-sha-256", "scram-sha-512":
+			default:
+				return fmt.Errorf("kafka.sasl: unsupported value %q", c.Kafka.SASL)
+			}
+		case NotifierWebhook:
+			if c.Webhook.URL == "" || c.Webhook.Template == "" {
+				return fmt.Errorf("webhook: url and template are required")
+			}
+		case NotifierEmail:
+			if c.Email.Host == "" || c.Email.From == "" {
+				return fmt.Errorf("email: host and from are required")
+			}
+			switch c.Email.TLS {
+			case "", "starttls", "tls", "none":
+			default:
+				return fmt.Errorf("email.tls: unsupported value %q", c.Email.TLS)
+			}
+		default:
+			return fmt.Errorf("notifiers: unsupported value %q", notifier)
+		}
+	}
+
+	for name, sink := range c.Sinks {
+		if !slices.Contains(c.EnabledNotifiers(), name) {
+			return fmt.Errorf("sinks.%s: notifier is not enabled", name)
+		}
+		switch sink.MinSeverity {
+		case "", "info", "warning", "critical":
+		default:
+			return fmt.Errorf("sinks.%s.minSeverity: unsupported value %q", name, sink.MinSeverity)
+		}
+		if sink.Retry.Attempts < 0 || sink.Retry.Backoff.Duration < 0 {
+			return fmt.Errorf("sinks.%s.retry: attempts and backoff must not be negative", name)
+		}
+		if sink.TLS != (SinkTLSConfig{}) && (name == NotifierEmail || name == NotifierFile || name == NotifierSNS) {
+			return fmt.Errorf("sinks.%s.tls: not supported by the %s notifier", name, name)
+		}
+		if sink.TLS != (SinkTLSConfig{}) && name == NotifierKafka && !c.Kafka.TLS {
+			return fmt.Errorf("sinks.kafka.tls requires kafka.tls")
+		}
+		switch sink.TLS.MinVersion {
+		case "", "1.2", "1.3":
+		default:
+			return fmt.Errorf("sinks.%s.tls.minVersion: unsupported value %q", name, sink.TLS.MinVersion)
+		}
+		if (sink.TLS.CertFile == "") != (sink.TLS.KeyFile == "") {
+			return fmt.Errorf("sinks.%s.tls: certFile and keyFile must be set together", name)
+		}
+	}
+
+	switch c.Queue.Overflow {
+	case "reject", "drop-oldest", "block":
+	default:
+		return fmt.Errorf("queue.overflow: unsupported value %q", c.Queue.Overflow)
+	}
+	if c.Queue.Size < 0 || c.Queue.Workers < 0 {
+		return fmt.Errorf("queue: size and workers must not be negative")
+	}
+
+	switch c.Debounce.Strategy {
+	case DebounceFixed, DebounceSliding:
+	default:
+		return fmt.Errorf("debounce.strategy: unsupported value %q", c.Debounce.Strategy)
+	}
+	switch c.Debounce.Scope {
+	case DebounceScopePod, DebounceScopeWorkload:
+	default:
+		return fmt.Errorf("debounce.scope: unsupported value %q", c.Debounce.Scope)
+	}
+	if c.Debounce.WorkloadDelay.Duration < 0 {
+		return fmt.Errorf("debounce.workloadDelay must not be negative")
+	}
+	if c.Debounce.Window.Duration < 0 {
+		return fmt.Errorf("debounce.window must not be negative")
+	}
+	for reason, window := range c.Debounce.Reasons {
+		if window.Duration < 0 {
+			return fmt.Errorf("debounce.reasons.%s must not be negative", reason)
+		}
+	}
+
+	if len(c.Escalation.Rules) > 0 && !slices.Contains(c.EnabledNotifiers(), NotifierSlack) {
+		return fmt.Errorf("escalation requires the slack notifier")
+	}
+	for i, rule := range c.Escalation.Rules {
+		if rule.After.Duration <= 0 {
+			return fmt.Errorf("escalation.rules[%d].after must be positive", i)
+		}
+		if rule.Channel == "" {
+			return fmt.Errorf("escalation.rules[%d].channel is required", i)
+		}
+		switch rule.MinSeverity {
+		case "", "info", "warning", "critical":
+		default:
+			return fmt.Errorf("escalation.rules[%d].minSeverity: unsupported value %q", i, rule.MinSeverity)
+		}
+	}
+
+	if c.Throttling.Interval.Duration < 0 || c.Throttling.Sustained.Duration < 0 {
+		return fmt.Errorf("throttling: interval and sustained must not be negative")
+	}
+	if c.Throttling.MinPercent < 1 || c.Throttling.MinPercent > 100 {
+		return fmt.Errorf("throttling.minPercent must be between 1 and 100")
+	}
+	if c.Pending.Timeout.Duration < 0 {
+		return fmt.Errorf("pending.timeout must not be negative")
+	}
+	if c.Probes.LivenessRestarts < 0 || c.Probes.Window.Duration < 0 || c.Probes.ReadinessThreshold.Duration < 0 {
+		return fmt.Errorf("probes: livenessRestarts, window and readinessThreshold must not be negative")
+	}
+	if c.Terminating.Threshold.Duration < 0 {
+		return fmt.Errorf("terminating.threshold must not be negative")
+	}
+
+	for _, label := range c.Spot.NodeLabels {
+		if key, _, ok := strings.Cut(label, "="); !ok || key == "" {
+			return fmt.Errorf("spot.nodeLabels: %q is not key=value", label)
+		}
+	}
+
+	if c.CronJobs.Tolerance.Duration < 0 {
+		return fmt.Errorf("cronJobs.tolerance must not be negative")
+	}
+	if c.Rollbacks.Window.Duration < 0 {
+		return fmt.Errorf("rollbacks.window must not be negative")
+	}
+
+	for _, kind := range c.Finalizers.Kinds {
+		if i := strings.LastIndex(kind, "/"); i <= 0 || i == len(kind)-1 {
+			return fmt.Errorf("finalizers.kinds: %q is not apiVersion/Kind", kind)
+		}
+	}
+	if c.Nodes.Pressure.Window.Duration < 0 {
+		return fmt.Errorf("nodes.pressure.window must not be negative")
+	}
+	if c.Nodes.NotReady.Grace.Duration < 0 {
+		return fmt.Errorf("nodes.notReady.grace must not be negative")
+	}
+	if c.PDBs.Threshold.Duration < 0 {
+		return fmt.Errorf("pdbs.threshold must not be negative")
+	}
+	if c.Certificates.ExpiryDays < 0 {
+		return fmt.Errorf("certificates.expiryDays must not be negative")
+	}
+	if c.Certificates.Grace.Duration < 0 {
+		return fmt.Errorf("certificates.grace must not be negative")
+	}
+	if c.Backends.Grace.Duration < 0 {
+		return fmt.Errorf("backends.grace must not be negative")
+	}
+	if len(c.Watch.Namespaces) > 0 && (c.Finalizers.Enabled || c.Forecast.Enabled) {
+		return fmt.Errorf("watch.namespaces: finalizers and forecast need a cluster-wide view and cannot be enabled")
+	}
+	if _, err := labels.Parse(c.Watch.PodLabelSelector); err != nil {
+		return fmt.Errorf("watch.podLabelSelector: %w", err)
+	}
+	if _, err := fields.ParseSelector(c.Watch.PodFieldSelector); err != nil {
+		return fmt.Errorf("watch.podFieldSelector: %w", err)
+	}
+	if c.Alertmanager.Silences.Enabled && c.Alertmanager.Silences.URL == "" {
+		return fmt.Errorf("alertmanager.silences.url is required")
+	}
+	if c.Alertmanager.Silences.Interval.Duration < 0 {
+		return fmt.Errorf("alertmanager.silences.interval must not be negative")
+	}
+	if c.Readiness.Slack.Enabled && !slices.Contains(c.EnabledNotifiers(), NotifierSlack) {
+		return fmt.Errorf("readiness.slack requires the slack notifier")
+	}
+	if c.Readiness.Slack.Interval.Duration < 0 {
+		return fmt.Errorf("readiness.slack.interval must not be negative")
+	}
+	if c.Audit.Retention.Duration < 0 {
+		return fmt.Errorf("audit.retention must not be negative")
+	}
+	if c.HistoryExport.Interval.Duration < 0 || c.HistoryExport.Retention.Duration < 0 {
+		return fmt.Errorf("historyExport: interval and retention must not be negative")
+	}
+	if c.HistoryExport.Format != ExportFormatJSON && c.HistoryExport.Format != ExportFormatCSV {
+		return fmt.Errorf("historyExport.format must be %s or %s", ExportFormatJSON, ExportFormatCSV)
+	}
+	if c.HistoryExport.Enabled && (c.HistoryExport.Path == "") == (c.HistoryExport.S3 == nil) {
+		return fmt.Errorf("historyExport requires exactly one of path and s3")
+	}
+	if c.HistoryExport.S3 != nil && c.HistoryExport.S3.Bucket == "" {
+		return fmt.Errorf("historyExport.s3.bucket must be set")
+	}
+	if c.Finalizers.Threshold.Duration < 0 || c.Finalizers.Interval.Duration < 0 {
+		return fmt.Errorf("finalizers: threshold and interval must not be negative")
+	}
+
+	for i, step := range c.Reminders.Schedule {
+		if step.Duration <= 0 || (i > 0 && step.Duration <= c.Reminders.Schedule[i-1].Duration) {
+			return fmt.Errorf("reminders.schedule must be positive and increasing")
+		}
+	}
+
+	switch c.Startup.PreExisting {
+	case PreExistingAlert, PreExistingSkip, PreExistingSummary:
+	default:
+		return fmt.Errorf("startup.preExisting: unsupported value %q", c.Startup.PreExisting)
+	}
+
+	switch c.Detection.Mode {
+	case DetectionRequeue, DetectionEventDriven:
+	default:
+		return fmt.Errorf("detection.mode: unsupported value %q", c.Detection.Mode)
+	}
+	if c.Detection.RetryInterval.Duration < 0 {
+		return fmt.Errorf("detection.retryInterval must not be negative")
+	}
+
+	if c.Experiment.Name != "" {
+		if c.Experiment.Percentage < 0 || c.Experiment.Percentage > 100 {
+			return fmt.Errorf("experiment.percentage must be between 0 and 100")
+		}
+		if c.Experiment.Template == "" {
+			return fmt.Errorf("experiment.template is required")
+		}
+	}
+
+	if c.QuotaReport.DayOfMonth < 1 || c.QuotaReport.DayOfMonth > 28 {
+		return fmt.Errorf("quotaReport.dayOfMonth must be between 1 and 28")
+	}
+	if c.Quotas.Threshold < 1 || c.Quotas.Threshold > 100 {
+		return fmt.Errorf("quotas.threshold must be between 1 and 100")
+	}
+	if c.Quotas.Window.Duration < 0 {
+		return fmt.Errorf("quotas.window must not be negative")
+	}
+
+	if c.Enrichment.MaxBytes < 0 || c.Enrichment.Budgets.Logs < 0 ||
+		c.Enrichment.Budgets.Events < 0 || c.Enrichment.Budgets.Describe < 0 {
+		return fmt.Errorf("enrichment: byte limits must not be negative")
+	}
+	if c.Enrichment.LogTailLines < 0 || c.Enrichment.LogTailLines > 1000 {
+		return fmt.Errorf("enrichment.logTailLines must be between 0 and 1000")
+	}
+	if c.Enrichment.RecentEvents < 0 || c.Enrichment.RecentEvents > 50 {
+		return fmt.Errorf("enrichment.recentEvents must be between 0 and 50")
+	}
+
+	if c.LogLinks.Enabled && c.LogLinks.ExternalURL == "" {
+		return fmt.Errorf("logLinks.externalURL is required when log links are enabled")
+	}
+
+	dashboards := make(map[string]bool, len(c.Dashboards))
+	for i, dashboard := range c.Dashboards {
+		if dashboard.Name == "" || dashboard.URL == "" {
+			return fmt.Errorf("dashboards[%d]: name and url are required", i)
+		}
+		if dashboards[dashboard.Name] {
+			return fmt.Errorf("dashboards[%d]: duplicate name %q", i, dashboard.Name)
+		}
+		dashboards[dashboard.Name] = true
+	}
+
+	if c.GitHub.Repository != "" {
+		if owner, name, ok := strings.Cut(c.GitHub.Repository, "/"); !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+			return fmt.Errorf("github.repository must be in owner/name form")
+		}
+		switch c.GitHub.KnownIssues {
+		case KnownIssueLink, KnownIssueSuppress:
+		default:
+			return fmt.Errorf("github.knownIssues: unsupported value %q", c.GitHub.KnownIssues)
+		}
+	}
+	if c.GitHub.CreateButton && !slices.Contains(c.EnabledNotifiers(), NotifierSlack) {
+		return fmt.Errorf("github.createButton requires the slack notifier")
+	}
+
+	if c.AppHome.Enabled && !slices.Contains(c.EnabledNotifiers(), NotifierSlack) {
+		return fmt.Errorf("appHome requires the slack notifier")
+	}
+
+	switch c.Routing.Mentions.MinSeverity {
+	case "", "info", "warning", "critical":
+	default:
+		return fmt.Errorf("routing.mentions.minSeverity: unsupported value %q", c.Routing.Mentions.MinSeverity)
+	}
+	for i, rule := range c.Routing.Mentions.Rules {
+		if rule.Mention == "" {
+			return fmt.Errorf("routing.mentions.rules[%d]: mention is required", i)
+		}
+		if len(rule.Namespaces) == 0 && len(rule.Labels) == 0 && len(rule.Teams) == 0 {
+			return fmt.Errorf("routing.mentions.rules[%d]: at least one of namespaces, labels or teams is required", i)
+		}
+		switch rule.MinSeverity {
+		case "", "info", "warning", "critical":
+		default:
+			return fmt.Errorf("routing.mentions.rules[%d].minSeverity: unsupported value %q", i, rule.MinSeverity)
+		}
+	}
+
+	switch c.Routing.OnCall.Provider {
+	case "", OnCallPagerDuty, OnCallOpsgenie:
+	default:
+		return fmt.Errorf("routing.onCall.provider: unsupported value %q", c.Routing.OnCall.Provider)
+	}
+	switch c.Routing.OnCall.MinSeverity {
+	case "", "info", "warning", "critical":
+	default:
+		return fmt.Errorf("routing.onCall.minSeverity: unsupported value %q", c.Routing.OnCall.MinSeverity)
+	}
+	if c.Routing.OnCall.Provider != "" {
+		if c.Routing.OnCall.Schedule == "" && len(c.Routing.OnCall.Namespaces) == 0 && len(c.Routing.OnCall.Teams) == 0 {
+			return fmt.Errorf("routing.onCall: at least one of schedule, namespaces or teams is required")
+		}
+	}
+
+	for i, rule := range c.Routing.Ownership {
+		if rule.Team == "" {
+			return fmt.Errorf("routing.ownership[%d]: team is required", i)
+		}
+		if len(rule.Namespaces) == 0 && len(rule.Containers) == 0 && len(rule.Labels) == 0 && !rule.NeedsNode() {
+			return fmt.Errorf("routing.ownership[%d]: at least one of namespaces, containers, labels, nodeLabels or nodeTaints is required", i)
+		}
+		for j, taint := range rule.NodeTaints {
+			if taint.Key == "" {
+				return fmt.Errorf("routing.ownership[%d].nodeTaints[%d]: key is required", i, j)
+			}
+		}
+	}
+	return nil
+}
+
+// RouteConflicts describes ownership rules that claim a namespace or container already claimed
+// by an earlier rule for another team. Since the first matching rule wins, the later claim
+// never takes effect.
+func (c *Config) RouteConflicts() []string {
+	var conflicts []string
+	namespaces := make(map[string]int)
+	containers := make(map[string]int)
+
+	for i, rule := range c.Routing.Ownership {
+		for _, ns := range rule.Namespaces {
+			if first, claimed := namespaces[ns]; claimed && c.Routing.Ownership[first].Team != rule.Team {
+				conflicts = append(conflicts, fmt.Sprintf("namespace %q of routing.ownership[%d] (%s) is already owned by routing.ownership[%d] (%s)",
+					ns, i, rule.Team, first, c.Routing.Ownership[first].Team))
+			} else if !claimed {
+				namespaces[ns] = i
+			}
+		}
+		for _, container := range rule.Containers {
+			if first, claimed := containers[container]; claimed && c.Routing.Ownership[first].Team != rule.Team {
+				conflicts = append(conflicts, fmt.Sprintf("container %q of routing.ownership[%d] (%s) is already owned by routing.ownership[%d] (%s)",
+					container, i, rule.Team, first, c.Routing.Ownership[first].Team))
+			} else if !claimed {
+				containers[container] = i
+			}
+		}
+	}
+	return conflicts
+}
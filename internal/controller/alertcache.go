@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/alertkey"
+)
+
+// alertCacheMaxEntries bounds the debounce cache; beyond it the oldest entries are evicted
+const alertCacheMaxEntries = 10000
+
+// alertCacheJanitorInterval is how often expired debounce entries are evicted
+const alertCacheJanitorInterval = time.Minute
+
+// runCacheJanitor periodically evicts debounce entries older than the debounce window, from
+// memory and from the state store. Such entries no longer suppress anything, but pods that
+// keep failing with changing reasons would otherwise accumulate them until the pod is deleted,
+// and the persisted snapshot would grow until its backend rejects it.
+func (r *PodReconciler) runCacheJanitor(ctx context.Context) error {
+	ticker := time.NewTicker(alertCacheJanitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			if evicted := r.evictExpiredAlerts(now); evicted > 0 {
+				logf.FromContext(ctx).V(1).Info("Evicted expired alert cache entries", "evicted", evicted)
+			}
+			if r.Store == nil {
+				continue
+			}
+			r.persistSlides(ctx)
+			expired, err := r.Store.ExpireAlerts(ctx, func(key string, at time.Time) bool {
+				window, ok := r.debounceWindow(key)
+				return ok && now.Sub(at) >= window
+			})
+			if err != nil {
+				logf.FromContext(ctx).Error(err, "Failed to expire persisted alert state")
+			} else if expired > 0 {
+				logf.FromContext(ctx).V(1).Info("Expired persisted alert state", "expired", expired)
+			}
+		}
+	}
+}
+
+// persistSlides writes the windows restarted by suppressed repeats since the last pass, so a
+// new leader keeps debouncing them. A failed write is retried on the next pass.
+func (r *PodReconciler) persistSlides(ctx context.Context) {
+	r.alertCacheMux.Lock()
+	slid := r.slid
+	r.slid = make(map[string]time.Time)
+	r.alertCacheMux.Unlock()
+
+	if len(slid) == 0 {
+		return
+	}
+	if err := r.Store.RecordAlerts(ctx, slid); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to persist sliding debounce windows", "keys", len(slid))
+
+		r.alertCacheMux.Lock()
+		for key, at := range slid {
+			// Keep newer slides recorded while the write was in flight
+			if _, newer := r.slid[key]; !newer {
+				r.slid[key] = at
+			}
+		}
+		r.alertCacheMux.Unlock()
+	}
+}
+
+// debounceWindow returns the debounce window of the reason encoded in an alert key of this
+// cluster. ok is false for other keys, such as the report timestamps kept in the same store.
+func (r *PodReconciler) debounceWindow(key string) (time.Duration, bool) {
+	parsed, err := alertkey.Parse(key)
+	if err != nil || parsed.Cluster != r.Config.Cluster {
+		return 0, false
+	}
+	return r.Config.Debounce.WindowFor(parsed.Reason), true
+}
+
+// evictExpiredAlerts removes debounce entries that are outside the debounce window of their
+// reason. Keys that do not parse are kept for the longest window.
+func (r *PodReconciler) evictExpiredAlerts(now time.Time) int {
+	r.alertCacheMux.Lock()
+	defer r.alertCacheMux.Unlock()
+
+	evicted := 0
+	for key, at := range r.alertCache {
+		window, ok := r.debounceWindow(key)
+		if !ok {
+			window = r.Config.Debounce.MaxWindow()
+		}
+		if now.Sub(at) >= window {
+			delete(r.alertCache, key)
+			evicted++
+		}
+	}
+	alertCacheSize.Set(float64(len(r.alertCache)))
+
+	// Workload holds whose replicas recovered before the alert was sent are never ended
+	stale := r.Config.Debounce.WorkloadDelay.Duration + aggregationStale
+	for key, since := range r.aggregating {
+		if now.Sub(since) > stale {
+			delete(r.aggregating, key)
+		}
+	}
+	return evicted
+}
+
+// evictOldestAlertsLocked trims the debounce cache to its maximum size by removing the oldest
+// entries, plus a tenth of the maximum as headroom so eviction does not run on every alert.
+// Callers must hold r.alertCacheMux.
+func (r *PodReconciler) evictOldestAlertsLocked() {
+	if len(r.alertCache) <= alertCacheMaxEntries {
+		return
+	}
+
+	keys := make([]string, 0, len(r.alertCache))
+	for key := range r.alertCache {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return r.alertCache[keys[i]].Before(r.alertCache[keys[j]]) })
+
+	excess := len(r.alertCache) - alertCacheMaxEntries + alertCacheMaxEntries/10
+	for _, key := range keys[:min(excess, len(keys))] {
+		delete(r.alertCache, key)
+	}
+}
+
+// DebounceEntries returns when the alerts in the debounce cache were last sent, by alert key
+func (r *PodReconciler) DebounceEntries() map[string]time.Time {
+	r.alertCacheMux.RLock()
+	defer r.alertCacheMux.RUnlock()
+
+	return maps.Clone(r.alertCache)
+}
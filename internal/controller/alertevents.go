@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// Reasons of the events the operator reports on pods. Failed deliveries are reported as
+// AlertUndeliverable by the dead-letter recorder.
+const (
+	EventAlertSent       = "AlertSent"
+	EventAlertSuppressed = "AlertSuppressed"
+)
+
+// suppressed counts a suppressed alert, logs the decision and reports it as an event on the
+// pod, so kubectl describe shows why no alert was sent. detail explains the suppression, if
+// there is more to say than its kind.
+func (r *PodReconciler) suppressed(pod *corev1.Pod, trace alertTrace, suppression, reason, detail string) {
+	notify.RecordSuppressed(suppression, reason)
+	r.logDecision(pod, reason, DecisionSuppressed, trace, "suppression", suppression, "detail", detail)
+	if r.Recorder == nil {
+		return
+	}
+	if detail != "" {
+		r.Recorder.Eventf(pod, corev1.EventTypeNormal, EventAlertSuppressed, "%s alert suppressed: %s, %s", reason, suppression, detail)
+	} else {
+		r.Recorder.Eventf(pod, corev1.EventTypeNormal, EventAlertSuppressed, "%s alert suppressed: %s", reason, suppression)
+	}
+}
+
+// AlertEvents reports each delivered pod alert as an event on its pod. Alerts without a pod in
+// the cluster, such as node and forwarded Alertmanager alerts, are not reported.
+type AlertEvents struct {
+	Client   client.Reader
+	Recorder record.EventRecorder
+}
+
+// ObserveDelivery reports the notifiers an alert was delivered to
+func (e *AlertEvents) ObserveDelivery(alert notify.PodAlert, deliveries []notify.Delivery) {
+	if alert.PodName == "" {
+		return
+	}
+	var sinks []string
+	for _, delivery := range deliveries {
+		if delivery.Err == nil {
+			sinks = append(sinks, delivery.Sink)
+		}
+	}
+	if len(sinks) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), deadLetterTimeout)
+	defer cancel()
+	var pod corev1.Pod
+	if err := e.Client.Get(ctx, types.NamespacedName{Namespace: alert.Namespace, Name: alert.PodName}, &pod); err != nil {
+		return
+	}
+	e.Recorder.Eventf(&pod, corev1.EventTypeNormal, EventAlertSent, "%s alert sent to %s, channel %s",
+		alert.Reason, strings.Join(sinks, ", "), channelName(alert.Channel))
+}
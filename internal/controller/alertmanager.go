@@ -0,0 +1,365 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/alertkey"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/store"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// alertmanagerPath is the path Alertmanager posts webhook notifications to
+const alertmanagerPath = "/api/v1/alertmanager"
+
+// maxWebhookBytes bounds the size of webhook notifications
+const maxWebhookBytes = 4 << 20
+
+// webhookMessage is an Alertmanager webhook notification, version 4 of the payload
+type webhookMessage struct {
+	Version  string         `json:"version"`
+	GroupKey string         `json:"groupKey"`
+	Receiver string         `json:"receiver"`
+	Status   string         `json:"status"`
+	Alerts   []webhookAlert `json:"alerts"`
+}
+
+// webhookAlert is an alert of an Alertmanager webhook notification
+type webhookAlert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// AlertmanagerReceiver serves the Alertmanager webhook receiver contract, so Prometheus
+// alerts are sent through the pod alert pipeline: they are silenced, debounced, routed by
+// ownership rules and channel annotations, threaded per alert, and resolved when Alertmanager
+// reports them resolved. Debounce and open alert state is kept by the leader, so the receiver
+// runs on the leader only.
+type AlertmanagerReceiver struct {
+	Pods   *PodReconciler
+	Config config.AlertmanagerReceiverConfig
+	// Token is the bearer token Alertmanager must send. It may only be empty when the
+	// configuration allows unauthenticated requests.
+	Token string
+}
+
+// NeedLeaderElection runs the receiver on the leader, which owns the alert state
+func (a *AlertmanagerReceiver) NeedLeaderElection() bool {
+	return true
+}
+
+// Start serves the receiver until the context is cancelled
+func (a *AlertmanagerReceiver) Start(ctx context.Context) error {
+	if a.Token == "" && !a.Config.AllowUnauthenticated {
+		return errors.New("the Alertmanager receiver requires a token unless allowUnauthenticated is set")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(alertmanagerPath, a.handle)
+
+	srv := &http.Server{
+		Addr:              a.Config.BindAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	logf.FromContext(ctx).Info("Serving Alertmanager receiver", "address", a.Config.BindAddress)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// handle processes a webhook notification. Any failure is answered with a server error, so
+// Alertmanager retries the whole notification; alerts already sent are debounced on retry.
+func (a *AlertmanagerReceiver) handle(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if a.Token != "" {
+		token, _ := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(a.Token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	var msg webhookMessage
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxWebhookBytes)).Decode(&msg); err != nil {
+		http.Error(w, "invalid notification: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if msg.Version != "4" {
+		http.Error(w, fmt.Sprintf("unsupported notification version %q", msg.Version), http.StatusBadRequest)
+		return
+	}
+
+	var errs []error
+	for _, alert := range msg.Alerts {
+		if err := a.receive(req.Context(), alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		logf.FromContext(req.Context()).Error(err, "Failed to forward Alertmanager alerts", "groupKey", msg.GroupKey)
+		http.Error(w, "failed to forward alerts", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// receive sends a firing alert unless it is silenced or debounced, and resolves a resolved one
+func (a *AlertmanagerReceiver) receive(ctx context.Context, am webhookAlert) error {
+	logger := logf.FromContext(ctx)
+	r := a.Pods
+
+	namespace, pod, reason := am.Labels["namespace"], am.Labels["pod"], am.Labels["alertname"]
+	key := alertkey.Key{
+		Cluster:   r.Config.Cluster,
+		Namespace: namespace,
+		OwnerKind: alertkey.KindAlert,
+		OwnerName: alertFingerprint(am),
+		Reason:    reason,
+	}
+	alertKey := key.String()
+
+	if am.Status == "resolved" {
+		if !r.hasOpenAlerts(alertKey) {
+			return nil
+		}
+		return r.resolveAlerts(ctx, alertKey)
+	}
+
+	if a.isSilenced(ctx, am) {
+		logger.V(1).Info("Skipping silenced Alertmanager alert", "alertname", reason, "namespace", namespace)
+		notify.RecordSuppressed(notify.SuppressedSilence, reason)
+		return nil
+	}
+	if r.isRecentlyAlerted(ctx, alertKey, reason) {
+		logger.V(1).Info("Skipping Alertmanager alert within debounce window", "alertname", reason, "namespace", namespace)
+		notify.RecordSuppressed(notify.SuppressedDebounce, reason)
+		return nil
+	}
+
+	alert := forwardedAlert(am, r.Config.Cluster, alertKey)
+	var trace alertTrace
+	trace.add("source: Alertmanager alert %s", reason)
+	if err := a.routeAlert(ctx, am, &alert, &trace); err != nil {
+		// Fall back to the default destination rather than dropping the alert
+		logger.Error(err, "Failed to resolve channel override, using default", "alertname", reason, "namespace", namespace)
+	}
+	if r.Config.Routing.Trace {
+		alert.Trace = trace
+	}
+
+	if err := r.Notifier.SendPodAlert(alert); err != nil {
+		return err
+	}
+	r.recordAlert(ctx, alertKey)
+	r.trackOpenAlert(ctx, alert)
+
+	if err := r.Store.AppendHistory(ctx, store.HistoryEntry{
+		Key:       alertKey,
+		Namespace: namespace,
+		Pod:       pod,
+		Reason:    reason,
+		Channel:   alert.Channel,
+		Timestamp: alert.Timestamp,
+	}); err != nil {
+		logger.Error(err, "Failed to record alert history", "alertname", reason, "namespace", namespace)
+	}
+
+	logger.Info("Forwarded Alertmanager alert", "alertname", reason, "namespace", namespace, "pod", pod)
+	return nil
+}
+
+// isSilenced checks whether an active silence matches the alert's namespace, pod, name and
+// labels
+func (a *AlertmanagerReceiver) isSilenced(ctx context.Context, am webhookAlert) bool {
+	silences, err := a.Pods.Store.Silences(ctx)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to read silences, alerting anyway")
+		return false
+	}
+
+	now := time.Now()
+	for _, silence := range silences {
+		if silence.Active(now) && silence.Matches(am.Labels["namespace"], am.Labels["pod"], am.Labels["alertname"], am.Labels) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeAlert sets the channel and team of a forwarded alert. Alerts about an existing pod are
+// routed exactly like that pod's failures; others by ownership rules matching their namespace
+// and labels, then the channel annotation of their namespace.
+func (a *AlertmanagerReceiver) routeAlert(ctx context.Context, am webhookAlert, alert *notify.PodAlert, trace *alertTrace) error {
+	r := a.Pods
+	namespace := am.Labels["namespace"]
+
+	if name := am.Labels["pod"]; namespace != "" && name != "" {
+		var pod corev1.Pod
+		err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &pod)
+		if err == nil {
+			return r.routeAlert(ctx, &pod, alert, trace)
+		}
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	for i, rule := range r.Config.Routing.Ownership {
+		if rule.Matches(namespace, am.Labels, alert.ContainerName, nil) {
+			alert.Team, alert.Channel = rule.Team, rule.Channel
+			trace.add("ownership: rule #%d (team %s) matched", i+1, rule.Team)
+			trace.add("channel: %s from ownership rule", channelName(rule.Channel))
+			return nil
+		}
+	}
+	if namespace == "" {
+		trace.add("channel: default, alert has no namespace label")
+		return nil
+	}
+
+	var ns corev1.Namespace
+	if err := r.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		trace.add("channel: default, annotation lookup failed")
+		return client.IgnoreNotFound(err)
+	}
+	alert.Channel = ns.Annotations[ChannelAnnotation]
+	if alert.Channel != "" {
+		trace.add("channel: %s from namespace annotation", alert.Channel)
+	} else {
+		trace.add("channel: default, no %s annotation", ChannelAnnotation)
+	}
+	return nil
+}
+
+// alertFingerprint returns Alertmanager's fingerprint of the alert, or a hash of its labels
+// for senders that omit it
+func alertFingerprint(am webhookAlert) string {
+	if am.Fingerprint != "" {
+		return am.Fingerprint
+	}
+
+	names := make([]string, 0, len(am.Labels))
+	for name := range am.Labels {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	hash := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(hash, "%s=%s\x00", name, am.Labels[name])
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:8])
+}
+
+// forwardedAlert converts a firing Alertmanager alert to a pod alert. The alert name is the
+// reason, its severity label the severity, and its summary and description the message; the
+// remaining labels are listed below them.
+func forwardedAlert(am webhookAlert, cluster, key string) notify.PodAlert {
+	var message []string
+	for _, annotation := range []string{"summary", "description", "message"} {
+		if text := strings.TrimSpace(am.Annotations[annotation]); text != "" {
+			message = append(message, text)
+		}
+	}
+
+	shown := []string{"alertname", "namespace", "pod", "container", "severity"}
+	var labels []string
+	for name, value := range am.Labels {
+		if !slices.Contains(shown, name) {
+			labels = append(labels, fmt.Sprintf("%s=%s", name, value))
+		}
+	}
+	slices.Sort(labels)
+	if len(labels) > 0 {
+		message = append(message, "Labels: `"+strings.Join(labels, ", ")+"`")
+	}
+
+	var links []notify.Link
+	if am.GeneratorURL != "" {
+		links = append(links, notify.Link{Name: "Source", URL: am.GeneratorURL})
+	}
+	if runbook := am.Annotations["runbook_url"]; runbook != "" {
+		links = append(links, notify.Link{Name: "Runbook", URL: runbook})
+	}
+
+	timestamp := am.StartsAt
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	return notify.PodAlert{
+		PodName:       am.Labels["pod"],
+		Namespace:     am.Labels["namespace"],
+		ContainerName: am.Labels["container"],
+		Reason:        am.Labels["alertname"],
+		Message:       strings.Join(message, "\n\n"),
+		Timestamp:     timestamp,
+		Key:           key,
+		Cluster:       cluster,
+		Node:          am.Labels["node"],
+		Links:         links,
+		Severity:      alertSeverity(am.Labels["severity"]),
+	}
+}
+
+// alertSeverity maps the severity label of a Prometheus alert to a severity; unknown values
+// such as "none" are info
+func alertSeverity(label string) notify.Severity {
+	switch strings.ToLower(label) {
+	case "critical", "page", "error":
+		return notify.SeverityCritical
+	case "warning", "warn":
+		return notify.SeverityWarning
+	default:
+		return notify.SeverityInfo
+	}
+}
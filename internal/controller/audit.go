@@ -0,0 +1,204 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	geniev1alpha1 "github.com/ahmadrazalab/kube-slackgenie-operator/api/v1alpha1"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// Labels of AlertRecords, so records can be selected with kubectl get -l
+const (
+	AuditNamespaceLabel = "slackgenie.io/namespace"
+	AuditReasonLabel    = "slackgenie.io/reason"
+	AuditSeverityLabel  = "slackgenie.io/severity"
+	// AuditDeliveryLabel is delivered, failed, or partial when only some notifiers failed
+	AuditDeliveryLabel = "slackgenie.io/delivery"
+)
+
+const (
+	// auditTimeout bounds creating a single record
+	auditTimeout = 10 * time.Second
+	// auditPruneInterval is how often records past their retention are deleted
+	auditPruneInterval = time.Hour
+	// auditMessageLimit bounds the failure message kept in a record
+	auditMessageLimit = 1024
+)
+
+// +kubebuilder:rbac:groups=genie.slackgenie.io,resources=alertrecords,verbs=list;create;delete
+
+// AuditTrail records every pod alert the dispatcher sent as an AlertRecord, with the outcome
+// on each notifier, and deletes records once they are past the retention. Failing to record
+// an alert is logged and does not affect its delivery.
+type AuditTrail struct {
+	// Client creates and deletes records
+	Client client.Client
+	// Reader lists records for pruning; records are not cached, since they pile up
+	Reader client.Reader
+	// Config has the namespace already resolved
+	Config config.AuditConfig
+	Logger logr.Logger
+}
+
+// ObserveDelivery creates the AlertRecord of a sent alert
+func (a *AuditTrail) ObserveDelivery(alert notify.PodAlert, deliveries []notify.Delivery) {
+	ctx, cancel := context.WithTimeout(context.Background(), auditTimeout)
+	defer cancel()
+
+	record := alertRecord(a.Config.Namespace, alert, deliveries)
+	if err := a.Client.Create(ctx, record); err != nil {
+		a.Logger.Error(err, "Failed to record alert", "key", alert.Key)
+	}
+}
+
+// alertRecord builds the record of an alert
+func alertRecord(namespace string, alert notify.PodAlert, deliveries []notify.Delivery) *geniev1alpha1.AlertRecord {
+	detectedAt := alert.Timestamp
+	if detectedAt.IsZero() {
+		detectedAt = time.Now()
+	}
+	message := alert.Message
+	if len(message) > auditMessageLimit {
+		message = message[:auditMessageLimit] + "…"
+	}
+
+	record := &geniev1alpha1.AlertRecord{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    namespace,
+			GenerateName: "alert-",
+			Labels:       map[string]string{},
+		},
+		Spec: geniev1alpha1.AlertRecordSpec{
+			Key:        alert.Key,
+			Cluster:    alert.Cluster,
+			Namespace:  alert.Namespace,
+			Pod:        alert.PodName,
+			Container:  alert.ContainerName,
+			Workload:   alert.Workload(),
+			Reason:     alert.Reason,
+			Severity:   string(alert.AlertSeverity()),
+			Message:    message,
+			Channel:    alert.Channel,
+			Team:       alert.Team,
+			DetectedAt: metav1.NewTime(detectedAt),
+		},
+	}
+	// Pod names make records easy to find with kubectl get; the server shortens long ones
+	if alert.PodName != "" && len(validation.IsDNS1123Subdomain(alert.PodName)) == 0 {
+		record.GenerateName = alert.PodName + "-"
+	}
+
+	failed := 0
+	for _, delivery := range deliveries {
+		entry := geniev1alpha1.AlertDelivery{
+			Sink:   delivery.Sink,
+			Status: geniev1alpha1.DeliveryDelivered,
+			Time:   metav1.NewTime(delivery.At),
+		}
+		if delivery.Err != nil {
+			entry.Status = geniev1alpha1.DeliveryFailed
+			entry.Error = delivery.Err.Error()
+			failed++
+		}
+		record.Spec.Deliveries = append(record.Spec.Deliveries, entry)
+	}
+
+	delivery := "partial"
+	switch failed {
+	case 0:
+		delivery = "delivered"
+	case len(deliveries):
+		delivery = "failed"
+	}
+	labels := map[string]string{
+		AuditNamespaceLabel: alert.Namespace,
+		AuditReasonLabel:    alert.Reason,
+		AuditSeverityLabel:  record.Spec.Severity,
+		AuditDeliveryLabel:  delivery,
+	}
+	for name, value := range labels {
+		// Reasons of forwarded alerts are arbitrary alert names
+		if value != "" && len(validation.IsValidLabelValue(value)) == 0 {
+			record.Labels[name] = value
+		}
+	}
+	return record
+}
+
+// NeedLeaderElection prunes on the leader only
+func (a *AuditTrail) NeedLeaderElection() bool {
+	return true
+}
+
+// Start deletes records past the retention until the context is cancelled
+func (a *AuditTrail) Start(ctx context.Context) error {
+	ticker := time.NewTicker(auditPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.prune(ctx); err != nil {
+			a.Logger.Error(err, "Failed to delete expired alert records")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// prune deletes the records created before the retention, reading only their metadata
+func (a *AuditTrail) prune(ctx context.Context) error {
+	cutoff := time.Now().Add(-a.Config.Retention.Duration)
+	deleted := 0
+
+	var records metav1.PartialObjectMetadataList
+	records.SetGroupVersionKind(geniev1alpha1.GroupVersion.WithKind("AlertRecordList"))
+	for {
+		if err := a.Reader.List(ctx, &records, client.InNamespace(a.Config.Namespace), client.Limit(500), client.Continue(records.Continue)); err != nil {
+			return err
+		}
+		for i := range records.Items {
+			record := &records.Items[i]
+			if !record.CreationTimestamp.Time.Before(cutoff) {
+				continue
+			}
+			record.SetGroupVersionKind(geniev1alpha1.GroupVersion.WithKind("AlertRecord"))
+			if err := a.Client.Delete(ctx, record); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			deleted++
+		}
+		if records.Continue == "" {
+			break
+		}
+	}
+
+	if deleted > 0 {
+		a.Logger.V(1).Info("Deleted expired alert records", "count", deleted)
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+
+// backendOutage is an Ingress backend Service without ready endpoints
+type backendOutage struct {
+	// since is when the Service was first seen without ready endpoints
+	since time.Time
+	// alerted reports whether the outage was alerted on
+	alerted bool
+}
+
+// BackendReconciler alerts when a Service referenced by an Ingress has no ready endpoints,
+// which means user-facing downtime even when the pod alerts of its workload were debounced.
+// Alerts are routed like pod alerts, see routeObject.
+type BackendReconciler struct {
+	client.Client
+	Notifier notify.Notifier
+	Config   config.BackendsConfig
+	Routing  config.RoutingConfig
+
+	mu sync.Mutex
+	// outages are the Ingress backends currently without ready endpoints
+	outages map[types.NamespacedName]*backendOutage
+}
+
+// Reconcile alerts on a Service referenced by an Ingress once it has had no ready endpoints for
+// the grace period, and posts a resolution when it has ready endpoints again
+func (r *BackendReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	var service corev1.Service
+	if err := r.Get(ctx, req.NamespacedName, &service); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			r.forget(req.NamespacedName)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	routes, err := r.ingressRoutes(ctx, &service)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(routes) == 0 {
+		r.forget(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	ready, total, err := r.endpointCounts(ctx, &service)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	now := time.Now()
+	r.mu.Lock()
+	outage := r.outages[req.NamespacedName]
+	if ready == 0 && outage == nil {
+		outage = &backendOutage{since: now}
+		r.outages[req.NamespacedName] = outage
+	}
+	since, alerted := time.Time{}, false
+	if outage != nil {
+		since, alerted = outage.since, outage.alerted
+	}
+	r.mu.Unlock()
+
+	if ready > 0 {
+		if alerted {
+			channel, err := routeObject(ctx, r.Client, r.Routing.Ownership, &service)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if err := r.Notifier.SendMetaAlert(backendRecoveryAlert(&service, ready, since, channel, now)); err != nil {
+				return ctrl.Result{}, err
+			}
+			logger.Info("Ingress backend recovered", "namespace", service.Namespace, "service", service.Name)
+		}
+		r.forget(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+	if alerted {
+		return ctrl.Result{}, nil
+	}
+	if wait := r.Config.Grace.Duration - now.Sub(since); wait > 0 {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	channel, err := routeObject(ctx, r.Client, r.Routing.Ownership, &service)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Notifier.SendMetaAlert(backendAlert(&service, routes, total, since, channel, now)); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.mu.Lock()
+	outage.alerted = true
+	r.mu.Unlock()
+	logger.Info("Ingress backend has no ready endpoints", "namespace", service.Namespace, "service", service.Name,
+		"endpoints", total)
+	return ctrl.Result{}, nil
+}
+
+// forget drops the outage of a Service that recovered, was deleted or is no longer referenced
+func (r *BackendReconciler) forget(service types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.outages, service)
+}
+
+// ingressBackends returns the names of the Services an Ingress routes to
+func ingressBackends(ingress *networkingv1.Ingress) []string {
+	var services []string
+	add := func(backend *networkingv1.IngressBackend) {
+		if backend != nil && backend.Service != nil && !slices.Contains(services, backend.Service.Name) {
+			services = append(services, backend.Service.Name)
+		}
+	}
+
+	add(ingress.Spec.DefaultBackend)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			add(&path.Backend)
+		}
+	}
+	return services
+}
+
+// ingressRoutes describes the Ingress routes to a Service, e.g. "shop (shop.example.com/api)",
+// sorted; empty if no Ingress references it
+func (r *BackendReconciler) ingressRoutes(ctx context.Context, service *corev1.Service) ([]string, error) {
+	var ingresses networkingv1.IngressList
+	if err := r.List(ctx, &ingresses, client.InNamespace(service.Namespace)); err != nil {
+		return nil, err
+	}
+
+	var routes []string
+	for _, ingress := range ingresses.Items {
+		if !slices.Contains(ingressBackends(&ingress), service.Name) {
+			continue
+		}
+
+		var paths []string
+		if backend := ingress.Spec.DefaultBackend; backend != nil && backend.Service != nil && backend.Service.Name == service.Name {
+			paths = append(paths, "default backend")
+		}
+		for _, rule := range ingress.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				if path.Backend.Service != nil && path.Backend.Service.Name == service.Name {
+					paths = append(paths, rule.Host+path.Path)
+				}
+			}
+		}
+		routes = append(routes, fmt.Sprintf("%s (%s)", ingress.Name, strings.Join(paths, ", ")))
+	}
+	sort.Strings(routes)
+	return routes, nil
+}
+
+// endpointCounts returns the ready and total endpoints of a Service across its EndpointSlices
+func (r *BackendReconciler) endpointCounts(ctx context.Context, service *corev1.Service) (int, int, error) {
+	var endpointSlices discoveryv1.EndpointSliceList
+	if err := r.List(ctx, &endpointSlices, client.InNamespace(service.Namespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: service.Name}); err != nil {
+		return 0, 0, err
+	}
+
+	var ready, total int
+	for _, endpointSlice := range endpointSlices.Items {
+		for _, endpoint := range endpointSlice.Endpoints {
+			total++
+			// A nil ready condition means unknown, which consumers treat as ready
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				ready++
+			}
+		}
+	}
+	return ready, total, nil
+}
+
+// backendAlert builds the alert for an Ingress backend Service without ready endpoints
+func backendAlert(service *corev1.Service, routes []string, endpoints int, since time.Time, channel string, now time.Time) notify.MetaAlert {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Service `%s/%s` has had no ready endpoints for %s. It backs Ingress %s.\n",
+		service.Namespace, service.Name, formatReminderAge(now.Sub(since)), strings.Join(routes, "; "))
+
+	switch {
+	case endpoints > 0:
+		fmt.Fprintf(&b, "%d endpoint(s) exist but none is ready: the pods fail their readiness probes or are terminating.", endpoints)
+	case len(service.Spec.Selector) == 0:
+		b.WriteString("The Service has no selector, so its endpoints are managed outside Kubernetes and none are registered.")
+	default:
+		fmt.Fprintf(&b, "No running pods match the Service's selector `%s`: the workload may be scaled to zero, or its pods fail to start.",
+			labels.SelectorFromSet(service.Spec.Selector).String())
+	}
+	b.WriteString(" Requests through the Ingress fail, typically with 502 or 503, even if alerts for the pods were debounced.")
+
+	return notify.MetaAlert{
+		Title:     fmt.Sprintf("🌐 Ingress backend %s/%s has no ready endpoints", service.Namespace, service.Name),
+		Text:      b.String(),
+		Severity:  notify.SeverityCritical,
+		Channel:   channel,
+		Timestamp: now,
+	}
+}
+
+// backendRecoveryAlert builds the message for an Ingress backend that has ready endpoints again
+func backendRecoveryAlert(service *corev1.Service, ready int, since time.Time, channel string, now time.Time) notify.MetaAlert {
+	return notify.MetaAlert{
+		Title: fmt.Sprintf("✅ Ingress backend %s/%s recovered", service.Namespace, service.Name),
+		Text: fmt.Sprintf("Service `%s/%s` has %d ready endpoint(s) again after %s without any.",
+			service.Namespace, service.Name, ready, formatReminderAge(now.Sub(since))),
+		Severity:  notify.SeverityInfo,
+		Channel:   channel,
+		Timestamp: now,
+	}
+}
+
+// mapIngressToServices maps an Ingress to the Services it routes to
+func mapIngressToServices(_ context.Context, obj client.Object) []reconcile.Request {
+	ingress := obj.(*networkingv1.Ingress)
+	var requests []reconcile.Request
+	for _, name := range ingressBackends(ingress) {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ingress.Namespace, Name: name}})
+	}
+	return requests
+}
+
+// mapEndpointSliceToService maps an EndpointSlice to the Service it belongs to
+func mapEndpointSliceToService(_ context.Context, obj client.Object) []reconcile.Request {
+	name := obj.GetLabels()[discoveryv1.LabelServiceName]
+	if name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}}}
+}
+
+// SetupWithManager sets up the controller with the Manager. Services are reconciled when their
+// EndpointSlices or the Ingresses referencing them change.
+func (r *BackendReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.outages = make(map[types.NamespacedName]*backendOutage)
+
+	servicePredicate := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetLabels()[discoveryv1.LabelServiceName] != ""
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Service{}).
+		Watches(&networkingv1.Ingress{}, handler.EnqueueRequestsFromMapFunc(mapIngressToServices)).
+		Watches(&discoveryv1.EndpointSlice{},
+			handler.EnqueueRequestsFromMapFunc(mapEndpointSliceToService),
+			builder.WithPredicates(servicePredicate),
+		).
+		Named("backend").
+		Complete(r)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+// lastAppliedAnnotation is the copy of the whole object kubectl apply keeps on it
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// TrimCachedObject is a cache transform dropping what alerting never reads before objects are
+// stored in the informer cache: managed fields and the last-applied annotation of every object,
+// and from pods the environment values, volumes and projected sources other than ConfigMap and
+// Secret references, which explain CreateContainerConfigError. In large clusters these make up
+// most of a pod's size.
+func TrimCachedObject(obj any) (any, error) {
+	if _, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		return obj, nil
+	}
+	if accessor, err := meta.Accessor(obj); err == nil {
+		accessor.SetManagedFields(nil)
+		if annotations := accessor.GetAnnotations(); annotations[lastAppliedAnnotation] != "" {
+			delete(annotations, lastAppliedAnnotation)
+			accessor.SetAnnotations(annotations)
+		}
+	}
+	if pod, ok := obj.(*corev1.Pod); ok {
+		trimPodSpec(&pod.Spec)
+	}
+	return obj, nil
+}
+
+// trimPodSpec keeps only the ConfigMap and Secret references of the pod's environment and
+// volumes
+func trimPodSpec(spec *corev1.PodSpec) {
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for i := range containers {
+			container := &containers[i]
+			env := container.Env[:0]
+			for _, variable := range container.Env {
+				if variable.ValueFrom != nil && (variable.ValueFrom.ConfigMapKeyRef != nil || variable.ValueFrom.SecretKeyRef != nil) {
+					env = append(env, corev1.EnvVar{Name: variable.Name, ValueFrom: &corev1.EnvVarSource{
+						ConfigMapKeyRef: variable.ValueFrom.ConfigMapKeyRef,
+						SecretKeyRef:    variable.ValueFrom.SecretKeyRef,
+					}})
+				}
+			}
+			container.Env = env
+		}
+	}
+	spec.EphemeralContainers = nil
+
+	volumes := spec.Volumes[:0]
+	for _, volume := range spec.Volumes {
+		if volume.ConfigMap != nil || volume.Secret != nil {
+			volumes = append(volumes, corev1.Volume{Name: volume.Name, VolumeSource: corev1.VolumeSource{
+				ConfigMap: volume.ConfigMap,
+				Secret:    volume.Secret,
+			}})
+		}
+	}
+	spec.Volumes = volumes
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// certificateGVK is the cert-manager Certificate kind, read as unstructured to avoid depending
+// on cert-manager's API module
+var certificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch
+
+// Certificate problems, from most to least severe
+const (
+	certExpired  = "Expired"
+	certNotReady = "NotReady"
+	certExpiring = "Expiring"
+)
+
+// CertificateReconciler alerts on cert-manager Certificates that have been NotReady for the
+// grace period or are close to expiry. Alerts are routed like pod alerts, see routeObject.
+type CertificateReconciler struct {
+	client.Client
+	Notifier notify.Notifier
+	Config   config.CertificatesConfig
+	Routing  config.RoutingConfig
+
+	mu sync.Mutex
+	// alerted is the problem each failing Certificate was last alerted on
+	alerted map[types.NamespacedName]string
+}
+
+// Reconcile alerts on a Certificate's problem unless the same problem was already alerted on
+func (r *CertificateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	cert := &unstructured.Unstructured{}
+	cert.SetGroupVersionKind(certificateGVK)
+	if err := r.Get(ctx, req.NamespacedName, cert); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			r.setAlerted(req.NamespacedName, "")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	now := time.Now()
+	problem, wait := r.certificateProblem(cert, now)
+	result := ctrl.Result{RequeueAfter: wait}
+
+	r.mu.Lock()
+	alerted := r.alerted[req.NamespacedName]
+	r.mu.Unlock()
+	if problem == "" || problem == alerted {
+		r.setAlerted(req.NamespacedName, problem)
+		return result, nil
+	}
+
+	channel, err := routeObject(ctx, r.Client, r.Routing.Ownership, cert)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Notifier.SendMetaAlert(r.certificateAlert(cert, problem, channel, now)); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.setAlerted(req.NamespacedName, problem)
+	logger.Info("Certificate alert sent", "namespace", cert.GetNamespace(), "certificate", cert.GetName(), "problem", problem)
+	return result, nil
+}
+
+// setAlerted records the problem a Certificate was alerted on, or forgets it if empty
+func (r *CertificateReconciler) setAlerted(cert types.NamespacedName, problem string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if problem == "" {
+		delete(r.alerted, cert)
+		return
+	}
+	r.alerted[cert] = problem
+}
+
+// certificateCondition returns the status, reason, message and transition time of a
+// condition of a Certificate; the status is empty if the condition is missing
+func certificateCondition(cert *unstructured.Unstructured, conditionType string) (string, string, string, time.Time) {
+	conditions, _, _ := unstructured.NestedSlice(cert.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if !ok || condition["type"] != conditionType {
+			continue
+		}
+		status, _, _ := unstructured.NestedString(condition, "status")
+		reason, _, _ := unstructured.NestedString(condition, "reason")
+		message, _, _ := unstructured.NestedString(condition, "message")
+		transition, _, _ := unstructured.NestedString(condition, "lastTransitionTime")
+		since, _ := time.Parse(time.RFC3339, transition)
+		return status, reason, message, since
+	}
+	return "", "", "", time.Time{}
+}
+
+// certificateTime returns a timestamp from the status of a Certificate, or the zero time
+func certificateTime(cert *unstructured.Unstructured, field string) time.Time {
+	value, _, _ := unstructured.NestedString(cert.Object, "status", field)
+	parsed, _ := time.Parse(time.RFC3339, value)
+	return parsed
+}
+
+// certificateProblem returns the most severe problem of a Certificate that is due, and how
+// long until a problem that is not yet due, such as the end of the grace period or the start
+// of the expiry window, becomes due; zero if there is none
+func (r *CertificateReconciler) certificateProblem(cert *unstructured.Unstructured, now time.Time) (string, time.Duration) {
+	notAfter := certificateTime(cert, "notAfter")
+	if !notAfter.IsZero() && !now.Before(notAfter) {
+		return certExpired, 0
+	}
+
+	var wait time.Duration
+	soonest := func(d time.Duration) {
+		if d > 0 && (wait == 0 || d < wait) {
+			wait = d
+		}
+	}
+
+	status, _, _, since := certificateCondition(cert, "Ready")
+	if status != "" && status != string(corev1.ConditionTrue) {
+		if since.IsZero() {
+			since = cert.GetCreationTimestamp().Time
+		}
+		remaining := r.Config.Grace.Duration - now.Sub(since)
+		if remaining <= 0 {
+			return certNotReady, 0
+		}
+		soonest(remaining)
+	}
+
+	if !notAfter.IsZero() {
+		expiryWindow := notAfter.Add(-time.Duration(r.Config.ExpiryDays) * 24 * time.Hour)
+		if !now.Before(expiryWindow) {
+			return certExpiring, wait
+		}
+		soonest(expiryWindow.Sub(now))
+	}
+	return "", wait
+}
+
+// certificateAlert builds the alert for a Certificate's problem
+func (r *CertificateReconciler) certificateAlert(cert *unstructured.Unstructured, problem, channel string, now time.Time) notify.MetaAlert {
+	name := cert.GetNamespace() + "/" + cert.GetName()
+	notAfter := certificateTime(cert, "notAfter")
+
+	var title string
+	severity := notify.SeverityWarning
+	switch problem {
+	case certExpired:
+		title = fmt.Sprintf("🔐 Certificate %s has expired", name)
+		severity = notify.SeverityCritical
+	case certNotReady:
+		title = fmt.Sprintf("🔐 Certificate %s is NotReady", name)
+	default:
+		title = fmt.Sprintf("🔐 Certificate %s expires in %s", name, formatCertificateDays(notAfter.Sub(now)))
+	}
+
+	var b strings.Builder
+	dnsNames, _, _ := unstructured.NestedStringSlice(cert.Object, "spec", "dnsNames")
+	if commonName, _, _ := unstructured.NestedString(cert.Object, "spec", "commonName"); len(dnsNames) == 0 && commonName != "" {
+		dnsNames = []string{commonName}
+	}
+	if len(dnsNames) > 0 {
+		fmt.Fprintf(&b, "*Names:* %s\n", strings.Join(dnsNames, ", "))
+	}
+	issuerKind, _, _ := unstructured.NestedString(cert.Object, "spec", "issuerRef", "kind")
+	issuerName, _, _ := unstructured.NestedString(cert.Object, "spec", "issuerRef", "name")
+	if issuerKind == "" {
+		issuerKind = "Issuer"
+	}
+	fmt.Fprintf(&b, "*Issuer:* %s %s\n", issuerKind, issuerName)
+	if secret, _, _ := unstructured.NestedString(cert.Object, "spec", "secretName"); secret != "" {
+		fmt.Fprintf(&b, "*Secret:* %s\n", secret)
+	}
+	if !notAfter.IsZero() {
+		fmt.Fprintf(&b, "*Expires:* %s\n", notAfter.UTC().Format(time.RFC3339))
+	}
+	if renewal := certificateTime(cert, "renewalTime"); !renewal.IsZero() && renewal.Before(now) {
+		fmt.Fprintf(&b, "*Renewal due since:* %s\n", renewal.UTC().Format(time.RFC3339))
+	}
+
+	for _, conditionType := range []string{"Ready", "Issuing"} {
+		status, reason, message, _ := certificateCondition(cert, conditionType)
+		if status == "" || (conditionType == "Ready" && status == string(corev1.ConditionTrue)) {
+			continue
+		}
+		fmt.Fprintf(&b, "*%s:* %s", conditionType, status)
+		if reason != "" {
+			fmt.Fprintf(&b, " (%s)", reason)
+		}
+		if message != "" {
+			fmt.Fprintf(&b, ": %s", message)
+		}
+		b.WriteString("\n")
+	}
+
+	if problem == certExpiring {
+		b.WriteString("cert-manager renews certificates well before expiry, so renewal is failing or the certificate's renewBefore is unusually short.")
+	} else {
+		fmt.Fprintf(&b, "The CertificateRequests of the Certificate and the status of its issuer explain issuance failures: `kubectl describe certificaterequest -n %s`", cert.GetNamespace())
+	}
+
+	return notify.MetaAlert{
+		Title:     title,
+		Text:      b.String(),
+		Severity:  severity,
+		Channel:   channel,
+		Timestamp: now,
+	}
+}
+
+// formatCertificateDays renders the time until expiry in days, or hours on the last day
+func formatCertificateDays(d time.Duration) string {
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+// SetupWithManager sets up the controller with the Manager if cert-manager is installed.
+// Certificates are reconciled when their status changes; timers cover grace periods and expiry.
+func (r *CertificateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if _, err := mgr.GetRESTMapper().RESTMapping(certificateGVK.GroupKind(), certificateGVK.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			mgr.GetLogger().Info("cert-manager is not installed, Certificate alerts are off")
+			return nil
+		}
+		return err
+	}
+	r.alerted = make(map[types.NamespacedName]string)
+
+	statusChanged := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldStatus, _, _ := unstructured.NestedMap(e.ObjectOld.(*unstructured.Unstructured).Object, "status")
+			newStatus, _, _ := unstructured.NestedMap(e.ObjectNew.(*unstructured.Unstructured).Object, "status")
+			return !equality.Semantic.DeepEqual(oldStatus, newStatus)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+
+	cert := &unstructured.Unstructured{}
+	cert.SetGroupVersionKind(certificateGVK)
+	return ctrl.NewControllerManagedBy(mgr).
+		For(cert, builder.WithPredicates(statusChanged)).
+		Named("certificate").
+		Complete(r)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ChaosExperimentAnnotation marks a pod, its workload or its namespace as the target of a
+// running chaos experiment, for chaos tooling other than Chaos Mesh and LitmusChaos. The
+// value names the experiment.
+const ChaosExperimentAnnotation = "slackgenie.io/chaos-experiment"
+
+// chaosMeshPauseAnnotation is set by Chaos Mesh on paused experiments
+const chaosMeshPauseAnnotation = "experiment.chaos-mesh.org/pause"
+
+// chaosMeshKinds are the Chaos Mesh experiment kinds that select pods
+var chaosMeshKinds = []string{
+	"PodChaos", "NetworkChaos", "StressChaos", "IOChaos", "TimeChaos", "DNSChaos", "HTTPChaos", "JVMChaos",
+}
+
+// litmusEngineListGVK is the LitmusChaos engine list kind
+var litmusEngineListGVK = schema.GroupVersionKind{Group: "litmuschaos.io", Version: "v1alpha1", Kind: "ChaosEngineList"}
+
+// +kubebuilder:rbac:groups=chaos-mesh.org,resources=podchaos;networkchaos;stresschaos;iochaos;timechaos;dnschaos;httpchaos;jvmchaos,verbs=get;list;watch
+// +kubebuilder:rbac:groups=litmuschaos.io,resources=chaosengines,verbs=get;list;watch
+
+// activeChaosExperiment returns the name of a running chaos experiment targeting the pod, or
+// an empty string. Chaos CRDs are optional; kinds that are not installed are skipped.
+func (r *PodReconciler) activeChaosExperiment(ctx context.Context, pod *corev1.Pod) (string, error) {
+	if name := pod.Annotations[ChaosExperimentAnnotation]; name != "" {
+		return name, nil
+	}
+	owner, err := r.resolveOwner(ctx, pod)
+	if err != nil {
+		return "", err
+	}
+	if owner != nil {
+		if name := owner.Annotations[ChaosExperimentAnnotation]; name != "" {
+			return name, nil
+		}
+	}
+	var namespace corev1.Namespace
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Namespace}, &namespace); client.IgnoreNotFound(err) != nil {
+		return "", err
+	}
+	if name := namespace.Annotations[ChaosExperimentAnnotation]; name != "" {
+		return name, nil
+	}
+
+	for _, kind := range chaosMeshKinds {
+		experiments, err := r.listOptional(ctx, schema.GroupVersionKind{Group: "chaos-mesh.org", Version: "v1alpha1", Kind: kind + "List"})
+		if err != nil {
+			return "", err
+		}
+		for _, experiment := range experiments {
+			if chaosMeshTargets(experiment, pod) {
+				return fmt.Sprintf("%s %s/%s", kind, experiment.GetNamespace(), experiment.GetName()), nil
+			}
+		}
+	}
+
+	engines, err := r.listOptional(ctx, litmusEngineListGVK)
+	if err != nil {
+		return "", err
+	}
+	for _, engine := range engines {
+		if litmusTargets(engine, pod) {
+			return fmt.Sprintf("ChaosEngine %s/%s", engine.GetNamespace(), engine.GetName()), nil
+		}
+	}
+	return "", nil
+}
+
+// listOptional lists all objects of a kind whose CRD may not be installed
+func (r *PodReconciler) listOptional(ctx context.Context, gvk schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk)
+	if err := r.List(ctx, list); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// chaosMeshTargets reports whether a running Chaos Mesh experiment selects the pod
+func chaosMeshTargets(experiment unstructured.Unstructured, pod *corev1.Pod) bool {
+	if experiment.GetAnnotations()[chaosMeshPauseAnnotation] == "true" {
+		return false
+	}
+	if phase, _, _ := unstructured.NestedString(experiment.Object, "status", "experiment", "desiredPhase"); phase != "Run" {
+		return false
+	}
+
+	// Explicitly listed pods, keyed by namespace
+	if pods, found, _ := unstructured.NestedMap(experiment.Object, "spec", "selector", "pods"); found {
+		names, _, _ := unstructured.NestedStringSlice(pods, pod.Namespace)
+		return slices.Contains(names, pod.Name)
+	}
+
+	namespaces, found, _ := unstructured.NestedStringSlice(experiment.Object, "spec", "selector", "namespaces")
+	if !found || len(namespaces) == 0 {
+		namespaces = []string{experiment.GetNamespace()}
+	}
+	if !slices.Contains(namespaces, pod.Namespace) {
+		return false
+	}
+
+	selector, _, _ := unstructured.NestedStringMap(experiment.Object, "spec", "selector", "labelSelectors")
+	return labels.SelectorFromSet(selector).Matches(labels.Set(pod.Labels))
+}
+
+// litmusTargets reports whether an active LitmusChaos engine targets the pod
+func litmusTargets(engine unstructured.Unstructured, pod *corev1.Pod) bool {
+	if state, _, _ := unstructured.NestedString(engine.Object, "spec", "engineState"); state != "active" {
+		return false
+	}
+	if status, _, _ := unstructured.NestedString(engine.Object, "status", "engineStatus"); status == "completed" || status == "stopped" {
+		return false
+	}
+
+	namespace, _, _ := unstructured.NestedString(engine.Object, "spec", "appinfo", "appns")
+	if namespace == "" {
+		namespace = engine.GetNamespace()
+	}
+	if namespace != pod.Namespace {
+		return false
+	}
+
+	appLabel, _, _ := unstructured.NestedString(engine.Object, "spec", "appinfo", "applabel")
+	selector, err := labels.Parse(appLabel)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(pod.Labels))
+}
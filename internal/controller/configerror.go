@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Waiting reasons of containers the kubelet could not create
+const (
+	// ReasonCreateContainerConfigError is set when a ConfigMap, Secret or key the container
+	// references does not exist
+	ReasonCreateContainerConfigError = "CreateContainerConfigError"
+	// ReasonCreateContainerError is set when the runtime fails to create the container
+	ReasonCreateContainerError = "CreateContainerError"
+)
+
+// Messages of CreateContainerConfigError, e.g. `configmap "app-config" not found` and
+// `couldn't find key DB_HOST in Secret shop/db`
+var (
+	missingObjectPattern = regexp.MustCompile(`(?i)^(configmap|secret) "([^"]+)" not found`)
+	missingKeyPattern    = regexp.MustCompile(`couldn't find key (\S+) in (ConfigMap|Secret) (?:[^/\s]+/)?(\S+)`)
+)
+
+// configReference is a missing ConfigMap or Secret, or a missing key of one
+type configReference struct {
+	kind string
+	name string
+	// key is empty if the whole object is missing
+	key string
+}
+
+// parseConfigError extracts the missing reference from a CreateContainerConfigError message
+func parseConfigError(message string) (configReference, bool) {
+	if m := missingKeyPattern.FindStringSubmatch(message); m != nil {
+		return configReference{kind: m[2], name: m[3], key: m[1]}, true
+	}
+	if m := missingObjectPattern.FindStringSubmatch(strings.TrimSpace(message)); m != nil {
+		kind := "ConfigMap"
+		if strings.EqualFold(m[1], "secret") {
+			kind = "Secret"
+		}
+		return configReference{kind: kind, name: m[2]}, true
+	}
+	return configReference{}, false
+}
+
+// describeConfigError explains which ConfigMap or Secret reference of a container is missing
+// and where the container uses it. It returns an empty string if the message names no
+// missing reference.
+func describeConfigError(pod *corev1.Pod, containerName, message string) string {
+	ref, ok := parseConfigError(message)
+	if !ok {
+		return ""
+	}
+
+	var b strings.Builder
+	if ref.key != "" {
+		fmt.Fprintf(&b, "🔑 %s `%s` has no key `%s`.", ref.kind, ref.name, ref.key)
+	} else {
+		fmt.Fprintf(&b, "🔑 %s `%s` does not exist in namespace `%s`.", ref.kind, ref.name, pod.Namespace)
+	}
+	if uses := configReferenceUses(pod, containerName, ref); len(uses) > 0 {
+		fmt.Fprintf(&b, " Referenced by %s.", strings.Join(uses, ", "))
+	}
+	b.WriteString(" Create it, fix the reference, or mark the reference `optional: true`.")
+	return b.String()
+}
+
+// configReferenceUses lists where a container references the ConfigMap or Secret, e.g.
+// "env `DB_HOST`", "envFrom" or "volume `config`"
+func configReferenceUses(pod *corev1.Pod, containerName string, ref configReference) []string {
+	var uses []string
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if container.Name != containerName {
+			continue
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if selector := env.ValueFrom.ConfigMapKeyRef; selector != nil && ref.kind == "ConfigMap" &&
+				selector.Name == ref.name && (ref.key == "" || selector.Key == ref.key) {
+				uses = append(uses, fmt.Sprintf("env `%s`", env.Name))
+			}
+			if selector := env.ValueFrom.SecretKeyRef; selector != nil && ref.kind == "Secret" &&
+				selector.Name == ref.name && (ref.key == "" || selector.Key == ref.key) {
+				uses = append(uses, fmt.Sprintf("env `%s`", env.Name))
+			}
+		}
+		for _, source := range container.EnvFrom {
+			if (source.ConfigMapRef != nil && ref.kind == "ConfigMap" && source.ConfigMapRef.Name == ref.name) ||
+				(source.SecretRef != nil && ref.kind == "Secret" && source.SecretRef.Name == ref.name) {
+				uses = append(uses, "envFrom")
+			}
+		}
+		mounted := make(map[string]bool)
+		for _, mount := range container.VolumeMounts {
+			mounted[mount.Name] = true
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if !mounted[volume.Name] {
+				continue
+			}
+			if (volume.ConfigMap != nil && ref.kind == "ConfigMap" && volume.ConfigMap.Name == ref.name) ||
+				(volume.Secret != nil && ref.kind == "Secret" && volume.Secret.SecretName == ref.name) {
+				uses = append(uses, fmt.Sprintf("volume `%s`", volume.Name))
+			}
+		}
+	}
+	return uses
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CostEstimator looks up what running a workload costs
+type CostEstimator interface {
+	// HourlyCost returns the average hourly cost of a workload, and false when it is unknown
+	HourlyCost(ctx context.Context, namespace, kind, name string) (float64, bool, error)
+}
+
+// costContext describes the hourly cost of the pod's workload and the share of it spent on
+// crash-looping replicas. It returns an empty string for bare pods and workloads without
+// cost data.
+func (r *PodReconciler) costContext(ctx context.Context, pod *corev1.Pod) (string, error) {
+	owner, err := r.resolveOwner(ctx, pod)
+	if err != nil || owner == nil {
+		return "", err
+	}
+
+	hourly, found, err := r.Costs.HourlyCost(ctx, pod.Namespace, owner.Kind, owner.Name)
+	if err != nil || !found {
+		return "", err
+	}
+
+	text := fmt.Sprintf("💰 %s %s costs about $%.2f/hour", owner.Kind, owner.Name, hourly)
+
+	replicas, crashLooping, err := r.countReplicas(ctx, pod)
+	if err != nil {
+		return text, err
+	}
+	if replicas > 0 && crashLooping > 0 {
+		idle := hourly / float64(replicas) * float64(crashLooping)
+		text += fmt.Sprintf(", of which ~$%.2f/hour is spent on %d of %d replicas crash-looping", idle, crashLooping, replicas)
+	}
+	return text, nil
+}
+
+// countReplicas counts the pods sharing the pod's controller and how many of them are
+// crash-looping
+func (r *PodReconciler) countReplicas(ctx context.Context, pod *corev1.Pod) (int, int, error) {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return 0, 0, nil
+	}
+
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.InNamespace(pod.Namespace)); err != nil {
+		return 0, 0, err
+	}
+
+	replicas, crashLooping := 0, 0
+	for i := range pods.Items {
+		sibling := &pods.Items[i]
+		if owner := metav1.GetControllerOf(sibling); owner == nil || owner.UID != ref.UID {
+			continue
+		}
+		replicas++
+		if isCrashLooping(sibling) {
+			crashLooping++
+		}
+	}
+	return replicas, crashLooping, nil
+}
+
+// isCrashLooping reports whether any container of the pod is in CrashLoopBackOff
+func isCrashLooping(pod *corev1.Pod) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff" {
+			return true
+		}
+	}
+	return false
+}
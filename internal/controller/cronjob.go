@@ -0,0 +1,212 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/kubeevent"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// maxMissedRuns bounds how many missed runs are counted, as the CronJob controller does
+const maxMissedRuns = 100
+
+// CronJobReconciler alerts when a CronJob's last scheduled run falls behind its schedule by more
+// than the tolerance, because it is suspended or its Jobs are not being created. Alerts are
+// routed like pod alerts, see routeObject.
+type CronJobReconciler struct {
+	client.Client
+	Notifier notify.Notifier
+	Config   config.CronJobsConfig
+	Routing  config.RoutingConfig
+
+	mu sync.Mutex
+	// missed is the last schedule time of each CronJob whose missed runs were alerted on
+	missed map[types.NamespacedName]time.Time
+}
+
+// Reconcile alerts on a CronJob whose next run after its last scheduled one is overdue, once
+// until it runs again, and otherwise checks it again when that run is due
+func (r *CronJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	var cronJob batchv1.CronJob
+	if err := r.Get(ctx, req.NamespacedName, &cronJob); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			r.forget(req.NamespacedName)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	schedule, err := parseCronSchedule(&cronJob)
+	if err != nil {
+		// The API server validates schedules, so this only happens for syntax the parser lacks
+		logger.Info("Skipping CronJob with unparseable schedule", "schedule", cronJob.Spec.Schedule, "error", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	last := cronJob.CreationTimestamp.Time
+	if cronJob.Status.LastScheduleTime != nil {
+		last = cronJob.Status.LastScheduleTime.Time
+	}
+
+	r.mu.Lock()
+	alerted, found := r.missed[req.NamespacedName]
+	r.mu.Unlock()
+	if found && alerted.Equal(last) {
+		return ctrl.Result{}, nil
+	}
+	r.forget(req.NamespacedName)
+
+	now := time.Now()
+	next := schedule.Next(last)
+	if next.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	if overdue := next.Add(r.Config.Tolerance.Duration); now.Before(overdue) {
+		return ctrl.Result{RequeueAfter: overdue.Sub(now)}, nil
+	}
+
+	missed := 0
+	for run := next; !run.IsZero() && run.Add(r.Config.Tolerance.Duration).Before(now) && missed < maxMissedRuns; run = schedule.Next(run) {
+		missed++
+	}
+
+	cause, err := r.missedRunCause(ctx, &cronJob)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	channel, err := routeObject(ctx, r.Client, r.Routing.Ownership, &cronJob)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Notifier.SendMetaAlert(missedRunAlert(&cronJob, next, missed, cause, channel, now)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.mu.Lock()
+	r.missed[req.NamespacedName] = last
+	r.mu.Unlock()
+	logger.Info("CronJob missed its schedule", "namespace", cronJob.Namespace, "cronJob", cronJob.Name, "missed", missed)
+	return ctrl.Result{}, nil
+}
+
+// forget drops the alert state of a CronJob that ran again or was deleted
+func (r *CronJobReconciler) forget(cronJob types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.missed, cronJob)
+}
+
+// parseCronSchedule parses the schedule of a CronJob in its time zone, the way the CronJob
+// controller does
+func parseCronSchedule(cronJob *batchv1.CronJob) (cron.Schedule, error) {
+	spec := cronJob.Spec.Schedule
+	if cronJob.Spec.TimeZone != nil && *cronJob.Spec.TimeZone != "" {
+		spec = fmt.Sprintf("TZ=%s %s", *cronJob.Spec.TimeZone, spec)
+	}
+	return cron.ParseStandard(spec)
+}
+
+// missedRunCause explains why a CronJob did not run: it is suspended, a running Job forbids a
+// concurrent one, or the CronJob controller reported a failure such as a rejected Job creation
+func (r *CronJobReconciler) missedRunCause(ctx context.Context, cronJob *batchv1.CronJob) (string, error) {
+	if cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend {
+		return "The CronJob is suspended (`spec.suspend: true`).", nil
+	}
+	if cronJob.Spec.ConcurrencyPolicy == batchv1.ForbidConcurrent && len(cronJob.Status.Active) > 0 {
+		return fmt.Sprintf("Job `%s` is still running and the concurrencyPolicy is Forbid, so runs are skipped until it finishes.",
+			cronJob.Status.Active[0].Name), nil
+	}
+
+	var events corev1.EventList
+	if err := r.List(ctx, &events, client.InNamespace(cronJob.Namespace),
+		client.MatchingFields{eventInvolvedObjectUIDField: string(cronJob.UID)}); err != nil {
+		return "", err
+	}
+	var latest *corev1.Event
+	for i := range events.Items {
+		if e := &events.Items[i]; e.Type == corev1.EventTypeWarning && (latest == nil || kubeevent.LastObserved(e).After(kubeevent.LastObserved(latest))) {
+			latest = e
+		}
+	}
+	if latest != nil {
+		return fmt.Sprintf("The CronJob controller reported %s: %s", latest.Reason, strings.TrimSpace(latest.Message)), nil
+	}
+	return "No Job was created and no error was reported; check that kube-controller-manager is healthy.", nil
+}
+
+// missedRunAlert builds the alert for a CronJob behind its schedule
+func missedRunAlert(cronJob *batchv1.CronJob, next time.Time, missed int, cause, channel string, now time.Time) notify.MetaAlert {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CronJob `%s/%s` (schedule `%s`", cronJob.Namespace, cronJob.Name, cronJob.Spec.Schedule)
+	if cronJob.Spec.TimeZone != nil && *cronJob.Spec.TimeZone != "" {
+		fmt.Fprintf(&b, " in %s", *cronJob.Spec.TimeZone)
+	}
+	fmt.Fprintf(&b, ") was due to run at %s, %s ago", next.UTC().Format(time.RFC3339), formatReminderAge(now.Sub(next)))
+	if missed > 1 {
+		fmt.Fprintf(&b, ", and has missed %d runs since", missed)
+		if missed == maxMissedRuns {
+			b.WriteString(" or more")
+		}
+	}
+	b.WriteString(".\n")
+
+	if cronJob.Status.LastScheduleTime != nil {
+		fmt.Fprintf(&b, "*Last scheduled:* %s\n", cronJob.Status.LastScheduleTime.UTC().Format(time.RFC3339))
+	} else {
+		b.WriteString("*Last scheduled:* never\n")
+	}
+	if cronJob.Status.LastSuccessfulTime != nil {
+		fmt.Fprintf(&b, "*Last succeeded:* %s\n", cronJob.Status.LastSuccessfulTime.UTC().Format(time.RFC3339))
+	}
+	b.WriteString(cause)
+
+	return notify.MetaAlert{
+		Title:     fmt.Sprintf("⏰ CronJob %s/%s missed its schedule", cronJob.Namespace, cronJob.Name),
+		Text:      b.String(),
+		Severity:  notify.SeverityWarning,
+		Channel:   channel,
+		Timestamp: now,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager. Timers check each CronJob when its
+// next run is due.
+func (r *CronJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.missed = make(map[types.NamespacedName]time.Time)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1.CronJob{}).
+		Named("cronjob").
+		Complete(r)
+}
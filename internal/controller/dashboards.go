@@ -0,0 +1,92 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// dashboardWindow is how far before the alert the time range of dashboard links starts
+const dashboardWindow = time.Hour
+
+// dashboardVars are the placeholders of dashboard URL templates. Values are query-escaped, so
+// they can be placed anywhere in a URL.
+type dashboardVars struct {
+	Cluster      string
+	Namespace    string
+	Pod          string
+	Container    string
+	Node         string
+	WorkloadKind string
+	WorkloadName string
+	Reason       string
+	// From and To bound the time range around the alert in Unix milliseconds, the format
+	// Grafana and Kibana accept
+	From int64
+	To   int64
+}
+
+// parseDashboards parses the dashboard URL templates
+func parseDashboards(dashboards []config.DashboardConfig) ([]*template.Template, error) {
+	templates := make([]*template.Template, 0, len(dashboards))
+	for _, dashboard := range dashboards {
+		tmpl, err := template.New(dashboard.Name).Option("missingkey=error").Parse(dashboard.URL)
+		if err != nil {
+			return nil, fmt.Errorf("dashboard %q: %w", dashboard.Name, err)
+		}
+		templates = append(templates, tmpl)
+	}
+	return templates, nil
+}
+
+// dashboardLinks renders the configured dashboard links for an alert
+func (r *PodReconciler) dashboardLinks(alert *notify.PodAlert) ([]notify.Link, error) {
+	templates, err := parseDashboards(r.Config.Dashboards)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := dashboardVars{
+		Cluster:      url.QueryEscape(r.Config.Cluster),
+		Namespace:    url.QueryEscape(alert.Namespace),
+		Pod:          url.QueryEscape(alert.PodName),
+		Container:    url.QueryEscape(alert.ContainerName),
+		Node:         url.QueryEscape(alert.Node),
+		WorkloadKind: url.QueryEscape(alert.WorkloadKind),
+		WorkloadName: url.QueryEscape(alert.WorkloadName),
+		Reason:       url.QueryEscape(alert.Reason),
+		From:         alert.Timestamp.Add(-dashboardWindow).UnixMilli(),
+		To:           alert.Timestamp.UnixMilli(),
+	}
+
+	links := make([]notify.Link, 0, len(templates))
+	for i, tmpl := range templates {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, vars); err != nil {
+			return nil, fmt.Errorf("dashboard %q: %w", r.Config.Dashboards[i].Name, err)
+		}
+		links = append(links, notify.Link{Name: r.Config.Dashboards[i].Name, URL: b.String()})
+	}
+	return links, nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/store"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// deadLetterTimeout bounds recording a single dead letter
+const deadLetterTimeout = 10 * time.Second
+
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// DeadLetterRecorder keeps undeliverable alerts in the state store and reports pod alerts as
+// Warning events on their pod, so failed notifications show up in kubectl describe
+type DeadLetterRecorder struct {
+	Client   client.Reader
+	Store    store.Store
+	Recorder record.EventRecorder
+	Logger   logr.Logger
+}
+
+// HandleDeadLetter records a delivery the send queue gave up on
+func (d *DeadLetterRecorder) HandleDeadLetter(letter notify.DeadLetter) {
+	ctx, cancel := context.WithTimeout(context.Background(), deadLetterTimeout)
+	defer cancel()
+
+	entry := store.DeadLetter{
+		Kind:     letter.Kind,
+		FailedAt: letter.FailedAt,
+	}
+	if letter.Err != nil {
+		entry.Error = letter.Err.Error()
+	}
+	if alert := letter.PodAlert; alert != nil {
+		entry.Key = alert.Key
+		entry.Namespace = alert.Namespace
+		entry.Pod = alert.PodName
+		entry.Reason = alert.Reason
+	}
+	if alert := letter.MetaAlert; alert != nil {
+		entry.Title = alert.Title
+	}
+
+	if err := d.Store.AppendDeadLetter(ctx, entry); err != nil {
+		d.Logger.Error(err, "Failed to record dead letter", "kind", entry.Kind, "key", entry.Key, "title", entry.Title)
+	}
+
+	if letter.PodAlert != nil && d.Recorder != nil {
+		d.Recorder.Eventf(d.podFor(ctx, entry), corev1.EventTypeWarning, "AlertUndeliverable",
+			"%s %s could not be delivered: %s", entry.Reason, kindVerb(entry.Kind), entry.Error)
+	}
+}
+
+// podFor returns the pod of a dead letter, falling back to a reference without UID when the
+// pod is gone
+func (d *DeadLetterRecorder) podFor(ctx context.Context, entry store.DeadLetter) *corev1.Pod {
+	var pod corev1.Pod
+	key := types.NamespacedName{Namespace: entry.Namespace, Name: entry.Pod}
+	if d.Client == nil || d.Client.Get(ctx, key, &pod) != nil {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: entry.Namespace, Name: entry.Pod}}
+	}
+	return &pod
+}
+
+// kindVerb describes what was being delivered for a dead letter kind
+func kindVerb(kind string) string {
+	if kind == notify.DeadLetterResolve {
+		return "resolution"
+	}
+	return "notification"
+}
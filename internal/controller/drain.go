@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReasonNodeDrain is the alert reason for failures of pods stopped by the drain of a cordoned
+// node. It has info severity: draining is maintenance, and the pods' controllers reschedule them
+// elsewhere.
+const ReasonNodeDrain = "NodeDrain"
+
+// drainPodListLimit bounds how many stopped pods the alert lists
+const drainPodListLimit = 10
+
+// isCordoned reports whether a node is cordoned, by its spec or the taint kubectl cordon sets
+func isCordoned(node *corev1.Node) bool {
+	return node.Spec.Unschedulable || slices.ContainsFunc(node.Spec.Taints, func(taint corev1.Taint) bool {
+		return taint.Key == corev1.TaintNodeUnschedulable
+	})
+}
+
+// stoppedByDrain reports whether a pod is being stopped the way a drain stops pods: evicted
+// through the eviction API, or deleted
+func stoppedByDrain(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return true
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.DisruptionTarget && condition.Status == corev1.ConditionTrue &&
+			condition.Reason == "EvictionByEvictionAPI" {
+			return true
+		}
+	}
+	return false
+}
+
+// findDrain returns the cordoned node whose drain caused the pod's failure, or an empty string.
+// Only failures that stopping a pod produces count; a pod crashing on its own while its node is
+// cordoned is still alerted on.
+func (r *PodReconciler) findDrain(ctx context.Context, pod *corev1.Pod, reason string) (string, error) {
+	if pod.Spec.NodeName == "" || !(isTerminationReason(reason) || reason == ReasonEvicted) || !stoppedByDrain(pod) {
+		return "", nil
+	}
+
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, &node); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	if !isCordoned(&node) {
+		return "", nil
+	}
+	return node.Name, nil
+}
+
+// describeDrain lists the pods of a cordoned node that are being stopped
+func (r *PodReconciler) describeDrain(ctx context.Context, node string) (string, error) {
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods); err != nil {
+		return "", err
+	}
+
+	var lines []string
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName == node && stoppedByDrain(pod) {
+			lines = append(lines, fmt.Sprintf("• `%s/%s`", pod.Namespace, pod.Name))
+		}
+	}
+	slices.Sort(lines)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "🔧 Node `%s` is cordoned and being drained; %d pod(s) are being stopped", node, len(lines))
+	if len(lines) > 0 {
+		fmt.Fprintf(&b, ":\n%s", strings.Join(lines[:min(len(lines), drainPodListLimit)], "\n"))
+		if len(lines) > drainPodListLimit {
+			fmt.Fprintf(&b, "\n• and %d more", len(lines)-drainPodListLimit)
+		}
+	}
+	b.WriteString("\nErrors on the way out are expected; their controllers reschedule the pods on other nodes.")
+	return b.String(), nil
+}
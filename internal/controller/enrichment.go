@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// enrichmentSource identifies where a piece of alert context comes from
+type enrichmentSource string
+
+// Enrichment sources with their own byte budget
+const (
+	sourceLogs     enrichmentSource = "logs"
+	sourceEvents   enrichmentSource = "events"
+	sourceDescribe enrichmentSource = "describe"
+)
+
+// enrichmentBudget caps the context attached to a single alert, both per source and in total,
+// so one noisy container cannot produce oversized messages that Slack rejects
+type enrichmentBudget struct {
+	remaining int
+	perSource map[enrichmentSource]int
+}
+
+// newEnrichmentBudget starts a fresh budget for one alert
+func (r *PodReconciler) newEnrichmentBudget() *enrichmentBudget {
+	cfg := r.Config.Enrichment
+	return &enrichmentBudget{
+		remaining: cfg.MaxBytes,
+		perSource: map[enrichmentSource]int{
+			sourceLogs:     cfg.Budgets.Logs,
+			sourceEvents:   cfg.Budgets.Events,
+			sourceDescribe: cfg.Budgets.Describe,
+		},
+	}
+}
+
+// fit truncates text to what is left of the source and total budgets and charges it.
+// Logs keep their tail, where the failure usually is; other sources keep their head.
+func (b *enrichmentBudget) fit(source enrichmentSource, text string) string {
+	limit := min(b.perSource[source], b.remaining)
+	if len(text) > limit {
+		if source == sourceLogs {
+			text = truncateHead(text, limit)
+		} else {
+			text = truncateTail(text, limit)
+		}
+	}
+
+	b.remaining -= len(text)
+	b.perSource[source] -= len(text)
+	return text
+}
+
+// truncateTail keeps the beginning of text within limit bytes, cutting at a line break when
+// one is reasonably close, and notes how much was dropped
+func truncateTail(text string, limit int) string {
+	marker := truncationMarker(len(text))
+	if limit <= len(marker) {
+		return ""
+	}
+
+	keep := text[:limit-len(marker)]
+	if i := strings.LastIndexByte(keep, '\n'); i >= len(keep)/2 {
+		keep = keep[:i+1]
+	}
+	for !utf8.ValidString(keep) {
+		keep = keep[:len(keep)-1]
+	}
+	return keep + truncationMarker(len(text)-len(keep))
+}
+
+// truncateHead keeps the end of text within limit bytes, cutting at a line break when one is
+// reasonably close, and notes how much was dropped
+func truncateHead(text string, limit int) string {
+	marker := truncationMarker(len(text))
+	if limit <= len(marker) {
+		return ""
+	}
+
+	keep := text[len(text)-(limit-len(marker)):]
+	if i := strings.IndexByte(keep, '\n'); i >= 0 && i < len(keep)/2 {
+		keep = keep[i+1:]
+	}
+	for !utf8.ValidString(keep) {
+		keep = keep[1:]
+	}
+	return strings.TrimPrefix(truncationMarker(len(text)-len(keep)), "\n") + keep
+}
+
+// truncationMarker notes the number of bytes removed from a text
+func truncationMarker(dropped int) string {
+	return fmt.Sprintf("\n… [%d bytes truncated]\n", dropped)
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/store"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// escalationInterval is how often open alerts are checked against the escalation rules
+const escalationInterval = 30 * time.Second
+
+// OpenAlertSource lists the alerts that were sent and have not recovered yet
+type OpenAlertSource interface {
+	OpenAlerts() []notify.PodAlert
+}
+
+// incident tracks an open alert for escalation
+type incident struct {
+	firstAlertAt time.Time
+	acknowledged bool
+	// escalated holds the indexes of the rules that already fired
+	escalated map[int]bool
+}
+
+// Escalator re-sends alerts that were neither acknowledged nor resolved in time to the
+// escalation channels of the configured rules. Open alerts are only known to the leader, so
+// the escalator runs on the leader only.
+type Escalator struct {
+	Alerts OpenAlertSource
+	// Notifier delivers escalated alerts
+	Notifier notify.Notifier
+	// Acks tells whether an alert was acknowledged; nil means only resolution stops escalation
+	Acks  notify.Acknowledger
+	Rules []config.EscalationRule
+	// Store persists which rules fired, so a new leader neither escalates an incident again nor
+	// restarts its delays; nil keeps escalation state in memory only
+	Store store.Store
+
+	incidents map[string]*incident
+}
+
+// NeedLeaderElection runs the escalator on the leader, which tracks the open alerts
+func (e *Escalator) NeedLeaderElection() bool {
+	return true
+}
+
+// Start checks open alerts until the context is cancelled
+func (e *Escalator) Start(ctx context.Context) error {
+	e.incidents = make(map[string]*incident)
+	restored := false
+
+	ticker := time.NewTicker(escalationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			// Restored on the first check, once the pod reconciler reloaded the store
+			if !restored {
+				restored = e.restore(ctx)
+			}
+			e.run(ctx, now)
+		}
+	}
+}
+
+// restore loads the escalation state the previous leader persisted and reports whether it
+// succeeded
+func (e *Escalator) restore(ctx context.Context) bool {
+	if e.Store == nil {
+		return true
+	}
+	records, err := e.Store.OpenAlerts(ctx)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to load escalation state")
+		return false
+	}
+	for _, record := range records {
+		state := &incident{firstAlertAt: record.OpenedAt, escalated: make(map[int]bool)}
+		if state.firstAlertAt.IsZero() {
+			state.firstAlertAt = record.Timestamp
+		}
+		for _, i := range record.Escalated {
+			state.escalated[i] = true
+		}
+		e.incidents[record.Key] = state
+	}
+	return true
+}
+
+// run escalates open alerts whose rules are due. Incidents that recovered are forgotten, so a
+// pod that fails again escalates again.
+func (e *Escalator) run(ctx context.Context, now time.Time) {
+	logger := logf.FromContext(ctx)
+
+	open := make(map[string]bool)
+	for _, alert := range e.Alerts.OpenAlerts() {
+		open[alert.Key] = true
+		state, exists := e.incidents[alert.Key]
+		if !exists {
+			state = &incident{firstAlertAt: alert.Timestamp, escalated: make(map[int]bool)}
+			e.incidents[alert.Key] = state
+		}
+
+		due := e.dueRules(alert, state, now)
+		if len(due) == 0 {
+			continue
+		}
+		if e.Acks != nil && !state.acknowledged {
+			acknowledged, err := e.Acks.Acknowledged(alert.Key)
+			if err != nil {
+				logger.V(1).Info("Failed to check whether alert was acknowledged", "key", alert.Key, "error", err.Error())
+			}
+			state.acknowledged = acknowledged
+		}
+		if state.acknowledged {
+			continue
+		}
+
+		for _, i := range due {
+			rule := e.Rules[i]
+			if err := e.Notifier.SendPodAlert(escalatedAlert(alert, rule, i)); err != nil {
+				// Retried on the next check
+				logger.Error(err, "Failed to escalate alert", "pod", alert.PodName, "namespace", alert.Namespace, "channel", rule.Channel)
+				continue
+			}
+			state.escalated[i] = true
+			if e.Store != nil {
+				if err := e.Store.MarkEscalated(ctx, alert.Key, i); err != nil {
+					logger.Error(err, "Failed to persist escalation", "key", alert.Key)
+				}
+			}
+			alertsEscalatedTotal.WithLabelValues(rule.Channel).Inc()
+			logger.Info("Escalated unacknowledged alert",
+				"pod", alert.PodName,
+				"namespace", alert.Namespace,
+				"reason", alert.Reason,
+				"channel", rule.Channel,
+				"after", rule.After.Duration,
+			)
+		}
+	}
+
+	for key := range e.incidents {
+		if !open[key] {
+			delete(e.incidents, key)
+		}
+	}
+}
+
+// dueRules returns the indexes of the rules that apply to the alert, have not fired for its
+// incident yet and whose delay has passed
+func (e *Escalator) dueRules(alert notify.PodAlert, state *incident, now time.Time) []int {
+	var due []int
+	for i, rule := range e.Rules {
+		if state.escalated[i] || now.Sub(state.firstAlertAt) < rule.After.Duration {
+			continue
+		}
+		if !alert.AlertSeverity().AtLeast(notify.Severity(rule.MinSeverity)) {
+			continue
+		}
+		due = append(due, i)
+	}
+	return due
+}
+
+// escalatedAlert copies an alert for the escalation channel of a rule. It gets its own key, so
+// it opens a thread in the escalation channel instead of replacing the incident's thread.
+func escalatedAlert(alert notify.PodAlert, rule config.EscalationRule, index int) notify.PodAlert {
+	alert.Channel = rule.Channel
+	alert.Key = fmt.Sprintf("%s-escalation-%d", alert.Key, index)
+	header := fmt.Sprintf("🚨 Escalated: not acknowledged or resolved after %s", formatReminderAge(rule.After.Duration))
+	if rule.Mention != "" {
+		header = rule.Mention + " " + header
+	}
+	alert.Message = strings.TrimSpace(header + "\n\n" + alert.Message)
+	return alert
+}
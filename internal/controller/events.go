@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/kubeevent"
+)
+
+// recentEventWindow is how far back events are attached to alerts
+const recentEventWindow = time.Hour
+
+// eventInvolvedObjectUIDField indexes Events by the UID of the object they describe
+const eventInvolvedObjectUIDField = "involvedObject.uid"
+
+// indexEventsByInvolvedObject is the field indexer for eventInvolvedObjectUIDField
+func indexEventsByInvolvedObject(obj client.Object) []string {
+	event := obj.(*corev1.Event)
+	if event.InvolvedObject.UID == "" {
+		return nil
+	}
+	return []string{string(event.InvolvedObject.UID)}
+}
+
+// podEvents returns the events recorded for a pod since the given time, newest first
+func (r *PodReconciler) podEvents(ctx context.Context, pod *corev1.Pod, since time.Time) ([]corev1.Event, error) {
+	var events corev1.EventList
+	if err := r.List(ctx, &events,
+		client.InNamespace(pod.Namespace),
+		client.MatchingFields{eventInvolvedObjectUIDField: string(pod.UID)},
+	); err != nil {
+		return nil, err
+	}
+
+	recent := make([]corev1.Event, 0, len(events.Items))
+	for _, event := range events.Items {
+		if !kubeevent.LastObserved(&event).Before(since) {
+			recent = append(recent, event)
+		}
+	}
+
+	sort.Slice(recent, func(i, j int) bool {
+		return kubeevent.LastObserved(&recent[i]).After(kubeevent.LastObserved(&recent[j]))
+	})
+	return recent, nil
+}
+
+// recentEvents describes the pod's most recent warning events, oldest first, such as
+// scheduling failures, probe failures and image pull errors. It returns an empty string when
+// there are none.
+func (r *PodReconciler) recentEvents(ctx context.Context, pod *corev1.Pod, now time.Time) (string, error) {
+	events, err := r.podEvents(ctx, pod, now.Add(-recentEventWindow))
+	if err != nil {
+		return "", err
+	}
+
+	var lines []string
+	for i := range events {
+		event := &events[i]
+		if event.Type != corev1.EventTypeWarning {
+			continue
+		}
+		line := fmt.Sprintf("• %s %s ago", event.Reason, now.Sub(kubeevent.LastObserved(event)).Round(time.Second))
+		if event.Count > 1 {
+			line += fmt.Sprintf(" (x%d)", event.Count)
+		}
+		lines = append(lines, line+": "+strings.TrimSpace(event.Message))
+		if len(lines) == r.Config.Enrichment.RecentEvents {
+			break
+		}
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+	slices.Reverse(lines)
+	return "📋 Recent events:\n" + strings.Join(lines, "\n"), nil
+}
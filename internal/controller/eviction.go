@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ReasonEvicted is the alert reason for pods evicted by the kubelet under node pressure, through
+// the Eviction API, e.g. by a node drain, or by the taint manager
+const ReasonEvicted = "Evicted"
+
+// evictionPodListLimit bounds how many evicted pods a node's eviction alert lists
+const evictionPodListLimit = 10
+
+// evictionCauseLimit bounds each pod's cause in a node's eviction alert; the kubelet's
+// messages name every container's usage
+const evictionCauseLimit = 200
+
+// evictionCause returns why a pod was evicted, or false if it was not. The kubelet marks pods
+// it evicts as Failed with reason Evicted; other evictions leave a DisruptionTarget condition
+// on the pod while it terminates.
+func evictionCause(pod *corev1.Pod) (string, bool) {
+	if pod.Status.Phase == corev1.PodFailed && pod.Status.Reason == ReasonEvicted {
+		return strings.TrimSpace(pod.Status.Message), true
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != corev1.DisruptionTarget || condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Reason {
+		case "EvictionByEvictionAPI":
+			return fallback(condition.Message, "Evicted through the Eviction API, e.g. by a node drain"), true
+		case "DeletionByTaintManager":
+			return fallback(condition.Message, "Evicted by the taint manager for a NoExecute taint on the node"), true
+		}
+	}
+	return "", false
+}
+
+// fallback returns value trimmed, or def if value is blank
+func fallback(value, def string) string {
+	if value = strings.TrimSpace(value); value != "" {
+		return value
+	}
+	return def
+}
+
+// describeEviction lists the evicted pods on a node for the alert message, so a node under
+// pressure produces one alert rather than one per pod
+func (r *PodReconciler) describeEviction(ctx context.Context, node string) (string, error) {
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods); err != nil {
+		return "", err
+	}
+
+	var lines []string
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName != node {
+			continue
+		}
+		if cause, evicted := evictionCause(pod); evicted {
+			lines = append(lines, fmt.Sprintf("• `%s/%s` %s", pod.Namespace, pod.Name, truncateCause(cause)))
+		}
+	}
+	slices.Sort(lines)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "🚪 %d pod(s) evicted from node `%s`", len(lines), node)
+	if len(lines) > 0 {
+		fmt.Fprintf(&b, ":\n%s", strings.Join(lines[:min(len(lines), evictionPodListLimit)], "\n"))
+		if len(lines) > evictionPodListLimit {
+			fmt.Fprintf(&b, "\n• and %d more", len(lines)-evictionPodListLimit)
+		}
+	}
+	return b.String(), nil
+}
+
+// truncateCause shortens an eviction cause to the limit
+func truncateCause(cause string) string {
+	if len(cause) <= evictionCauseLimit {
+		return cause
+	}
+	return cause[:evictionCauseLimit-3] + "..."
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ExpectedFailuresAnnotation marks a pod, its workload or its namespace as allowed to fail, e.g.
+// canary kill tests or CI runners. Alerts for such pods are dropped but still counted in the
+// suppressed alerts metric.
+const ExpectedFailuresAnnotation = "slackgenie.io/expected-failures"
+
+// expectsFailures reports whether an annotation value marks failures as expected
+func expectsFailures(annotations map[string]string) bool {
+	expected, err := strconv.ParseBool(annotations[ExpectedFailuresAnnotation])
+	return err == nil && expected
+}
+
+// expectedFailures returns where the pod's failures are marked as expected, checking the pod
+// itself, then its owning workload, then its namespace, or an empty string if they are not
+func (r *PodReconciler) expectedFailures(ctx context.Context, pod *corev1.Pod) (string, error) {
+	if expectsFailures(pod.Annotations) {
+		return "pod", nil
+	}
+
+	owner, err := r.resolveOwner(ctx, pod)
+	if err != nil {
+		return "", err
+	}
+	if owner != nil && expectsFailures(owner.Annotations) {
+		return owner.Kind + " " + owner.Name, nil
+	}
+
+	var namespace corev1.Namespace
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Namespace}, &namespace); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	if expectsFailures(namespace.Annotations) {
+		return "namespace", nil
+	}
+	return "", nil
+}
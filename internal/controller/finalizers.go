@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// +kubebuilder:rbac:groups=core,resources=namespaces;persistentvolumeclaims,verbs=get;list;watch
+
+// FinalizerMonitor periodically scans the configured kinds for resources that have been
+// deleting for longer than the threshold and posts an alert naming the finalizers holding
+// them. Such resources silently block GitOps syncs and namespace cleanup.
+type FinalizerMonitor struct {
+	// Reader lists resources directly from the API server. The kinds are arbitrary, so they
+	// are not cached, and a kind the operator may not list only fails its own scan.
+	Reader   client.Reader
+	Notifier notify.Notifier
+	Config   config.FinalizersConfig
+
+	// flagged holds the resources already alerted on, until they are gone or deleted again
+	flagged map[types.UID]bool
+}
+
+// NeedLeaderElection ensures only the leader alerts
+func (m *FinalizerMonitor) NeedLeaderElection() bool {
+	return true
+}
+
+// Start scans the configured kinds until the context is cancelled
+func (m *FinalizerMonitor) Start(ctx context.Context) error {
+	m.flagged = make(map[types.UID]bool)
+
+	ticker := time.NewTicker(m.Config.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			m.scan(ctx, now)
+		}
+	}
+}
+
+// scan alerts on resources newly found stuck and forgets those that finished deleting
+func (m *FinalizerMonitor) scan(ctx context.Context, now time.Time) {
+	logger := logf.FromContext(ctx)
+
+	deleting := make(map[types.UID]bool)
+	for _, kind := range m.Config.Kinds {
+		sep := strings.LastIndex(kind, "/")
+		gvk := schema.FromAPIVersionAndKind(kind[:sep], kind[sep+1:])
+
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := m.Reader.List(ctx, list); err != nil {
+			logger.Error(err, "Failed to list resources for stuck finalizers", "kind", kind)
+			// The flags cannot be told apart by kind; keep them all rather than alerting
+			// again once listing recovers
+			for uid := range m.flagged {
+				deleting[uid] = true
+			}
+			continue
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if obj.DeletionTimestamp == nil || len(obj.Finalizers) == 0 {
+				continue
+			}
+			deleting[obj.UID] = true
+			if m.flagged[obj.UID] || now.Sub(obj.DeletionTimestamp.Time) < m.Config.Threshold.Duration {
+				continue
+			}
+
+			if err := m.Notifier.SendMetaAlert(m.alert(gvk.Kind, obj, now)); err != nil {
+				logger.Error(err, "Failed to send stuck finalizer alert", "kind", kind, "name", obj.Name, "namespace", obj.Namespace)
+				continue
+			}
+			m.flagged[obj.UID] = true
+			logger.Info("Resource stuck deleting",
+				"kind", kind,
+				"name", obj.Name,
+				"namespace", obj.Namespace,
+				"finalizers", obj.Finalizers,
+			)
+		}
+	}
+
+	for uid := range m.flagged {
+		if !deleting[uid] {
+			delete(m.flagged, uid)
+		}
+	}
+}
+
+// alert describes a resource stuck deleting
+func (m *FinalizerMonitor) alert(kind string, obj *metav1.PartialObjectMetadata, now time.Time) notify.MetaAlert {
+	name := obj.Name
+	if obj.Namespace != "" {
+		name = obj.Namespace + "/" + obj.Name
+	}
+
+	finalizers := make([]string, 0, len(obj.Finalizers))
+	for _, finalizer := range obj.Finalizers {
+		finalizers = append(finalizers, "`"+finalizer+"`")
+	}
+
+	return notify.MetaAlert{
+		Title: fmt.Sprintf("🧷 %s %s stuck deleting", kind, name),
+		Text: fmt.Sprintf("%s `%s` has been deleting for %s, held by finalizer(s) %s.\n\n"+
+			"Each finalizer is removed by the controller that added it once its cleanup is done. "+
+			"Check that controller's logs; remove a finalizer by hand only if its controller is gone "+
+			"and the cleanup is not needed.",
+			kind, name, formatReminderAge(now.Sub(obj.DeletionTimestamp.Time)), strings.Join(finalizers, ", ")),
+		Severity:  notify.SeverityWarning,
+		Channel:   m.Config.Channel,
+		Timestamp: now,
+	}
+}
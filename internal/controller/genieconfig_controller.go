@@ -0,0 +1,347 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	geniev1alpha1 "github.com/ahmadrazalab/kube-slackgenie-operator/api/v1alpha1"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/httpwebhook"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/slack"
+)
+
+const (
+	// backendDialTimeout bounds each reachability check
+	backendDialTimeout = 5 * time.Second
+	// genieConfigRecheckInterval refreshes reachability, which can change without a spec change
+	genieConfigRecheckInterval = 10 * time.Minute
+)
+
+// GenieConfigReconciler validates GenieConfig resources and reports the result in their status
+type GenieConfigReconciler struct {
+	client.Client
+	// Active is the GenieConfig the operator loaded at startup
+	Active types.NamespacedName
+	// ActiveGeneration is the generation of Active that was loaded
+	ActiveGeneration int64
+	// SlackProxy is the proxy Slack requests go through, nil for the proxy of the environment
+	SlackProxy *url.URL
+}
+
+// +kubebuilder:rbac:groups=genie.slackgenie.io,resources=genieconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=genie.slackgenie.io,resources=genieconfigs/status,verbs=get;update;patch
+
+// Reconcile validates the configuration and writes its status conditions
+func (r *GenieConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var genieConfig geniev1alpha1.GenieConfig
+	if err := r.Get(ctx, req.NamespacedName, &genieConfig); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	status := genieConfig.Status.DeepCopy()
+	for _, condition := range r.evaluate(ctx, &genieConfig) {
+		condition.ObservedGeneration = genieConfig.Generation
+		meta.SetStatusCondition(&status.Conditions, condition)
+	}
+	status.ObservedGeneration = genieConfig.Generation
+
+	if equality.Semantic.DeepEqual(status, &genieConfig.Status) {
+		return ctrl.Result{RequeueAfter: genieConfigRecheckInterval}, nil
+	}
+
+	genieConfig.Status = *status
+	if err := r.Status().Update(ctx, &genieConfig); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: genieConfigRecheckInterval}, nil
+}
+
+// evaluate computes all conditions of a GenieConfig
+func (r *GenieConfigReconciler) evaluate(ctx context.Context, genieConfig *geniev1alpha1.GenieConfig) []metav1.Condition {
+	conditions := []metav1.Condition{r.applied(genieConfig)}
+
+	cfg, err := config.Decode(genieConfig.Spec.Config.Raw)
+	if err != nil {
+		return append(conditions,
+			condition(geniev1alpha1.ConditionParsed, false, "DecodeFailed", err),
+			condition(geniev1alpha1.ConditionAccepted, false, "Invalid", err),
+		)
+	}
+	conditions = append(conditions, condition(geniev1alpha1.ConditionParsed, true, "", nil))
+
+	if err := cfg.Validate(); err != nil {
+		return append(conditions,
+			condition(geniev1alpha1.ConditionRulesCompiled, false, "ValidationFailed", err),
+			condition(geniev1alpha1.ConditionAccepted, false, "Invalid", err),
+		)
+	}
+	conditions = append(conditions, condition(geniev1alpha1.ConditionRulesCompiled, true, "", nil))
+
+	templatesErr := compileTemplates(cfg)
+	conditions = append(conditions, condition(geniev1alpha1.ConditionTemplatesCompiled, templatesErr == nil, "TemplateError", templatesErr))
+
+	conflicts := cfg.RouteConflicts()
+	routes := metav1.Condition{
+		Type:    geniev1alpha1.ConditionRoutesConflicting,
+		Status:  metav1.ConditionFalse,
+		Reason:  "NoConflicts",
+		Message: "No ownership rule is shadowed by an earlier rule",
+	}
+	if len(conflicts) > 0 {
+		routes.Status = metav1.ConditionTrue
+		routes.Reason = "RulesShadowed"
+		routes.Message = strings.Join(conflicts, "; ")
+	}
+	conditions = append(conditions, routes)
+
+	reachErr := checkBackends(ctx, cfg, r.SlackProxy)
+	conditions = append(conditions, condition(geniev1alpha1.ConditionBackendsReachable, reachErr == nil, "Unreachable", reachErr))
+
+	if templatesErr != nil {
+		conditions = append(conditions, condition(geniev1alpha1.ConditionAccepted, false, "Invalid", templatesErr))
+	} else {
+		conditions = append(conditions, condition(geniev1alpha1.ConditionAccepted, true, "", nil))
+	}
+	return conditions
+}
+
+// applied reports whether the running operator uses this generation of the configuration
+func (r *GenieConfigReconciler) applied(genieConfig *geniev1alpha1.GenieConfig) metav1.Condition {
+	switch {
+	case client.ObjectKeyFromObject(genieConfig) != r.Active:
+		return metav1.Condition{
+			Type:    geniev1alpha1.ConditionApplied,
+			Status:  metav1.ConditionFalse,
+			Reason:  "NotSelected",
+			Message: fmt.Sprintf("The operator uses GenieConfig %s", r.Active),
+		}
+	case genieConfig.Generation != r.ActiveGeneration:
+		return metav1.Condition{
+			Type:    geniev1alpha1.ConditionApplied,
+			Status:  metav1.ConditionFalse,
+			Reason:  "RestartRequired",
+			Message: fmt.Sprintf("The operator loaded generation %d; restart it to apply the change", r.ActiveGeneration),
+		}
+	default:
+		return metav1.Condition{
+			Type:    geniev1alpha1.ConditionApplied,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Loaded",
+			Message: "The operator is running with this configuration",
+		}
+	}
+}
+
+// condition builds a condition from the outcome of a check
+func condition(conditionType string, ok bool, failureReason string, err error) metav1.Condition {
+	if ok {
+		return metav1.Condition{
+			Type:    conditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Succeeded",
+			Message: "Check passed",
+		}
+	}
+	return metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  failureReason,
+		Message: err.Error(),
+	}
+}
+
+// compileTemplates parses every user-supplied message template
+func compileTemplates(cfg *config.Config) error {
+	if cfg.Experiment.Name != "" {
+		if _, err := slack.NewExperiment(cfg.Experiment.Name, cfg.Experiment.Percentage, cfg.Experiment.Template); err != nil {
+			return err
+		}
+	}
+	if cfg.Webhook.Template != "" {
+		if _, err := httpwebhook.NewNotifier(logr.Discard(), httpwebhook.Options{
+			URL:          cfg.Webhook.URL,
+			Method:       cfg.Webhook.Method,
+			Template:     cfg.Webhook.Template,
+			MetaTemplate: cfg.Webhook.MetaTemplate,
+		}); err != nil {
+			return err
+		}
+	}
+	if _, err := parseDashboards(cfg.Dashboards); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkBackends connects to the endpoint of every enabled notifier, in parallel. Endpoints
+// reached over HTTP are checked with a HEAD request through the sink's proxy and TLS options,
+// so a sink behind a proxy is not reported unreachable; any response counts as reachable.
+func checkBackends(ctx context.Context, cfg *config.Config, slackProxy *url.URL) error {
+	endpoints := backendEndpoints(cfg)
+	names := make([]string, 0, len(endpoints))
+	for name := range endpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		var proxy *url.URL
+		if name == config.NotifierSlack {
+			proxy = slackProxy
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = checkBackend(ctx, endpoints[name], sinkTLSOptions(cfg.Sinks[name].TLS), proxy)
+		}()
+	}
+	wg.Wait()
+
+	var failures []string
+	for i, name := range names {
+		if errs[i] != nil {
+			failures = append(failures, fmt.Sprintf("%s (%s): %v", name, endpoints[name].Host, errs[i]))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// checkBackend connects to a single endpoint within backendDialTimeout
+func checkBackend(ctx context.Context, endpoint *url.URL, tlsOpts notify.TLSOptions, proxy *url.URL) error {
+	ctx, cancel := context.WithTimeout(ctx, backendDialTimeout)
+	defer cancel()
+
+	if endpoint.Scheme == "smtp" {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", endpoint.Host)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	transport, err := notify.NewTransport(tlsOpts, proxy)
+	if err != nil {
+		return err
+	}
+	defer transport.CloseIdleConnections()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// sinkTLSOptions converts the TLS settings of a sink
+func sinkTLSOptions(cfg config.SinkTLSConfig) notify.TLSOptions {
+	opts := notify.TLSOptions{CAFile: cfg.CAFile, CertFile: cfg.CertFile, KeyFile: cfg.KeyFile}
+	if cfg.MinVersion == "1.3" {
+		opts.MinVersion = tls.VersionTLS13
+	}
+	return opts
+}
+
+// backendEndpoints returns the root URL of each enabled notifier's endpoint. Only the root is
+// checked, so webhook paths, which often hold secrets, are neither requested nor reported.
+// The email endpoint has the scheme smtp.
+func backendEndpoints(cfg *config.Config) map[string]*url.URL {
+	endpoints := make(map[string]*url.URL)
+	for _, notifier := range cfg.EnabledNotifiers() {
+		var endpoint *url.URL
+		switch notifier {
+		case config.NotifierSlack:
+			endpoint = rootURL(os.Getenv("SLACK_WEBHOOK_URL"), "https://slack.com")
+		case config.NotifierTeams:
+			endpoint = rootURL(os.Getenv("TEAMS_WEBHOOK_URL"), "")
+		case config.NotifierDiscord:
+			endpoint = rootURL(os.Getenv("DISCORD_WEBHOOK_URL"), "https://discord.com")
+		case config.NotifierMattermost:
+			endpoint = rootURL(os.Getenv("MATTERMOST_WEBHOOK_URL"), "")
+		case config.NotifierTelegram:
+			endpoint = rootURL("https://api.telegram.org", "")
+		case config.NotifierPagerDuty:
+			endpoint = rootURL("https://events.pagerduty.com", "")
+		case config.NotifierWebhook:
+			endpoint = rootURL(cfg.Webhook.URL, "")
+		case config.NotifierEmail:
+			port := cfg.Email.Port
+			if port == 0 {
+				port = 587
+				if cfg.Email.TLS == "tls" {
+					port = 465
+				}
+			}
+			if cfg.Email.Host != "" {
+				endpoint = &url.URL{Scheme: "smtp", Host: net.JoinHostPort(cfg.Email.Host, strconv.Itoa(port))}
+			}
+		}
+		if endpoint != nil {
+			endpoints[notifier] = endpoint
+		}
+	}
+	return endpoints
+}
+
+// rootURL returns the scheme and host of a URL, or of the fallback if the URL is empty or
+// invalid. It returns nil if neither is usable.
+func rootURL(rawURL, fallback string) *url.URL {
+	for _, candidate := range []string{rawURL, fallback} {
+		u, err := url.Parse(candidate)
+		if candidate != "" && err == nil && u.Hostname() != "" && (u.Scheme == "http" || u.Scheme == "https") {
+			return &url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/"}
+		}
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *GenieConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&geniev1alpha1.GenieConfig{}).
+		Named("genieconfig").
+		Complete(r)
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	geniev1alpha1 "github.com/ahmadrazalab/kube-slackgenie-operator/api/v1alpha1"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// GenieRouteTestReconciler routes the sample alert of each GenieRouteTest and reports in its
+// status whether it reached the expected channel, team and severity. Tests can also send their
+// sample alert, to check the channels and templates end to end.
+type GenieRouteTestReconciler struct {
+	client.Client
+	// Config is the configuration the operator loaded
+	Config *config.Config
+	// ActiveConfig is the GenieConfig the operator loaded, if any. Tests run against its latest
+	// spec, so a change that breaks routing fails them before the operator is restarted.
+	ActiveConfig types.NamespacedName
+	// Notifier sends the test alerts of tests with spec.sendTestAlert; if nil, none are sent
+	Notifier notify.Notifier
+}
+
+// +kubebuilder:rbac:groups=genie.slackgenie.io,resources=genieroutetests,verbs=get;list;watch
+// +kubebuilder:rbac:groups=genie.slackgenie.io,resources=genieroutetests/status,verbs=get;update;patch
+
+// Reconcile routes the sample alert and writes the result and the Passed condition
+func (r *GenieRouteTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var test geniev1alpha1.GenieRouteTest
+	if err := r.Get(ctx, req.NamespacedName, &test); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	status := test.Status.DeepCopy()
+	passed := metav1.Condition{Type: geniev1alpha1.ConditionPassed, ObservedGeneration: test.Generation}
+
+	cfg, source, err := r.routingConfig(ctx)
+	if err != nil {
+		status.Result = nil
+		passed.Status = metav1.ConditionUnknown
+		passed.Reason = "ConfigInvalid"
+		passed.Message = err.Error()
+	} else {
+		result, err := r.route(ctx, cfg, test.Spec.Alert)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		status.Result = result
+
+		if mismatches := expectationMismatches(test.Spec.Expect, result); len(mismatches) > 0 {
+			passed.Status = metav1.ConditionFalse
+			passed.Reason = "Mismatch"
+			passed.Message = fmt.Sprintf("Against %s: %s", source, strings.Join(mismatches, "; "))
+		} else {
+			passed.Status = metav1.ConditionTrue
+			passed.Reason = "Matched"
+			passed.Message = fmt.Sprintf("Routed as expected against %s", source)
+		}
+	}
+	meta.SetStatusCondition(&status.Conditions, passed)
+	status.ObservedGeneration = test.Generation
+
+	var sendErr error
+	if test.Spec.SendTestAlert && status.SentGeneration != test.Generation && status.Result != nil && r.Notifier != nil {
+		sent := metav1.Condition{Type: geniev1alpha1.ConditionSent, ObservedGeneration: test.Generation}
+		if sendErr = r.Notifier.SendPodAlert(testAlert(&test, status.Result)); sendErr != nil {
+			sent.Status = metav1.ConditionFalse
+			sent.Reason = "SendFailed"
+			sent.Message = sendErr.Error()
+		} else {
+			sent.Status = metav1.ConditionTrue
+			sent.Reason = "Sent"
+			sent.Message = fmt.Sprintf("Test alert sent to %s", channelName(status.Result.Channel))
+			status.SentGeneration = test.Generation
+		}
+		meta.SetStatusCondition(&status.Conditions, sent)
+	}
+
+	// Namespace annotations can change routing without any event on the test
+	if !equality.Semantic.DeepEqual(status, &test.Status) {
+		test.Status = *status
+		if err := r.Status().Update(ctx, &test); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	// A failed test alert is retried with backoff
+	if sendErr != nil {
+		return ctrl.Result{}, sendErr
+	}
+	return ctrl.Result{RequeueAfter: genieConfigRecheckInterval}, nil
+}
+
+// testAlert builds the test alert of a route test, routed as in the test's result. Its key
+// changes with the generation, so each test alert starts its own thread.
+func testAlert(test *geniev1alpha1.GenieRouteTest, result *geniev1alpha1.RouteResult) notify.PodAlert {
+	sample := test.Spec.Alert
+	return notify.PodAlert{
+		PodName:       "test-" + test.Name,
+		Namespace:     sample.Namespace,
+		ContainerName: sample.Container,
+		Reason:        sample.Reason,
+		Message:       fmt.Sprintf("This is a test alert sent by GenieRouteTest %s/%s. No pod is failing.", test.Namespace, test.Name),
+		Timestamp:     time.Now(),
+		Channel:       result.Channel,
+		Team:          result.Team,
+		Severity:      notify.Severity(result.Severity),
+		Trace:         result.Trace,
+		Key:           fmt.Sprintf("routetest/%s/%s/%d", test.Namespace, test.Name, test.Generation),
+	}
+}
+
+// routingConfig returns the configuration tests run against and a description of it
+func (r *GenieRouteTestReconciler) routingConfig(ctx context.Context) (*config.Config, string, error) {
+	if r.ActiveConfig.Name == "" {
+		return r.Config, "the loaded configuration", nil
+	}
+
+	var genieConfig geniev1alpha1.GenieConfig
+	if err := r.Get(ctx, r.ActiveConfig, &genieConfig); err != nil {
+		return nil, "", fmt.Errorf("failed to read GenieConfig %s: %w", r.ActiveConfig, err)
+	}
+	cfg, err := config.Parse(genieConfig.Spec.Config.Raw)
+	if err != nil {
+		return nil, "", fmt.Errorf("GenieConfig %s is invalid: %w", r.ActiveConfig, err)
+	}
+	return cfg, fmt.Sprintf("GenieConfig %s generation %d", r.ActiveConfig, genieConfig.Generation), nil
+}
+
+// route routes a sample alert the way the pod reconciler routes real ones. The sample pod
+// has no owner, so workload channel annotations do not apply.
+func (r *GenieRouteTestReconciler) route(ctx context.Context, cfg *config.Config, sample geniev1alpha1.SampleAlert) (*geniev1alpha1.RouteResult, error) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   sample.Namespace,
+		Labels:      sample.Labels,
+		Annotations: sample.Annotations,
+	}}
+	var node *corev1.Node
+	if len(sample.NodeLabels) > 0 || len(sample.NodeTaints) > 0 {
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: sample.NodeLabels}}
+		for _, taint := range sample.NodeTaints {
+			node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{
+				Key:    taint.Key,
+				Value:  taint.Value,
+				Effect: corev1.TaintEffect(taint.Effect),
+			})
+		}
+	}
+	alert := notify.PodAlert{Namespace: sample.Namespace, ContainerName: sample.Container, Reason: sample.Reason}
+
+	var trace alertTrace
+	if !applyOwnership(cfg.Routing.Ownership, pod, node, &alert, &trace) {
+		alert.Channel = pod.Annotations[ChannelAnnotation]
+		source := "pod"
+		if alert.Channel == "" {
+			var namespace corev1.Namespace
+			if err := r.Get(ctx, client.ObjectKey{Name: sample.Namespace}, &namespace); client.IgnoreNotFound(err) != nil {
+				return nil, err
+			}
+			alert.Channel, source = namespace.Annotations[ChannelAnnotation], "namespace"
+		}
+		if alert.Channel != "" {
+			trace.add("channel: %s from %s annotation", alert.Channel, source)
+		} else {
+			trace.add("channel: default, no %s annotation", ChannelAnnotation)
+		}
+	}
+
+	return &geniev1alpha1.RouteResult{
+		Channel:  alert.Channel,
+		Team:     alert.Team,
+		Severity: string(alert.AlertSeverity()),
+		Trace:    trace,
+	}, nil
+}
+
+// expectationMismatches describes how a result differs from the expectation
+func expectationMismatches(expect geniev1alpha1.RouteExpectation, result *geniev1alpha1.RouteResult) []string {
+	var mismatches []string
+	if expect.Channel != nil && *expect.Channel != result.Channel {
+		mismatches = append(mismatches, fmt.Sprintf("expected channel %s, got %s", channelName(*expect.Channel), channelName(result.Channel)))
+	}
+	if expect.Team != nil && *expect.Team != result.Team {
+		mismatches = append(mismatches, fmt.Sprintf("expected team %q, got %q", *expect.Team, result.Team))
+	}
+	if expect.Severity != "" && expect.Severity != result.Severity {
+		mismatches = append(mismatches, fmt.Sprintf("expected severity %s, got %s", expect.Severity, result.Severity))
+	}
+	return mismatches
+}
+
+// SetupWithManager sets up the controller with the Manager. Changes to the active GenieConfig
+// re-evaluate every test.
+func (r *GenieRouteTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&geniev1alpha1.GenieRouteTest{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+
+	if r.ActiveConfig.Name != "" {
+		activePredicate := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return client.ObjectKeyFromObject(obj) == r.ActiveConfig
+		})
+		b = b.Watches(&geniev1alpha1.GenieConfig{},
+			handler.EnqueueRequestsFromMapFunc(r.allTests),
+			builder.WithPredicates(activePredicate, predicate.GenerationChangedPredicate{}),
+		)
+	}
+
+	return b.Named("genieroutetest").Complete(r)
+}
+
+// allTests enqueues every GenieRouteTest
+func (r *GenieRouteTestReconciler) allTests(ctx context.Context, _ client.Object) []reconcile.Request {
+	var tests geniev1alpha1.GenieRouteTestList
+	if err := r.List(ctx, &tests); err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(tests.Items))
+	for _, test := range tests.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&test)})
+	}
+	return requests
+}
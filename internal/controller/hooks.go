@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Alert reasons for container lifecycle hook failures, matching the kubelet event reasons
+const (
+	ReasonFailedPostStartHook = "FailedPostStartHook"
+	ReasonFailedPreStopHook   = "FailedPreStopHook"
+)
+
+// hookEventWindow is how far back hook failure events are considered when classifying a pod
+const hookEventWindow = 10 * time.Minute
+
+// hookFailure describes a failed postStart/preStop hook
+type hookFailure struct {
+	reason    string
+	container string
+	command   string
+	message   string
+}
+
+// isHookFailureReason reports whether an event reason is a lifecycle hook failure
+func isHookFailureReason(reason string) bool {
+	return reason == ReasonFailedPostStartHook || reason == ReasonFailedPreStopHook
+}
+
+// findHookFailure returns the most recent lifecycle hook failure recorded for the pod, if any
+func (r *PodReconciler) findHookFailure(ctx context.Context, pod *corev1.Pod) (*hookFailure, error) {
+	events, err := r.podEvents(ctx, pod, time.Now().Add(-hookEventWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		if !isHookFailureReason(event.Reason) {
+			continue
+		}
+
+		container := containerFromFieldPath(event.InvolvedObject.FieldPath)
+		return &hookFailure{
+			reason:    event.Reason,
+			container: container,
+			command:   hookCommand(pod, container, event.Reason),
+			message:   event.Message,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// describe renders the hook failure for the alert message
+func (h *hookFailure) describe() string {
+	hook := "postStart"
+	if h.reason == ReasonFailedPreStopHook {
+		hook = "preStop"
+	}
+
+	if h.command == "" {
+		return fmt.Sprintf("%s hook failed: %s", hook, h.message)
+	}
+	return fmt.Sprintf("%s hook `%s` failed: %s", hook, h.command, h.message)
+}
+
+// containerFromFieldPath extracts the container name from an event field path
+// such as "spec.containers{app}"
+func containerFromFieldPath(fieldPath string) string {
+	start := strings.Index(fieldPath, "{")
+	end := strings.LastIndex(fieldPath, "}")
+	if start < 0 || end <= start {
+		return ""
+	}
+	return fieldPath[start+1 : end]
+}
+
+// hookCommand renders the configured lifecycle handler of a container for display
+func hookCommand(pod *corev1.Pod, containerName, reason string) string {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != containerName || container.Lifecycle == nil {
+			continue
+		}
+
+		handler := container.Lifecycle.PostStart
+		if reason == ReasonFailedPreStopHook {
+			handler = container.Lifecycle.PreStop
+		}
+		if handler == nil {
+			return ""
+		}
+
+		switch {
+		case handler.Exec != nil:
+			return strings.Join(handler.Exec.Command, " ")
+		case handler.HTTPGet != nil:
+			return fmt.Sprintf("HTTP GET %s:%s%s", handler.HTTPGet.Host, handler.HTTPGet.Port.String(), handler.HTTPGet.Path)
+		case handler.Sleep != nil:
+			return fmt.Sprintf("sleep %ds", handler.Sleep.Seconds)
+		}
+	}
+	return ""
+}
+
+// containerImage returns the image of the named container from the pod spec
+func containerImage(pod *corev1.Pod, containerName string) string {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == containerName {
+			return container.Image
+		}
+	}
+	return ""
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Kubelet event reasons about image pulls
+const (
+	eventReasonFailed                 = "Failed"
+	eventReasonMissingImagePullSecret = "FailedToRetrieveImagePullSecret"
+)
+
+// Causes of image pull failures
+const (
+	pullCauseAuth        = "auth"
+	pullCauseNotFound    = "notFound"
+	pullCauseAmbiguous   = "notFoundOrAuth"
+	pullCauseRateLimited = "rateLimited"
+	pullCauseDNS         = "dns"
+	pullCauseNetwork     = "network"
+	pullCauseTLS         = "tls"
+)
+
+// pullCausePatterns classify runtime pull errors by substrings of their message, checked in
+// order. Docker Hub answers "pull access denied, repository does not exist or may require
+// authorization" for both causes, so that one is checked first.
+var pullCausePatterns = []struct {
+	cause    string
+	patterns []string
+}{
+	{pullCauseAmbiguous, []string{"does not exist or may require authorization"}},
+	{pullCauseRateLimited, []string{"toomanyrequests", "429 too many requests", "rate limit"}},
+	{pullCauseAuth, []string{"unauthorized", "authentication required", "401", "403 forbidden", "denied", "no basic auth credentials"}},
+	{pullCauseNotFound, []string{"manifest unknown", "not found", "404", "name unknown"}},
+	{pullCauseDNS, []string{"no such host", "server misbehaving", "temporary failure in name resolution"}},
+	{pullCauseTLS, []string{"x509", "certificate", "tls: "}},
+	{pullCauseNetwork, []string{"i/o timeout", "connection refused", "connection reset", "deadline exceeded", "network is unreachable", "handshake timeout"}},
+}
+
+// pullCauseAdvice explains each cause for the alert message
+var pullCauseAdvice = map[string]string{
+	pullCauseAuth:        "the registry rejected the credentials. Check that a pull secret for this registry is set and not expired.",
+	pullCauseNotFound:    "the image or tag does not exist. Check the image name and tag for typos and that the tag was pushed.",
+	pullCauseAmbiguous:   "the repository does not exist or requires credentials. Check the image name, then the pull secrets.",
+	pullCauseRateLimited: "the registry rate limited the pull. Authenticate pulls or use a mirror or pull-through cache.",
+	pullCauseDNS:         "the registry host does not resolve. Check the registry name and the node's DNS.",
+	pullCauseTLS:         "the registry's TLS certificate is not trusted by the node's container runtime.",
+	pullCauseNetwork:     "the node cannot reach the registry. Check egress rules, proxies and the registry's availability.",
+}
+
+// classifyPullError returns the cause of an image pull failure from the runtime's error, or
+// an empty string if it is not recognized
+func classifyPullError(message string) string {
+	lower := strings.ToLower(message)
+	for _, class := range pullCausePatterns {
+		for _, pattern := range class.patterns {
+			if strings.Contains(lower, pattern) {
+				return class.cause
+			}
+		}
+	}
+	return ""
+}
+
+// isImagePullReason reports whether a waiting reason is an image pull failure
+func isImagePullReason(reason string) bool {
+	return reason == "ImagePullBackOff" || reason == "ErrImagePull"
+}
+
+// describeImagePull diagnoses an image pull failure of a container. The back-off message in
+// the pod status only names the image, so the runtime's error is taken from the latest pull
+// failure event. Missing pull secrets are reported by the kubelet in their own event, which
+// spares the operator reading Secrets.
+func (r *PodReconciler) describeImagePull(ctx context.Context, pod *corev1.Pod, container, statusMessage string, now time.Time) (string, error) {
+	events, err := r.podEvents(ctx, pod, now.Add(-recentEventWindow))
+	if err != nil {
+		return "", err
+	}
+
+	pullError := statusMessage
+	var missingSecrets string
+	for _, event := range events {
+		switch {
+		case event.Reason == eventReasonFailed && strings.HasPrefix(event.Message, "Failed to pull image") &&
+			containerFromFieldPath(event.InvolvedObject.FieldPath) == container && pullError == statusMessage:
+			pullError = event.Message
+		case event.Reason == eventReasonMissingImagePullSecret && missingSecrets == "":
+			missingSecrets = strings.TrimSpace(event.Message)
+		}
+	}
+
+	var b strings.Builder
+	if cause := classifyPullError(pullError); cause != "" {
+		fmt.Fprintf(&b, "🔍 Diagnosis: %s", pullCauseAdvice[cause])
+	} else {
+		b.WriteString("🔍 Diagnosis: unrecognized pull error.")
+	}
+
+	secrets := make([]string, 0, len(pod.Spec.ImagePullSecrets))
+	for _, secret := range pod.Spec.ImagePullSecrets {
+		secrets = append(secrets, "`"+secret.Name+"`")
+	}
+	switch {
+	case missingSecrets != "":
+		fmt.Fprintf(&b, "\n⚠️ Pull secret missing: %s", missingSecrets)
+	case len(secrets) > 0:
+		fmt.Fprintf(&b, "\nPull secrets: %s", strings.Join(secrets, ", "))
+	default:
+		b.WriteString("\nPull secrets: none, neither on the pod nor its service account")
+	}
+
+	if pullError != statusMessage {
+		fmt.Fprintf(&b, "\n\nPull error: %s", strings.TrimSpace(pullError))
+	}
+	return b.String(), nil
+}
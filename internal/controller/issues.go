@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// IssueTracker finds open issues tracking a failure
+type IssueTracker interface {
+	FindOpen(ctx context.Context, fingerprint string) (*notify.Issue, error)
+}
+
+// attachKnownIssue fingerprints the alert's failure and links the open issue tracking it. Without
+// one, the alert offers to open an issue if the create button is enabled.
+func (r *PodReconciler) attachKnownIssue(ctx context.Context, pod *corev1.Pod, alert *notify.PodAlert) error {
+	fingerprint, err := r.failureFingerprint(ctx, pod, alert.ContainerName, alert.Reason)
+	if err != nil {
+		return err
+	}
+	alert.Fingerprint = fingerprint
+
+	issue, err := r.Issues.FindOpen(ctx, fingerprint)
+	if err != nil {
+		return err
+	}
+	alert.KnownIssue = issue
+	alert.OfferIssue = issue == nil && r.Config.GitHub.CreateButton
+	return nil
+}
+
+// failureFingerprint identifies a failure across restarts and replacements of its pod by the
+// owning workload, or the pod itself for bare pods, the container and the reason
+func (r *PodReconciler) failureFingerprint(ctx context.Context, pod *corev1.Pod, container, reason string) (string, error) {
+	kind, name := "Pod", pod.Name
+	owner, err := r.resolveOwner(ctx, pod)
+	if err != nil {
+		return "", err
+	}
+	if owner != nil {
+		kind, name = owner.Kind, owner.Name
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{pod.Namespace, kind, name, container, reason}, "/")))
+	return "slackgenie-" + hex.EncodeToString(sum[:8]), nil
+}
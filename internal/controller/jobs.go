@@ -0,0 +1,227 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// isJobRetryReason reports whether a failure reason is a failed attempt the Job controller
+// retries, as opposed to a pod that cannot start at all
+func isJobRetryReason(reason string) bool {
+	switch reason {
+	case "Error", "CrashLoopBackOff", "OOMKilled", "ContainerCannotRun", string(corev1.PodFailed),
+		ReasonLivenessProbeFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// podJob returns the Job controlling the pod, or nil if it is not controlled by an existing Job
+func (r *PodReconciler) podJob(ctx context.Context, pod *corev1.Pod) (*batchv1.Job, error) {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil || ref.Kind != "Job" || !strings.HasPrefix(ref.APIVersion, batchv1.GroupName+"/") {
+		return nil, nil
+	}
+
+	var job batchv1.Job
+	if err := r.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: ref.Name}, &job); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if job.UID != ref.UID {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+// jobFailedCondition returns the Failed condition of a Job if it is True, or nil
+func jobFailedCondition(job *batchv1.Job) *batchv1.JobCondition {
+	for i := range job.Status.Conditions {
+		if condition := &job.Status.Conditions[i]; condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return condition
+		}
+	}
+	return nil
+}
+
+// JobReconciler alerts once when a Job fails, replacing the alerts for the failed attempts of
+// its pods when jobs.suppress is set. Alerts are routed like pod alerts, see routeObject.
+type JobReconciler struct {
+	client.Client
+	Notifier notify.Notifier
+	Routing  config.RoutingConfig
+
+	mu sync.Mutex
+	// failed are the failed Jobs that were alerted on
+	failed map[types.NamespacedName]types.UID
+}
+
+// Reconcile alerts on a Job whose Failed condition is True, once
+func (r *JobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	var job batchv1.Job
+	if err := r.Get(ctx, req.NamespacedName, &job); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			r.mu.Lock()
+			delete(r.failed, req.NamespacedName)
+			r.mu.Unlock()
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	condition := jobFailedCondition(&job)
+	r.mu.Lock()
+	alerted := r.failed[req.NamespacedName] == job.UID
+	r.mu.Unlock()
+	if condition == nil || alerted {
+		return ctrl.Result{}, nil
+	}
+
+	lastFailure, err := r.lastPodFailure(ctx, &job)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	channel, err := routeObject(ctx, r.Client, r.Routing.Ownership, &job)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Notifier.SendMetaAlert(jobFailureAlert(&job, condition, lastFailure, channel, time.Now())); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.mu.Lock()
+	r.failed[req.NamespacedName] = job.UID
+	r.mu.Unlock()
+	logger.Info("Job failed", "namespace", job.Namespace, "job", job.Name, "reason", condition.Reason)
+	return ctrl.Result{}, nil
+}
+
+// lastPodFailure describes the most recent container failure among the Job's pods, e.g.
+// "container migrate of pod db-migrate-x7k2p: Error, exit code 1", or returns an empty string
+func (r *JobReconciler) lastPodFailure(ctx context.Context, job *batchv1.Job) (string, error) {
+	if job.Spec.Selector == nil {
+		return "", nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return "", err
+	}
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.InNamespace(job.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return "", err
+	}
+
+	var latest time.Time
+	var description string
+	for _, pod := range pods.Items {
+		for _, status := range append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+			terminated := status.State.Terminated
+			if terminated == nil {
+				terminated = status.LastTerminationState.Terminated
+			}
+			if terminated == nil || terminated.ExitCode == 0 || terminated.FinishedAt.Time.Before(latest) {
+				continue
+			}
+			latest = terminated.FinishedAt.Time
+			description = fmt.Sprintf("container %s of pod %s: %s, exit code %d", status.Name, pod.Name, terminated.Reason, terminated.ExitCode)
+			if message := strings.TrimSpace(terminated.Message); message != "" {
+				description += ": " + truncateCause(message)
+			}
+		}
+	}
+	return description, nil
+}
+
+// jobFailureAlert builds the alert for a failed Job
+func jobFailureAlert(job *batchv1.Job, condition *batchv1.JobCondition, lastFailure, channel string, now time.Time) notify.MetaAlert {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Job `%s/%s` failed", job.Namespace, job.Name)
+	if condition.Reason != "" {
+		fmt.Fprintf(&b, " (%s)", condition.Reason)
+	}
+	if message := strings.TrimSpace(condition.Message); message != "" {
+		fmt.Fprintf(&b, ": %s", message)
+	}
+	b.WriteString(".\n")
+
+	backoffLimit := int32(6)
+	if job.Spec.BackoffLimit != nil {
+		backoffLimit = *job.Spec.BackoffLimit
+	}
+	fmt.Fprintf(&b, "*Attempts:* %d failed, %d succeeded, backoffLimit %d\n", job.Status.Failed, job.Status.Succeeded, backoffLimit)
+	if ref := metav1.GetControllerOf(job); ref != nil && ref.Kind == "CronJob" {
+		fmt.Fprintf(&b, "*CronJob:* %s\n", ref.Name)
+	}
+	if lastFailure != "" {
+		fmt.Fprintf(&b, "*Last failure:* %s\n", lastFailure)
+	}
+	fmt.Fprintf(&b, "Logs of the last attempt: `kubectl logs -n %s job/%s`", job.Namespace, job.Name)
+
+	return notify.MetaAlert{
+		Title:     fmt.Sprintf("❌ Job %s/%s failed", job.Namespace, job.Name),
+		Text:      b.String(),
+		Severity:  notify.SeverityWarning,
+		Channel:   channel,
+		Timestamp: now,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager. Only Jobs turning Failed are
+// reconciled, so Jobs that failed before the operator started are not alerted on.
+func (r *JobReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.failed = make(map[types.NamespacedName]types.UID)
+
+	failedPredicate := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return jobFailedCondition(e.ObjectOld.(*batchv1.Job)) == nil && jobFailedCondition(e.ObjectNew.(*batchv1.Job)) != nil
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&batchv1.Job{}, builder.WithPredicates(failedPredicate)).
+		Named("job").
+		Complete(r)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// maxLogBytes bounds how much of a container's log is read; the enrichment budget trims it further
+const maxLogBytes = 64 << 10
+
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
+
+// logTail returns the last lines of the container's log, cut to the logs budget, as a code
+// block, or an empty string if the container never ran. A crash-looping container is waiting
+// to restart, so the log of its previous instance is read.
+func (r *PodReconciler) logTail(ctx context.Context, pod *corev1.Pod, container string, budget *enrichmentBudget) (string, error) {
+	var status *corev1.ContainerStatus
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.ContainerStatuses, pod.Status.InitContainerStatuses} {
+		for i := range statuses {
+			if statuses[i].Name == container {
+				status = &statuses[i]
+			}
+		}
+	}
+	if status == nil || (status.State.Running == nil && status.State.Terminated == nil && status.RestartCount == 0) {
+		return "", nil
+	}
+
+	lines := r.Config.Enrichment.LogTailLines
+	previous := status.State.Waiting != nil
+	stream, err := r.Clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+		TailLines: &lines,
+	}).Stream(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+
+	data, err := io.ReadAll(io.LimitReader(stream, maxLogBytes))
+	if err != nil {
+		return "", err
+	}
+	logs := budget.fit(sourceLogs, strings.TrimRight(string(data), "\n"))
+	if logs == "" {
+		return "", nil
+	}
+
+	instance := "container"
+	if previous {
+		instance = "previous instance of container"
+	}
+	return fmt.Sprintf("📜 Last %d log lines of %s %s:\n```\n%s\n```", lines, instance, container, logs), nil
+}
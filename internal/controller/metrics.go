@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var alertCacheSize = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "slackgenie_alert_cache_size",
+		Help: "Number of pod/reason entries in the alert debounce cache",
+	},
+)
+
+var timerWheelPending = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "slackgenie_timer_wheel_pending",
+		Help: "Number of pods waiting on the event-driven timer wheel",
+	},
+)
+
+var timerWheelFiredTotal = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Name: "slackgenie_timer_wheel_fired_total",
+		Help: "Total number of pods re-examined because their timer wheel entry fired",
+	},
+)
+
+var podRetriesTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "slackgenie_pod_retries_total",
+		Help: "Total number of pods scheduled for re-examination after a failed delivery or resolution, by detection mode",
+	},
+	[]string{"mode"},
+)
+
+var alertsEscalatedTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "slackgenie_alerts_escalated_total",
+		Help: "Total number of unacknowledged alerts re-sent to an escalation channel",
+	},
+	[]string{"channel"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(alertCacheSize, timerWheelPending, timerWheelFiredTotal, podRetriesTotal, alertsEscalatedTotal)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// nodeConditionTypes are the node conditions attached to alerts, in display order
+var nodeConditionTypes = []corev1.NodeConditionType{
+	corev1.NodeReady,
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+	corev1.NodeNetworkUnavailable,
+}
+
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+
+// attachNodeContext records the node the pod runs on and the node's readiness and pressure
+// conditions on the alert
+func (r *PodReconciler) attachNodeContext(ctx context.Context, pod *corev1.Pod, alert *notify.PodAlert) error {
+	if pod.Spec.NodeName == "" {
+		return nil
+	}
+	alert.Node = pod.Spec.NodeName
+
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, &node); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	for _, conditionType := range nodeConditionTypes {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == conditionType {
+				alert.NodeConditions = append(alert.NodeConditions, notify.NodeCondition{
+					Type:   string(condition.Type),
+					Status: string(condition.Status),
+				})
+			}
+		}
+	}
+	return nil
+}
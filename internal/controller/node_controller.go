@@ -0,0 +1,388 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// pressureConditionTypes are the node conditions that make the kubelet evict pods
+var pressureConditionTypes = []corev1.NodeConditionType{
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+}
+
+// nodeConditionKey identifies a condition of a node
+type nodeConditionKey struct {
+	node      string
+	condition corev1.NodeConditionType
+}
+
+// nodeOutage is a NotReady node that was alerted on
+type nodeOutage struct {
+	// since is when the node became NotReady
+	since time.Time
+	// pods is the number of pods scheduled on the node when it was alerted on
+	pods int
+}
+
+// NodeReconciler alerts on node conditions. Pressure precedes mass evictions, which would
+// otherwise be reported pod by pod, and a NotReady node takes all its pods down at once.
+type NodeReconciler struct {
+	client.Client
+	Notifier notify.Notifier
+	Config   config.NodesConfig
+
+	mu sync.Mutex
+	// pressureAlerts is when each node condition was last alerted on
+	pressureAlerts map[nodeConditionKey]time.Time
+	// outages are the NotReady nodes that were alerted on, by name
+	outages map[string]nodeOutage
+}
+
+// Reconcile alerts on a node that has been NotReady for the grace period, on its recovery, and
+// on pressure conditions of the node that are True and were not alerted on within the window
+func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	var node corev1.Node
+	if err := r.Get(ctx, req.NamespacedName, &node); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			r.forget(req.Name)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	now := time.Now()
+	var result ctrl.Result
+	if r.Config.NotReady.Enabled {
+		wait, err := r.checkReady(ctx, &node, now)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		result.RequeueAfter = wait
+	}
+
+	if !r.Config.Pressure.Enabled {
+		return result, nil
+	}
+	for _, condition := range node.Status.Conditions {
+		if !isPressureCondition(condition) || !r.pressureDue(node.Name, condition.Type, now) {
+			continue
+		}
+
+		alert, err := r.pressureAlert(ctx, &node, condition, now)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.Notifier.SendMetaAlert(alert); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.recordPressure(node.Name, condition.Type, now)
+		logger.Info("Node under pressure", "node", node.Name, "condition", condition.Type)
+	}
+	return result, nil
+}
+
+// nodeReadyCondition returns the Ready condition of a node, or nil if it has not reported one
+func nodeReadyCondition(node *corev1.Node) *corev1.NodeCondition {
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == corev1.NodeReady {
+			return &node.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// checkReady alerts on a node that has been NotReady for the grace period, and on the recovery
+// of a node that was alerted on. It returns how long until the grace period of a NotReady node
+// ends, or zero.
+func (r *NodeReconciler) checkReady(ctx context.Context, node *corev1.Node, now time.Time) (time.Duration, error) {
+	logger := logf.FromContext(ctx)
+	ready := nodeReadyCondition(node)
+	if ready == nil {
+		return 0, nil
+	}
+
+	r.mu.Lock()
+	outage, alerted := r.outages[node.Name]
+	r.mu.Unlock()
+
+	if ready.Status == corev1.ConditionTrue {
+		if !alerted {
+			return 0, nil
+		}
+		alert, err := r.recoveryAlert(ctx, node, outage, now)
+		if err != nil {
+			return 0, err
+		}
+		if err := r.Notifier.SendMetaAlert(alert); err != nil {
+			return 0, err
+		}
+		r.mu.Lock()
+		delete(r.outages, node.Name)
+		r.mu.Unlock()
+		logger.Info("Node recovered", "node", node.Name, "notReadyFor", now.Sub(outage.since).Round(time.Second))
+		return 0, nil
+	}
+	if alerted {
+		return 0, nil
+	}
+
+	since := ready.LastTransitionTime.Time
+	if since.IsZero() {
+		since = now
+	}
+	if wait := r.Config.NotReady.Grace.Duration - now.Sub(since); wait > 0 {
+		return wait, nil
+	}
+
+	pods, err := r.nodePods(ctx, node.Name)
+	if err != nil {
+		return 0, err
+	}
+	if err := r.Notifier.SendMetaAlert(r.notReadyAlert(node, ready, since, len(pods), now)); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	r.outages[node.Name] = nodeOutage{since: since, pods: len(pods)}
+	r.mu.Unlock()
+	logger.Info("Node NotReady", "node", node.Name, "status", ready.Status, "reason", ready.Reason)
+	return 0, nil
+}
+
+// notReadyAlert builds the alert for a node that has been NotReady for the grace period
+func (r *NodeReconciler) notReadyAlert(node *corev1.Node, ready *corev1.NodeCondition, since time.Time, pods int, now time.Time) notify.MetaAlert {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Node `%s` has been NotReady for %s", node.Name, formatReminderAge(now.Sub(since)))
+	if ready.Reason != "" {
+		fmt.Fprintf(&b, " (%s)", ready.Reason)
+	}
+	if message := strings.TrimSpace(ready.Message); message != "" {
+		fmt.Fprintf(&b, ": %s", message)
+	}
+	fmt.Fprintf(&b, ".\n%d pod(s) are scheduled on it.", pods)
+
+	if ready.Status == corev1.ConditionUnknown {
+		b.WriteString(" The kubelet stopped reporting, e.g. because the node is down or cut off from the control plane.")
+	}
+	if node.Spec.Unschedulable {
+		b.WriteString(" The node is cordoned, so it may be under maintenance.")
+	}
+	b.WriteString(" Its pods are evicted once they no longer tolerate the node's not-ready or unreachable taint, by default after 5m.")
+
+	return notify.MetaAlert{
+		Title:     fmt.Sprintf("🔴 Node %s is NotReady", node.Name),
+		Text:      b.String(),
+		Severity:  notify.SeverityCritical,
+		Channel:   r.Config.Channel,
+		Timestamp: now,
+	}
+}
+
+// recoveryAlert builds the message for a node that is Ready again after it was alerted on
+func (r *NodeReconciler) recoveryAlert(ctx context.Context, node *corev1.Node, outage nodeOutage, now time.Time) (notify.MetaAlert, error) {
+	pods, err := r.nodePods(ctx, node.Name)
+	if err != nil {
+		return notify.MetaAlert{}, err
+	}
+
+	text := fmt.Sprintf("Node `%s` is Ready again after %s NotReady.\n%d pod(s) were scheduled on it when it was alerted on; %d are scheduled on it now.",
+		node.Name, formatReminderAge(now.Sub(outage.since)), outage.pods, len(pods))
+	return notify.MetaAlert{
+		Title:     fmt.Sprintf("✅ Node %s recovered", node.Name),
+		Text:      text,
+		Severity:  notify.SeverityInfo,
+		Channel:   r.Config.Channel,
+		Timestamp: now,
+	}, nil
+}
+
+// isPressureCondition reports whether a node condition is a pressure condition that is True
+func isPressureCondition(condition corev1.NodeCondition) bool {
+	for _, conditionType := range pressureConditionTypes {
+		if condition.Type == conditionType {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// pressureDue reports whether a node condition was not alerted on within the window
+func (r *NodeReconciler) pressureDue(node string, condition corev1.NodeConditionType, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last, alerted := r.pressureAlerts[nodeConditionKey{node, condition}]
+	return !alerted || now.Sub(last) >= r.Config.Pressure.Window.Duration
+}
+
+// recordPressure records that a node condition was alerted on
+func (r *NodeReconciler) recordPressure(node string, condition corev1.NodeConditionType, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pressureAlerts[nodeConditionKey{node, condition}] = now
+}
+
+// forget drops the state of a deleted node
+func (r *NodeReconciler) forget(node string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key := range r.pressureAlerts {
+		if key.node == node {
+			delete(r.pressureAlerts, key)
+		}
+	}
+	delete(r.outages, node)
+}
+
+// nodePods returns the pods scheduled on a node that have not finished
+func (r *NodeReconciler) nodePods(ctx context.Context, node string) ([]corev1.Pod, error) {
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods); err != nil {
+		return nil, err
+	}
+
+	var scheduled []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == node && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			scheduled = append(scheduled, pod)
+		}
+	}
+	return scheduled, nil
+}
+
+// pressureAlert builds the alert for a pressure condition of a node
+func (r *NodeReconciler) pressureAlert(ctx context.Context, node *corev1.Node, condition corev1.NodeCondition, now time.Time) (notify.MetaAlert, error) {
+	pods, err := r.nodePods(ctx, node.Name)
+	if err != nil {
+		return notify.MetaAlert{}, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Node `%s` reports %s", node.Name, condition.Type)
+	if !condition.LastTransitionTime.IsZero() {
+		fmt.Fprintf(&b, " since %s", condition.LastTransitionTime.UTC().Format("15:04 MST"))
+	}
+	if message := strings.TrimSpace(condition.Message); message != "" {
+		fmt.Fprintf(&b, ": %s", message)
+	}
+	fmt.Fprintf(&b, ".\n%d pod(s) run on it.", len(pods))
+
+	switch condition.Type {
+	case corev1.NodeMemoryPressure:
+		b.WriteString(" The kubelet evicts pods using more memory than they request, starting with BestEffort pods, until memory is reclaimed.")
+	case corev1.NodeDiskPressure:
+		b.WriteString(" The kubelet removes unused images and evicts pods with the largest local storage use, e.g. logs and emptyDir volumes.")
+	case corev1.NodePIDPressure:
+		b.WriteString(" The kubelet evicts pods running the most processes; look for a fork bomb or a leak of threads.")
+	}
+	b.WriteString(" New pods are not scheduled on the node while the condition lasts.")
+
+	return notify.MetaAlert{
+		Title:     fmt.Sprintf("🔥 %s on node %s", condition.Type, node.Name),
+		Text:      b.String(),
+		Severity:  notify.SeverityWarning,
+		Channel:   r.Config.Channel,
+		Timestamp: now,
+	}, nil
+}
+
+// pressureChanged reports whether any pressure condition turned True between two versions of
+// a node
+func pressureChanged(oldNode, newNode *corev1.Node) bool {
+	for _, condition := range newNode.Status.Conditions {
+		if !isPressureCondition(condition) {
+			continue
+		}
+		wasTrue := false
+		for _, old := range oldNode.Status.Conditions {
+			if old.Type == condition.Type && isPressureCondition(old) {
+				wasTrue = true
+			}
+		}
+		if !wasTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// readyChanged reports whether the Ready condition changed status between two versions of a
+// node
+func readyChanged(oldNode, newNode *corev1.Node) bool {
+	oldReady, newReady := nodeReadyCondition(oldNode), nodeReadyCondition(newNode)
+	if oldReady == nil || newReady == nil {
+		return oldReady != newReady
+	}
+	return oldReady.Status != newReady.Status
+}
+
+// SetupWithManager sets up the controller with the Manager. Nodes update their heartbeat
+// constantly, so only condition transitions are reconciled.
+func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.pressureAlerts = make(map[nodeConditionKey]time.Time)
+	r.outages = make(map[string]nodeOutage)
+
+	nodePredicate := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			node := e.Object.(*corev1.Node)
+			if ready := nodeReadyCondition(node); ready != nil && ready.Status != corev1.ConditionTrue {
+				return true
+			}
+			for _, condition := range node.Status.Conditions {
+				if isPressureCondition(condition) {
+					return true
+				}
+			}
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, newNode := e.ObjectOld.(*corev1.Node), e.ObjectNew.(*corev1.Node)
+			return readyChanged(oldNode, newNode) || pressureChanged(oldNode, newNode)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Node{}, builder.WithPredicates(nodePredicate)).
+		Named("node").
+		Complete(r)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// podMetricsGVK is the metrics-server PodMetrics kind, read as unstructured to avoid depending
+// on the metrics client
+var podMetricsGVK = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetrics"}
+
+// nearLimitPercent is the memory usage, as a share of the limit, above which the limit is
+// likely too low
+const nearLimitPercent = 90
+
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
+
+// oomKilled reports whether the container was OOM killed, either just now or in the
+// termination that put it into a crash loop
+func oomKilled(pod *corev1.Pod, container, reason string) bool {
+	if reason == "OOMKilled" {
+		return true
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container && status.LastTerminationState.Terminated != nil {
+			return status.LastTerminationState.Terminated.Reason == "OOMKilled"
+		}
+	}
+	return false
+}
+
+// memoryContext describes the memory request and limit of an OOM killed container and its
+// last usage reported by metrics-server, e.g. "🧠 Memory of container app: request 256Mi,
+// limit 512Mi, last usage 498Mi (97% of the limit)". Usage is omitted when metrics-server is
+// not installed or has no sample of the pod yet.
+func (r *PodReconciler) memoryContext(ctx context.Context, pod *corev1.Pod, container string) (string, error) {
+	var request, limit *resource.Quantity
+	for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if c.Name != container {
+			continue
+		}
+		if q, ok := c.Resources.Requests[corev1.ResourceMemory]; ok {
+			request = &q
+		}
+		if q, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+			limit = &q
+		}
+	}
+
+	description := fmt.Sprintf("🧠 Memory of container %s:", container)
+	if request != nil {
+		description += fmt.Sprintf(" request %s,", request.String())
+	} else {
+		description += " no request,"
+	}
+	if limit != nil {
+		description += fmt.Sprintf(" limit %s", limit.String())
+	} else {
+		description += " no limit"
+	}
+
+	usage, err := r.memoryUsage(ctx, pod, container)
+	if err != nil {
+		return "", err
+	}
+	if usage != nil {
+		description += fmt.Sprintf(", last usage %dMi", usage.Value()>>20)
+		if limit != nil && limit.Value() > 0 {
+			percent := 100 * usage.Value() / limit.Value()
+			description += fmt.Sprintf(" (%d%% of the limit)", percent)
+			if percent >= nearLimitPercent {
+				description += ". Usage is close to the limit, which likely needs raising."
+			}
+		}
+	}
+
+	if limit == nil {
+		description += ". Without a limit the container was killed under node memory pressure; set a request that covers its usage."
+	}
+	return description, nil
+}
+
+// memoryUsage returns the container's last memory usage reported by metrics-server, or nil if
+// there is none. The sample covers the restarted container, so it shows how fast memory grows
+// back rather than the usage at the time of the kill.
+func (r *PodReconciler) memoryUsage(ctx context.Context, pod *corev1.Pod, container string) (*resource.Quantity, error) {
+	podMetrics := &unstructured.Unstructured{}
+	podMetrics.SetGroupVersionKind(podMetricsGVK)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(pod), podMetrics); err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	containers, _, _ := unstructured.NestedSlice(podMetrics.Object, "containers")
+	for _, c := range containers {
+		metrics, ok := c.(map[string]any)
+		if !ok || metrics["name"] != container {
+			continue
+		}
+		memory, _, _ := unstructured.NestedString(metrics, "usage", "memory")
+		if q, err := resource.ParseQuantity(memory); err == nil {
+			return &q, nil
+		}
+	}
+	return nil, nil
+}
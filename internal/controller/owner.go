@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// maxOwnerDepth bounds the ownerReferences walk (Pod → ReplicaSet → Deployment is depth 2)
+const maxOwnerDepth = 4
+
+// +kubebuilder:rbac:groups=apps,resources=replicasets;deployments;statefulsets;daemonsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs;cronjobs,verbs=get;list;watch
+
+// resolveOwner walks the controller ownerReferences of a pod and returns the metadata of
+// the top-level owning workload (e.g. Deployment, StatefulSet, CronJob).
+// Returns nil if the pod has no controller owner.
+func (r *PodReconciler) resolveOwner(ctx context.Context, pod *corev1.Pod) (*metav1.PartialObjectMetadata, error) {
+	var owner *metav1.PartialObjectMetadata
+	namespace := pod.Namespace
+	ref := metav1.GetControllerOf(pod)
+
+	for depth := 0; ref != nil && depth < maxOwnerDepth; depth++ {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			return owner, err
+		}
+
+		obj := &metav1.PartialObjectMetadata{}
+		obj.SetGroupVersionKind(gv.WithKind(ref.Kind))
+		if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, obj); err != nil {
+			return owner, client.IgnoreNotFound(err)
+		}
+
+		owner = obj
+		ref = metav1.GetControllerOf(obj)
+	}
+
+	return owner, nil
+}
+
+// attachWorkload names the pod's owning workload in the alert, so recipients see e.g.
+// "Deployment payments-api" rather than only a hashed pod name
+func (r *PodReconciler) attachWorkload(ctx context.Context, pod *corev1.Pod, alert *notify.PodAlert) error {
+	owner, err := r.resolveOwner(ctx, pod)
+	if owner != nil {
+		alert.WorkloadKind = owner.Kind
+		alert.WorkloadName = owner.Name
+	}
+	return err
+}
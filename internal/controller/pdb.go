@@ -0,0 +1,287 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch
+
+// pdbAlertLevel is how serious the last alert for a PDB was, so a PDB that starts blocking a
+// drain after it was alerted on for allowing no disruptions is alerted on again
+type pdbAlertLevel int
+
+const (
+	// pdbNoDisruptions is a PDB that has allowed no disruptions for the threshold
+	pdbNoDisruptions pdbAlertLevel = iota + 1
+	// pdbBlockingDrain is a PDB allowing no disruptions with pods on a cordoned node
+	pdbBlockingDrain
+)
+
+// PDBReconciler alerts on PodDisruptionBudgets that allow no disruptions for an extended
+// period, or while pods they cover run on a cordoned node, so operators know which workloads
+// block node drains
+type PDBReconciler struct {
+	client.Client
+	Notifier notify.Notifier
+	Config   config.PDBsConfig
+
+	mu sync.Mutex
+	// alerted is the level of the last alert for each PDB that still allows no disruptions
+	alerted map[types.NamespacedName]pdbAlertLevel
+}
+
+// Reconcile alerts on a PDB allowing no disruptions once the threshold has passed, or right
+// away if it blocks the drain of a cordoned node
+func (r *PDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	var pdb policyv1.PodDisruptionBudget
+	if err := r.Get(ctx, req.NamespacedName, &pdb); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			r.forget(req.NamespacedName)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// A PDB selecting no pods allows no disruptions without blocking anything
+	if pdb.Status.DisruptionsAllowed > 0 || pdb.Status.ExpectedPods == 0 {
+		r.forget(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	since := pdbBlockedSince(&pdb)
+	pods, err := r.pdbPods(ctx, &pdb)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	draining, err := r.cordonedNodes(ctx, pods)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	level := pdbBlockingDrain
+	if len(draining) == 0 {
+		if wait := r.Config.Threshold.Duration - now.Sub(since); wait > 0 {
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+		level = pdbNoDisruptions
+	}
+
+	r.mu.Lock()
+	alerted := r.alerted[req.NamespacedName]
+	r.mu.Unlock()
+	if alerted >= level {
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.Notifier.SendMetaAlert(r.pdbAlert(&pdb, pods, draining, since, now)); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.mu.Lock()
+	r.alerted[req.NamespacedName] = level
+	r.mu.Unlock()
+	logger.Info("PodDisruptionBudget allows no disruptions", "namespace", pdb.Namespace, "pdb", pdb.Name,
+		"cordonedNodes", len(draining))
+	return ctrl.Result{}, nil
+}
+
+// forget drops the alert state of a PDB that allows disruptions again or was deleted
+func (r *PDBReconciler) forget(pdb types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.alerted, pdb)
+}
+
+// pdbBlockedSince returns when a PDB stopped allowing disruptions, from its DisruptionAllowed
+// condition, or its creation if it has none
+func pdbBlockedSince(pdb *policyv1.PodDisruptionBudget) time.Time {
+	condition := meta.FindStatusCondition(pdb.Status.Conditions, policyv1.DisruptionAllowedCondition)
+	if condition != nil && condition.Status == metav1.ConditionFalse {
+		return condition.LastTransitionTime.Time
+	}
+	return pdb.CreationTimestamp.Time
+}
+
+// pdbPods returns the pods selected by a PDB
+func (r *PDBReconciler) pdbPods(ctx context.Context, pdb *policyv1.PodDisruptionBudget) ([]corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.InNamespace(pdb.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// cordonedNodes returns the cordoned nodes running any of the pods, sorted by name
+func (r *PDBReconciler) cordonedNodes(ctx context.Context, pods []corev1.Pod) ([]string, error) {
+	var cordoned []string
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || slices.Contains(cordoned, pod.Spec.NodeName) {
+			continue
+		}
+		var node corev1.Node
+		if err := r.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, &node); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				continue
+			}
+			return nil, err
+		}
+		if node.Spec.Unschedulable {
+			cordoned = append(cordoned, node.Name)
+		}
+	}
+	sort.Strings(cordoned)
+	return cordoned, nil
+}
+
+// pdbAlert builds the alert for a PDB allowing no disruptions
+func (r *PDBReconciler) pdbAlert(pdb *policyv1.PodDisruptionBudget, pods []corev1.Pod, draining []string, since, now time.Time) notify.MetaAlert {
+	var b strings.Builder
+	fmt.Fprintf(&b, "PodDisruptionBudget `%s/%s` has allowed no disruptions for %s: %d of %d pods healthy, %d required",
+		pdb.Namespace, pdb.Name, formatReminderAge(now.Sub(since)),
+		pdb.Status.CurrentHealthy, pdb.Status.ExpectedPods, pdb.Status.DesiredHealthy)
+	switch {
+	case pdb.Spec.MinAvailable != nil:
+		fmt.Fprintf(&b, " (minAvailable %s)", pdb.Spec.MinAvailable.String())
+	case pdb.Spec.MaxUnavailable != nil:
+		fmt.Fprintf(&b, " (maxUnavailable %s)", pdb.Spec.MaxUnavailable.String())
+	}
+	b.WriteString(".\n")
+
+	if controllers := podControllers(pods); len(controllers) > 0 {
+		fmt.Fprintf(&b, "It covers the pods of %s.\n", strings.Join(controllers, ", "))
+	}
+
+	title := fmt.Sprintf("🚧 PodDisruptionBudget %s/%s allows no disruptions", pdb.Namespace, pdb.Name)
+	if len(draining) > 0 {
+		title = fmt.Sprintf("🚧 PodDisruptionBudget %s/%s is blocking a node drain", pdb.Namespace, pdb.Name)
+		fmt.Fprintf(&b, "Its pods run on cordoned node(s) %s, whose drain cannot evict them until more pods are healthy.",
+			strings.Join(draining, ", "))
+	} else if pdb.Status.CurrentHealthy >= pdb.Status.ExpectedPods {
+		b.WriteString("All pods are healthy, so the budget never allows a disruption; node drains and cluster autoscaler scale-downs will block on these pods.")
+	} else {
+		b.WriteString("Unhealthy pods use up the budget; node drains will block on the remaining pods until they recover.")
+	}
+
+	return notify.MetaAlert{
+		Title:     title,
+		Text:      b.String(),
+		Severity:  notify.SeverityWarning,
+		Channel:   r.Config.Channel,
+		Timestamp: now,
+	}
+}
+
+// podControllers names the controllers of the pods, e.g. "ReplicaSet web-7d9f", sorted
+func podControllers(pods []corev1.Pod) []string {
+	var controllers []string
+	for _, pod := range pods {
+		ref := metav1.GetControllerOf(&pod)
+		if ref == nil {
+			continue
+		}
+		if name := ref.Kind + " " + ref.Name; !slices.Contains(controllers, name) {
+			controllers = append(controllers, name)
+		}
+	}
+	sort.Strings(controllers)
+	return controllers
+}
+
+// blockingPDBs maps a node that was cordoned to the PDBs allowing no disruptions, which may
+// now block its drain
+func (r *PDBReconciler) blockingPDBs(ctx context.Context, _ client.Object) []reconcile.Request {
+	var pdbs policyv1.PodDisruptionBudgetList
+	if err := r.List(ctx, &pdbs); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list PodDisruptionBudgets")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, pdb := range pdbs.Items {
+		if pdb.Status.DisruptionsAllowed == 0 && pdb.Status.ExpectedPods > 0 {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&pdb)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager. PDBs are reconciled when their
+// status changes, and those allowing no disruptions again when a node is cordoned.
+func (r *PDBReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.alerted = make(map[types.NamespacedName]pdbAlertLevel)
+
+	cordonPredicate := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !e.ObjectOld.(*corev1.Node).Spec.Unschedulable && e.ObjectNew.(*corev1.Node).Spec.Unschedulable
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&policyv1.PodDisruptionBudget{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldPDB, newPDB := e.ObjectOld.(*policyv1.PodDisruptionBudget), e.ObjectNew.(*policyv1.PodDisruptionBudget)
+				return oldPDB.Status.DisruptionsAllowed != newPDB.Status.DisruptionsAllowed ||
+					oldPDB.Status.ExpectedPods != newPDB.Status.ExpectedPods
+			},
+		})).
+		Watches(&corev1.Node{},
+			handler.EnqueueRequestsFromMapFunc(r.blockingPDBs),
+			builder.WithPredicates(cordonPredicate),
+		).
+		Named("poddisruptionbudget").
+		Complete(r)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ReasonPendingTimeout is the alert reason for pods that stayed Pending longer than the
+// configured timeout, for causes other than an unschedulable pod, which is FailedScheduling
+const ReasonPendingTimeout = "PendingTimeout"
+
+// pendingFor returns how long a pod has been Pending, or false if it is not Pending or pending
+// timeouts are disabled. Deleted pods are excluded; they are Pending while they terminate.
+func (r *PodReconciler) pendingFor(pod *corev1.Pod, now time.Time) (time.Duration, bool) {
+	if !r.Config.Pending.Enabled || pod.Status.Phase != corev1.PodPending || pod.DeletionTimestamp != nil {
+		return 0, false
+	}
+	return now.Sub(pod.CreationTimestamp.Time), true
+}
+
+// pendingTimedOut reports whether a pod has been Pending longer than the timeout
+func (r *PodReconciler) pendingTimedOut(pod *corev1.Pod, now time.Time) bool {
+	pending, ok := r.pendingFor(pod, now)
+	return ok && pending >= r.Config.Pending.Timeout.Duration
+}
+
+// pendingRecheck returns when a Pending pod reaches the timeout, or false if it is not Pending
+// or has already reached it. Pods that merely wait raise no events, so the reconciler has to
+// come back on its own.
+func (r *PodReconciler) pendingRecheck(pod *corev1.Pod, now time.Time) (time.Duration, bool) {
+	pending, ok := r.pendingFor(pod, now)
+	if !ok || pending >= r.Config.Pending.Timeout.Duration {
+		return 0, false
+	}
+	return r.Config.Pending.Timeout.Duration - pending, true
+}
+
+// describePending explains what a Pending pod is waiting on, from its scheduling, its
+// containers' waiting reasons and its latest warning event, e.g. a volume that fails to attach
+func (r *PodReconciler) describePending(ctx context.Context, pod *corev1.Pod, now time.Time) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "⏳ Pending for %s", formatReminderAge(now.Sub(pod.CreationTimestamp.Time)))
+	if pod.Spec.NodeName != "" {
+		fmt.Fprintf(&b, ", scheduled on node `%s`", pod.Spec.NodeName)
+	} else {
+		b.WriteString(", not yet scheduled")
+	}
+	b.WriteString(".")
+
+	var waiting []string
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+			waiting = append(waiting, fmt.Sprintf("`%s` %s", status.Name, status.State.Waiting.Reason))
+		}
+	}
+	if len(waiting) > 0 {
+		fmt.Fprintf(&b, "\nWaiting containers: %s", strings.Join(waiting, ", "))
+	}
+
+	events, err := r.podEvents(ctx, pod, pod.CreationTimestamp.Time)
+	if err != nil {
+		return b.String(), err
+	}
+	for i := range events {
+		if events[i].Type == corev1.EventTypeWarning {
+			fmt.Fprintf(&b, "\nLatest warning: %s: %s", events[i].Reason, strings.TrimSpace(events[i].Message))
+			break
+		}
+	}
+	return b.String(), nil
+}
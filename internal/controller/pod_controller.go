@@ -19,28 +19,89 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/slack"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/alertkey"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/store"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
 )
 
+// LogLinker creates links to the logs of the container an alert is about
+type LogLinker interface {
+	URL(alert notify.PodAlert) (string, error)
+}
+
+// ExternalSilences reports silences kept outside the operator, such as Alertmanager's
+type ExternalSilences interface {
+	// Silenced returns the ID of a silence matching the pod alert, or an empty string
+	Silenced(namespace, pod, reason string, podLabels map[string]string) string
+}
+
 // PodReconciler reconciles a Pod object
 type PodReconciler struct {
 	client.Client
-	Scheme         *runtime.Scheme
-	SlackNotifier  *slack.Notifier
-	alertCache     map[string]time.Time
-	alertCacheMux  sync.RWMutex
-	debounceWindow time.Duration
+	Scheme   *runtime.Scheme
+	Notifier notify.Notifier
+	Store    store.Store
+	Config   *config.Config
+	// LogLinks signs links to container logs for alerts, when enabled
+	LogLinks LogLinker
+	// Costs looks up workload costs for critical alerts, when cost context is enabled
+	Costs CostEstimator
+	// Issues finds open issues tracking a failure, when GitHub issue lookups are enabled
+	Issues IssueTracker
+	// Clientset reads container logs, when log tails are enabled
+	Clientset kubernetes.Interface
+	// Throttling reports heavily CPU throttled containers, when throttling sampling is enabled
+	Throttling *ThrottlingMonitor
+	// ExternalSilences suppresses alerts silenced in Alertmanager, when silence sync is enabled
+	ExternalSilences ExternalSilences
+	// OnCall resolves the on-call engineer mentioned in severe alerts, when on-call mentions
+	// are enabled
+	OnCall OnCallResolver
+	// Recorder reports suppressed alerts as events on their pod, if set
+	Recorder record.EventRecorder
+	// Decisions logs the outcome of every alert evaluation, if set
+	Decisions  logr.Logger
+	alertCache map[string]time.Time
+	// slid holds the keys whose sliding window restarted since the janitor last persisted them
+	slid          map[string]time.Time
+	openAlerts    map[string]notify.PodAlert
+	alertCacheMux sync.RWMutex
+	// restored delivers the pods of open alerts restored from the store, to resolve those that
+	// recovered during a failover
+	restored chan event.TypedGenericEvent[reconcile.Request]
+	// reminders tracks incidents that are still failing, for reminder alerts
+	reminders map[string]reminderState
+	// aggregating holds the start of the hold of each workload incident that is still
+	// collecting failing replicas
+	aggregating map[string]time.Time
+	// retries schedules re-examination of pods in event-driven detection mode
+	retries *timerWheel
+	// startup holds the pods that were failing when the operator started
+	startup startupSnapshot
 }
 
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
@@ -55,42 +116,632 @@ func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 	// Fetch the Pod instance
 	var pod corev1.Pod
 	if err := r.Get(ctx, req.NamespacedName, &pod); err != nil {
-		// Pod was deleted or doesn't exist, clean up cache entry
-		r.cleanupCacheEntry(req.NamespacedName.String())
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+
+		// Pod was deleted, its incidents can never recover on their own
+		podPrefix := r.podKeyPrefix(req.Namespace, req.Name)
+		if err := r.resolveAlerts(ctx, podPrefix); err != nil {
+			logger.Error(err, "Failed to resolve alerts for deleted pod", "pod", req.NamespacedName)
+			return r.retryLater(req.NamespacedName), nil
+		}
+
+		// The pod may have been the last failing replica of a workload incident
+		if err := r.resolveWorkloadAlerts(ctx, req.Namespace); err != nil {
+			logger.Error(err, "Failed to resolve workload alerts", "namespace", req.Namespace)
+			return r.retryLater(req.NamespacedName), nil
+		}
+
+		// Clean up cache entry and thread state
+		r.cleanupCacheEntry(ctx, podPrefix)
+		if forgetter, ok := r.Notifier.(notify.ThreadForgetter); ok {
+			forgetter.ForgetThreads(podPrefix)
+		}
+		if r.retries != nil {
+			r.retries.Cancel(req.NamespacedName)
+		}
+		return ctrl.Result{}, nil
 	}
 
+	r.snapshotPreExisting(ctx)
+
 	// Check if pod has failure conditions that should trigger alerts
 	shouldAlert, reason := r.shouldAlertForPod(&pod)
+
+	// Lifecycle hook failures look like generic crashes in the pod status, so classify
+	// them from the kubelet events instead
+	hook, err := r.findHookFailure(ctx, &pod)
+	if err != nil {
+		logger.Error(err, "Failed to look up lifecycle hook events",
+			"pod", pod.Name,
+			"namespace", pod.Namespace,
+		)
+	}
+	if hook != nil {
+		shouldAlert, reason = true, hook.reason
+	}
+
+	// SIGKILLs at the end of the grace period look like generic errors in the pod status
+	overrun := findShutdownOverrun(&pod)
+	if overrun != nil && hook == nil {
+		shouldAlert, reason = true, ReasonGracefulShutdownExceeded
+	}
+
+	// Liveness probes timing out under CPU throttling restart containers without leaving a
+	// failure in the pod status
+	throttled, err := r.findThrottledProbeFailure(ctx, &pod)
+	if err != nil {
+		logger.Error(err, "Failed to look up liveness probe events",
+			"pod", pod.Name,
+			"namespace", pod.Namespace,
+		)
+	}
+	if throttled != nil && !shouldAlert {
+		shouldAlert, reason = true, ReasonCPUThrottling
+	}
+
+	// Probe failures leave the pod Running; liveness restarts also explain a crash loop better
+	// than its status does
+	probe, probeWait, err := r.findProbeFailure(ctx, &pod, time.Now())
+	if err != nil {
+		logger.Error(err, "Failed to look up probe events",
+			"pod", pod.Name,
+			"namespace", pod.Namespace,
+		)
+	}
+	if probe != nil && (!shouldAlert ||
+		(probe.reason == ReasonLivenessProbeFailed && (reason == "CrashLoopBackOff" || reason == "Error"))) {
+		shouldAlert, reason = true, probe.reason
+	} else {
+		probe = nil
+	}
+
+	var trace alertTrace
+	switch {
+	case hook != nil:
+		trace.add("detected: %s from lifecycle hook events", reason)
+	case overrun != nil:
+		trace.add("detected: %s, SIGKILL after the grace period", reason)
+	case reason == ReasonCPUThrottling:
+		trace.add("detected: %s, liveness probe failed while throttled", reason)
+	case probe != nil:
+		trace.add("detected: %s from probe events of container %s", reason, probe.container)
+	case shouldAlert:
+		trace.add("detected: %s from pod status", reason)
+	}
+
 	if !shouldAlert {
+		r.logDecision(&pod, "", DecisionNotFailing, trace, "phase", string(pod.Status.Phase))
+		if isPodHealthy(&pod) {
+			r.forgetPreExisting(&pod)
+			if err := r.resolveAlerts(ctx, r.podKeyPrefix(pod.Namespace, pod.Name)); err != nil {
+				logger.Error(err, "Failed to resolve alerts for recovered pod",
+					"pod", pod.Name,
+					"namespace", pod.Namespace,
+				)
+				return r.retryLater(req.NamespacedName), nil
+			}
+			if err := r.resolveWorkloadAlerts(ctx, pod.Namespace); err != nil {
+				logger.Error(err, "Failed to resolve workload alerts", "namespace", pod.Namespace)
+				return r.retryLater(req.NamespacedName), nil
+			}
+		}
+		if wait, pending := r.pendingRecheck(&pod, time.Now()); pending {
+			return r.recheckAfter(req.NamespacedName, wait), nil
+		}
+		if wait, terminating := r.terminatingRecheck(&pod, time.Now()); terminating {
+			return r.recheckAfter(req.NamespacedName, wait), nil
+		}
+		if probeWait > 0 {
+			return r.recheckAfter(req.NamespacedName, probeWait), nil
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Jobs retry failed pods up to their backoffLimit; the Job's own failure is alerted instead
+	if r.Config.Jobs.Suppress && isJobRetryReason(reason) {
+		job, err := r.podJob(ctx, &pod)
+		if err != nil {
+			logger.Error(err, "Failed to look up the pod's Job, alerting anyway",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+			)
+		}
+		if job != nil {
+			logger.V(1).Info("Skipping alert for failed attempt of Job pod",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+				"reason", reason,
+				"job", job.Name,
+			)
+			r.suppressed(&pod, trace, notify.SuppressedJobRetry, reason, "failed attempt of Job "+job.Name)
+			return ctrl.Result{}, nil
+		}
+	}
+
+	// Pods stopped by a deliberate scale-down often exit with errors; those are not failures
+	if isTerminationReason(reason) {
+		scaledDown, why, err := r.intentionalScaleDown(ctx, &pod)
+		if err != nil {
+			logger.Error(err, "Failed to check for intentional scale-down, alerting anyway",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+			)
+		}
+		if scaledDown {
+			logger.V(1).Info("Skipping alert for pod of intentionally scaled down workload",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+				"reason", reason,
+				"scaleDown", why,
+			)
+			r.suppressed(&pod, trace, notify.SuppressedScaleDown, reason, why)
+			return ctrl.Result{}, nil
+		}
+		trace.add("scale-down: not an intentional scale-down")
+	}
+
+	// Failures on an interrupted spot node are capacity churn, alerted once per node
+	spot, err := r.findSpotInterruption(ctx, &pod)
+	if err != nil {
+		logger.Error(err, "Failed to check for spot interruption, alerting anyway",
+			"pod", pod.Name,
+			"namespace", pod.Namespace,
+		)
+	}
+	if spot != nil {
+		if r.Config.Spot.Suppress {
+			logger.V(1).Info("Skipping alert for pod on interrupted spot node",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+				"reason", reason,
+				"node", spot.node,
+			)
+			r.suppressed(&pod, trace, notify.SuppressedSpot, reason, "interruption of spot node "+spot.node)
+			return ctrl.Result{}, nil
+		}
+		trace.add("spot: node %s interrupted, %s classified as %s", spot.node, reason, ReasonSpotInterruption)
+		reason = ReasonSpotInterruption
+	}
+
+	// Draining a cordoned node stops its pods, often with errors; that is maintenance, alerted
+	// once per node
+	var drainedFrom string
+	if spot == nil {
+		drainedFrom, err = r.findDrain(ctx, &pod, reason)
+		if err != nil {
+			logger.Error(err, "Failed to check for a node drain, alerting anyway",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+			)
+		}
+	}
+	if drainedFrom != "" {
+		if r.Config.Drain.Suppress {
+			logger.V(1).Info("Skipping alert for pod stopped by a node drain",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+				"reason", reason,
+				"node", drainedFrom,
+			)
+			r.suppressed(&pod, trace, notify.SuppressedDrain, reason, "drain of node "+drainedFrom)
+			return ctrl.Result{}, nil
+		}
+		trace.add("drain: node %s cordoned, %s classified as %s", drainedFrom, reason, ReasonNodeDrain)
+		reason = ReasonNodeDrain
+	}
+
+	// Evictions hit many pods of a node at once, so they are alerted once per node
+	var evictedFrom string
+	if spot == nil && drainedFrom == "" && reason == ReasonEvicted && pod.Spec.NodeName != "" {
+		evictedFrom = pod.Spec.NodeName
+		trace.add("eviction: aggregated per node %s", evictedFrom)
+	}
+
+	// Workloads marked as allowed to fail, such as CI runners, are counted but not alerted on
+	expectedBy, err := r.expectedFailures(ctx, &pod)
+	if err != nil {
+		logger.Error(err, "Failed to check for expected failures, alerting anyway",
+			"pod", pod.Name,
+			"namespace", pod.Namespace,
+		)
+	}
+	if expectedBy != "" {
+		logger.V(1).Info("Skipping alert for pod whose failures are expected",
+			"pod", pod.Name,
+			"namespace", pod.Namespace,
+			"reason", reason,
+			"annotatedOn", expectedBy,
+		)
+		r.suppressed(&pod, trace, notify.SuppressedExpected, reason, "failures expected by annotation on "+expectedBy)
+		return ctrl.Result{}, nil
+	}
+
+	// Failures induced by a chaos experiment are expected
+	chaosExperiment, err := r.activeChaosExperiment(ctx, &pod)
+	if err != nil {
+		logger.Error(err, "Failed to check for chaos experiments, alerting anyway",
+			"pod", pod.Name,
+			"namespace", pod.Namespace,
+		)
+	}
+	if chaosExperiment != "" && r.Config.Chaos.Suppress {
+		logger.V(1).Info("Skipping alert for pod targeted by a chaos experiment",
+			"pod", pod.Name,
+			"namespace", pod.Namespace,
+			"reason", reason,
+			"experiment", chaosExperiment,
+		)
+		r.suppressed(&pod, trace, notify.SuppressedChaos, reason, "chaos experiment "+chaosExperiment)
+		return ctrl.Result{}, nil
+	}
+	if chaosExperiment != "" {
+		trace.add("chaos: experiment %s running, marked as expected", chaosExperiment)
+	}
+
+	// Check silences - skip alerts explicitly muted by an operator
+	if r.isSilenced(ctx, &pod, reason) {
+		logger.V(1).Info("Skipping alert due to active silence",
+			"pod", pod.Name,
+			"namespace", pod.Namespace,
+			"reason", reason,
+		)
+		r.suppressed(&pod, trace, notify.SuppressedSilence, reason, "")
+		return ctrl.Result{}, nil
+	}
+	trace.add("silences: no active silence matched")
+
+	// Pods already failing when the operator started were alerted on before the restart
+	if r.isPreExisting(&pod) {
+		logger.V(1).Info("Skipping alert for pod failing since before startup",
+			"pod", pod.Name,
+			"namespace", pod.Namespace,
+			"reason", reason,
+		)
+		r.suppressed(&pod, trace, notify.SuppressedStartup, reason, "failing since before the operator started")
 		return ctrl.Result{}, nil
 	}
 
-	// Check debouncing - avoid duplicate alerts for the same pod failure
-	alertKey := fmt.Sprintf("%s/%s-%s", pod.Namespace, pod.Name, reason)
-	if r.isRecentlyAlerted(alertKey) {
+	// Check debouncing - avoid duplicate alerts for the same pod failure, or for the same
+	// workload failure when replicas are deduplicated together
+	key := alertkey.Key{Cluster: r.Config.Cluster, Namespace: pod.Namespace, OwnerKind: alertkey.KindPod, OwnerName: pod.Name, Reason: reason}
+	var workload *metav1.PartialObjectMetadata
+	if spot != nil {
+		key.Namespace, key.OwnerKind, key.OwnerName = "", alertkey.KindNode, spot.node
+	} else if evictedFrom != "" {
+		key.Namespace, key.OwnerKind, key.OwnerName = "", alertkey.KindNode, evictedFrom
+	} else if drainedFrom != "" {
+		key.Namespace, key.OwnerKind, key.OwnerName = "", alertkey.KindNode, drainedFrom
+	} else if r.Config.Debounce.Scope == config.DebounceScopeWorkload {
+		owner, err := r.resolveOwner(ctx, &pod)
+		if err != nil {
+			logger.Error(err, "Failed to resolve owning workload, deduplicating per pod",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+			)
+		} else if owner != nil {
+			workload = owner
+			key.OwnerKind, key.OwnerName = owner.Kind, owner.Name
+			trace.add("debounce: deduplicated per %s %s", owner.Kind, owner.Name)
+		}
+	}
+	alertKey := key.String()
+	if r.isRecentlyAlerted(ctx, alertKey, reason) {
 		logger.V(1).Info("Skipping alert due to debouncing",
 			"pod", pod.Name,
 			"namespace", pod.Namespace,
 			"reason", reason,
 		)
+		r.suppressed(&pod, trace, notify.SuppressedDebounce, reason, "")
 		return ctrl.Result{}, nil
 	}
+	trace.add("debounce: no alert within %s", r.Config.Debounce.WindowFor(reason))
+
+	// Pods that keep failing past the debounce window get reminders on an escalating schedule
+	// rather than a repeat alert every window
+	var reminder string
+	if r.remindersApply(reason) {
+		var wait time.Duration
+		reminder, wait = r.reminderDue(alertKey, time.Now())
+		if wait > 0 {
+			logger.V(1).Info("Skipping repeat alert until the next reminder",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+				"reason", reason,
+				"next", wait,
+			)
+			r.suppressed(&pod, trace, notify.SuppressedDebounce, reason, "waiting for the next reminder")
+			return r.recheckAfter(req.NamespacedName, wait), nil
+		}
+		if reminder != "" {
+			trace.add("reminder: still failing after %s", reminder)
+		}
+	}
+
+	// The first alert of a workload incident, spot interruption or node's evictions waits for
+	// replicas failing at about the same time
+	if (workload != nil || spot != nil || evictedFrom != "" || drainedFrom != "") && reminder == "" {
+		if wait := r.aggregationWait(alertKey, time.Now()); wait > 0 {
+			logger.V(1).Info("Holding workload alert to aggregate failing replicas",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+				"reason", reason,
+				"wait", wait,
+			)
+			r.logDecision(&pod, reason, DecisionHeld, trace, "wait", wait.String())
+			return r.recheckAfter(req.NamespacedName, wait), nil
+		}
+	}
 
 	// Create and send alert
-	alert := slack.CreatePodAlertFromPod(&pod)
+	alert := notify.CreatePodAlertFromPod(&pod)
 	if alert != nil {
-		if err := r.SlackNotifier.SendPodAlert(*alert); err != nil {
-			logger.Error(err, "Failed to send Slack alert",
+		budget := r.newEnrichmentBudget()
+		statusContainer := alert.ContainerName
+		if spot != nil {
+			alert.Reason = ReasonSpotInterruption
+			if description, err := r.describeSpotInterruption(ctx, spot); err != nil {
+				logger.Error(err, "Failed to list pods on interrupted node", "node", spot.node)
+			} else {
+				alert.Message = budget.fit(sourceDescribe, description)
+			}
+		} else if drainedFrom != "" {
+			alert.Reason = ReasonNodeDrain
+			alert.ExitCode, alert.Signal = nil, 0
+			if description, err := r.describeDrain(ctx, drainedFrom); err != nil {
+				logger.Error(err, "Failed to list pods on drained node", "node", drainedFrom)
+			} else {
+				alert.Message = budget.fit(sourceDescribe, description)
+			}
+		} else if reason == ReasonEvicted {
+			alert.Reason = ReasonEvicted
+			alert.ExitCode, alert.Signal = nil, 0
+			cause, _ := evictionCause(&pod)
+			alert.Message = budget.fit(sourceDescribe, cause)
+			if evictedFrom != "" {
+				if description, err := r.describeEviction(ctx, evictedFrom); err != nil {
+					logger.Error(err, "Failed to list pods evicted from node", "node", evictedFrom)
+				} else {
+					alert.Message = budget.fit(sourceDescribe, description)
+				}
+			}
+		} else if hook != nil {
+			alert.Reason = hook.reason
+			alert.Message = budget.fit(sourceEvents, hook.describe())
+			if hook.container != "" {
+				alert.ContainerName = hook.container
+				alert.Image = containerImage(&pod, hook.container)
+			}
+		} else if overrun != nil {
+			alert.Reason = ReasonGracefulShutdownExceeded
+			alert.ContainerName = overrun.container
+			alert.Image = containerImage(&pod, overrun.container)
+			alert.Message = budget.fit(sourceDescribe, overrun.describe())
+		} else if reason == ReasonCPUThrottling {
+			alert.Reason = ReasonCPUThrottling
+			alert.ContainerName = throttled.container
+			alert.Image = containerImage(&pod, throttled.container)
+			alert.RestartCount = containerRestarts(&pod, throttled.container)
+			alert.Message = budget.fit(sourceDescribe, throttled.describe(time.Now()))
+		} else if probe != nil {
+			alert.Reason = probe.reason
+			alert.ContainerName = probe.container
+			alert.Image = containerImage(&pod, probe.container)
+			alert.RestartCount = containerRestarts(&pod, probe.container)
+			alert.Message = budget.fit(sourceEvents, probe.describe(r.Config.Probes.Window.Duration, time.Now()))
+		} else if reason == ReasonStuckTerminating {
+			alert.Reason = ReasonStuckTerminating
+			description, err := r.describeStuckTerminating(ctx, &pod, time.Now())
+			if err != nil {
+				logger.Error(err, "Failed to look up events of terminating pod", "pod", pod.Name, "namespace", pod.Namespace)
+			}
+			alert.Message = budget.fit(sourceDescribe, description)
+			alert.ExitCode, alert.Signal = nil, 0
+		} else if reason == ReasonPendingTimeout {
+			alert.Reason = ReasonPendingTimeout
+			description, err := r.describePending(ctx, &pod, time.Now())
+			if err != nil {
+				logger.Error(err, "Failed to look up events of pending pod", "pod", pod.Name, "namespace", pod.Namespace)
+			}
+			alert.Message = budget.fit(sourceDescribe, description)
+		} else if isImagePullReason(alert.Reason) {
+			diagnosis, err := r.describeImagePull(ctx, &pod, alert.ContainerName, alert.Message, time.Now())
+			if err != nil {
+				logger.Error(err, "Failed to look up image pull events", "pod", pod.Name, "namespace", pod.Namespace)
+			}
+			alert.Message = budget.fit(sourceDescribe, strings.TrimSpace(alert.Message+"\n\n"+diagnosis))
+		} else if alert.Reason == ReasonCreateContainerConfigError {
+			alert.Message = budget.fit(sourceDescribe, strings.TrimSpace(
+				describeConfigError(&pod, alert.ContainerName, alert.Message)+"\n\n"+alert.Message))
+		} else {
+			// Termination messages fall back to the log tail with FallbackToLogsOnError
+			alert.Message = budget.fit(sourceLogs, alert.Message)
+		}
+		if alert.ContainerName != statusContainer {
+			// The exit code belongs to the container the status reported
+			alert.ExitCode, alert.Signal = nil, 0
+		}
+		if throttled != nil && alert.Reason != ReasonCPUThrottling {
+			// Throttling may explain a failure detected from the status, e.g. a crash loop
+			alert.Message = strings.TrimSpace(alert.Message + "\n\n" + budget.fit(sourceDescribe, throttled.describe(time.Now())))
+		}
+
+		if err := r.attachWorkload(ctx, &pod, alert); err != nil {
+			logger.Error(err, "Failed to resolve owning workload", "pod", pod.Name, "namespace", pod.Namespace)
+		}
+		if workload != nil {
+			if summary, err := r.workloadSummary(ctx, &pod, workload, alert.Reason); err != nil {
+				logger.Error(err, "Failed to count failing replicas", "pod", pod.Name, "namespace", pod.Namespace)
+			} else {
+				alert.Message = strings.TrimSpace(summary + "\n\n" + alert.Message)
+			}
+		}
+		if err := r.attachNodeContext(ctx, &pod, alert); err != nil {
+			logger.Error(err, "Failed to look up node", "pod", pod.Name, "node", pod.Spec.NodeName)
+		}
+		if r.Config.Enrichment.RecentEvents > 0 {
+			if events, err := r.recentEvents(ctx, &pod, time.Now()); err != nil {
+				logger.Error(err, "Failed to look up pod events", "pod", pod.Name, "namespace", pod.Namespace)
+			} else if events != "" {
+				alert.Message = strings.TrimSpace(alert.Message + "\n\n" + budget.fit(sourceEvents, events))
+			}
+		}
+		if r.Config.Enrichment.LogTailLines > 0 && r.Clientset != nil && alert.ContainerName != "" {
+			if logs, err := r.logTail(ctx, &pod, alert.ContainerName, budget); err != nil {
+				logger.Error(err, "Failed to fetch container logs", "pod", pod.Name, "namespace", pod.Namespace)
+			} else if logs != "" {
+				alert.Message = strings.TrimSpace(alert.Message + "\n\n" + logs)
+			}
+		}
+
+		if chaosExperiment != "" {
+			alert.Message = strings.TrimSpace(fmt.Sprintf("🧪 Expected: chaos experiment %s running\n\n%s", chaosExperiment, alert.Message))
+		}
+		if reminder != "" {
+			alert.Message = strings.TrimSpace(fmt.Sprintf("⏰ Still failing after %s\n\n%s", reminder, alert.Message))
+		}
+
+		if alert.ContainerName != "" && oomKilled(&pod, alert.ContainerName, alert.Reason) {
+			if memory, err := r.memoryContext(ctx, &pod, alert.ContainerName); err != nil {
+				logger.Error(err, "Failed to look up memory usage", "pod", pod.Name, "namespace", pod.Namespace)
+			} else {
+				alert.Message = strings.TrimSpace(alert.Message + "\n\n" + budget.fit(sourceDescribe, memory))
+			}
+		}
+
+		if sizingReason(alert.Reason) {
+			if recommendation, err := r.vpaRecommendation(ctx, &pod, alert.ContainerName); err != nil {
+				logger.Error(err, "Failed to look up VPA recommendation", "pod", pod.Name, "namespace", pod.Namespace)
+			} else if recommendation != "" {
+				alert.Message = strings.TrimSpace(alert.Message + "\n\n" + budget.fit(sourceDescribe, recommendation))
+			}
+		}
+
+		if r.Costs != nil && alert.AlertSeverity() == notify.SeverityCritical {
+			if cost, err := r.costContext(ctx, &pod); err != nil {
+				logger.Error(err, "Failed to look up workload cost", "pod", pod.Name, "namespace", pod.Namespace)
+			} else if cost != "" {
+				alert.Message = strings.TrimSpace(alert.Message + "\n\n" + budget.fit(sourceDescribe, cost))
+			}
+		}
+
+		if r.Issues != nil {
+			if err := r.attachKnownIssue(ctx, &pod, alert); err != nil {
+				logger.Error(err, "Failed to look up known issue", "pod", pod.Name, "namespace", pod.Namespace)
+			}
+			if alert.KnownIssue != nil {
+				trace.add("known issue: #%d %s", alert.KnownIssue.Number, alert.KnownIssue.URL)
+				if r.Config.GitHub.KnownIssues == config.KnownIssueSuppress {
+					logger.V(1).Info("Skipping alert for failure tracked in a known issue",
+						"pod", pod.Name,
+						"namespace", pod.Namespace,
+						"reason", reason,
+						"issue", alert.KnownIssue.URL,
+					)
+					r.suppressed(&pod, trace, notify.SuppressedKnownIssue, reason, "tracked in "+alert.KnownIssue.URL)
+					// Debounce, so the issue is not looked up on every reconcile
+					r.recordAlert(ctx, alertKey)
+					return ctrl.Result{}, nil
+				}
+			}
+		}
+
+		if err := r.routeAlert(ctx, &pod, alert, &trace); err != nil {
+			// Fall back to the default destination rather than dropping the alert
+			logger.Error(err, "Failed to resolve channel override, using default",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+			)
+		}
+		if err := r.mentionAlert(ctx, &pod, alert, &trace); err != nil {
+			logger.Error(err, "Failed to resolve mention annotation",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+			)
+		}
+		if err := r.mentionOnCall(ctx, &pod, alert, &trace); err != nil {
+			logger.Error(err, "Failed to look up on-call engineer",
 				"pod", pod.Name,
 				"namespace", pod.Namespace,
 			)
-			// Requeue to retry later
-			return ctrl.Result{RequeueAfter: time.Minute * 5}, err
+		}
+		alert.Key = alertKey
+		alert.Cluster = r.Config.Cluster
+		logger.V(1).Info("Routed alert",
+			"pod", pod.Name,
+			"namespace", pod.Namespace,
+			"trace", []string(trace),
+		)
+		if r.Config.Routing.Trace {
+			alert.Trace = trace
 		}
 
+		if r.LogLinks != nil {
+			if logURL, err := r.LogLinks.URL(*alert); err != nil {
+				logger.Error(err, "Failed to sign log link", "pod", pod.Name, "namespace", pod.Namespace)
+			} else {
+				alert.LogURL = logURL
+			}
+		}
+		if len(r.Config.Dashboards) > 0 {
+			if links, err := r.dashboardLinks(alert); err != nil {
+				logger.Error(err, "Failed to render dashboard links", "pod", pod.Name, "namespace", pod.Namespace)
+			} else {
+				alert.Links = links
+			}
+		}
+
+		if err := r.Notifier.SendPodAlert(*alert); err != nil {
+			r.logDecision(&pod, reason, DecisionSendFailed, trace, "key", alertKey, "error", err.Error())
+			logger.Error(err, "Failed to send alert",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+			)
+			// Retry later; in event-driven mode the timer wheel retries instead of the
+			// controller's rate limited requeue
+			if r.retries != nil {
+				return r.retryLater(req.NamespacedName), nil
+			}
+			return r.retryLater(req.NamespacedName), err
+		}
+
+		r.logDecision(&pod, reason, DecisionSent, trace,
+			"key", alertKey,
+			"channel", channelName(alert.Channel),
+			"severity", string(alert.AlertSeverity()),
+			"team", alert.Team,
+		)
+
 		// Record alert in cache to prevent duplicates
-		r.recordAlert(alertKey)
+		r.recordAlert(ctx, alertKey)
+		if spot == nil && evictedFrom == "" && drainedFrom == "" {
+			// Interrupted nodes do not come back, and evicted or drained pods do not recover, so
+			// there is nothing to resolve
+			r.trackOpenAlert(ctx, *alert)
+		}
+		if workload != nil || spot != nil || evictedFrom != "" || drainedFrom != "" {
+			r.aggregationDone(alertKey)
+		}
+		if reminder != "" {
+			r.reminderSent(alertKey)
+		} else if r.remindersApply(reason) {
+			r.startIncident(alertKey, alert.Timestamp)
+		}
+
+		if err := r.Store.AppendHistory(ctx, store.HistoryEntry{
+			Key:       alertKey,
+			Namespace: pod.Namespace,
+			Pod:       pod.Name,
+			Reason:    reason,
+			Channel:   alert.Channel,
+			Timestamp: alert.Timestamp,
+		}); err != nil {
+			logger.Error(err, "Failed to record alert history",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+			)
+		}
 
 		logger.Info("Sent pod failure alert",
 			"pod", pod.Name,
@@ -105,6 +756,16 @@ func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 
 // shouldAlertForPod determines if a pod should trigger an alert based on its status
 func (r *PodReconciler) shouldAlertForPod(pod *corev1.Pod) (bool, string) {
+	// A pod that cannot finish terminating is stuck whatever its containers report
+	if r.stuckTerminating(pod, time.Now()) {
+		return true, ReasonStuckTerminating
+	}
+
+	// Evictions are failures of the node rather than the pod
+	if _, evicted := evictionCause(pod); evicted {
+		return true, ReasonEvicted
+	}
+
 	// Check pod phase
 	if pod.Status.Phase == corev1.PodFailed {
 		return true, string(pod.Status.Phase)
@@ -115,7 +776,8 @@ func (r *PodReconciler) shouldAlertForPod(pod *corev1.Pod) (bool, string) {
 		if containerStatus.State.Waiting != nil {
 			reason := containerStatus.State.Waiting.Reason
 			switch reason {
-			case "CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "InvalidImageName", "ImageInspectError":
+			case "CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "InvalidImageName", "ImageInspectError",
+				ReasonCreateContainerConfigError, ReasonCreateContainerError:
 				return true, reason
 			}
 		}
@@ -141,7 +803,8 @@ func (r *PodReconciler) shouldAlertForPod(pod *corev1.Pod) (bool, string) {
 		if containerStatus.State.Waiting != nil {
 			reason := containerStatus.State.Waiting.Reason
 			switch reason {
-			case "CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull":
+			case "CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull",
+				ReasonCreateContainerConfigError, ReasonCreateContainerError:
 				return true, fmt.Sprintf("InitContainer-%s", reason)
 			}
 		}
@@ -156,52 +819,175 @@ func (r *PodReconciler) shouldAlertForPod(pod *corev1.Pod) (bool, string) {
 		}
 	}
 
+	if r.pendingTimedOut(pod, time.Now()) {
+		return true, ReasonPendingTimeout
+	}
+
 	return false, ""
 }
 
-// isRecentlyAlerted checks if we've recently sent an alert for this pod/reason combination
-func (r *PodReconciler) isRecentlyAlerted(alertKey string) bool {
-	r.alertCacheMux.RLock()
-	defer r.alertCacheMux.RUnlock()
+// isSilenced checks whether an active silence in the store or in Alertmanager matches this
+// pod/reason combination
+func (r *PodReconciler) isSilenced(ctx context.Context, pod *corev1.Pod, reason string) bool {
+	if r.ExternalSilences != nil {
+		if id := r.ExternalSilences.Silenced(pod.Namespace, pod.Name, reason, pod.Labels); id != "" {
+			logf.FromContext(ctx).V(1).Info("Alert matches Alertmanager silence", "pod", pod.Name, "silence", id)
+			return true
+		}
+	}
+
+	silences, err := r.Store.Silences(ctx)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to read silences, alerting anyway")
+		return false
+	}
+
+	now := time.Now()
+	for _, silence := range silences {
+		if silence.Active(now) && silence.Matches(pod.Namespace, pod.Name, reason, pod.Labels) {
+			return true
+		}
+	}
+	return false
+}
 
+// isRecentlyAlerted checks if we've recently sent an alert for this pod/reason combination.
+// Keys missing from the in-memory cache are looked up in the state store, so alerts sent
+// before an operator restart are still debounced with a persistent store backend. With the
+// sliding strategy, a suppressed repeat restarts the window.
+func (r *PodReconciler) isRecentlyAlerted(ctx context.Context, alertKey, reason string) bool {
+	window := r.Config.Debounce.WindowFor(reason)
+
+	r.alertCacheMux.RLock()
 	lastAlert, exists := r.alertCache[alertKey]
-	if !exists {
+	r.alertCacheMux.RUnlock()
+
+	if !exists && r.Store != nil {
+		at, found, err := r.Store.LastAlert(ctx, alertKey)
+		if err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to read alert state, debouncing from memory only", "key", alertKey)
+			return false
+		}
+		if !found || time.Since(at) >= window {
+			return false
+		}
+
+		r.alertCacheMux.Lock()
+		if _, exists := r.alertCache[alertKey]; !exists {
+			r.alertCache[alertKey] = at
+			r.evictOldestAlertsLocked()
+			alertCacheSize.Set(float64(len(r.alertCache)))
+		}
+		r.alertCacheMux.Unlock()
+		lastAlert = at
+	}
+
+	if time.Since(lastAlert) >= window {
 		return false
 	}
+	if r.Config.Debounce.Strategy == config.DebounceSliding {
+		r.slideAlert(alertKey)
+	}
+	return true
+}
+
+// slideAlert restarts the sliding window of a suppressed repeat. Repeats are frequent, so the
+// new time is kept in memory and persisted by the cache janitor rather than on every reconcile.
+func (r *PodReconciler) slideAlert(alertKey string) {
+	now := time.Now()
+
+	r.alertCacheMux.Lock()
+	defer r.alertCacheMux.Unlock()
 
-	return time.Since(lastAlert) < r.debounceWindow
+	r.alertCache[alertKey] = now
+	r.slid[alertKey] = now
+	r.evictOldestAlertsLocked()
+	alertCacheSize.Set(float64(len(r.alertCache)))
 }
 
 // recordAlert records that we've sent an alert for this pod/reason combination
-func (r *PodReconciler) recordAlert(alertKey string) {
+func (r *PodReconciler) recordAlert(ctx context.Context, alertKey string) {
+	now := time.Now()
+
 	r.alertCacheMux.Lock()
-	defer r.alertCacheMux.Unlock()
+	r.alertCache[alertKey] = now
+	r.evictOldestAlertsLocked()
+	alertCacheSize.Set(float64(len(r.alertCache)))
+	r.alertCacheMux.Unlock()
 
-	r.alertCache[alertKey] = time.Now()
+	if r.Store != nil {
+		if err := r.Store.RecordAlert(ctx, alertKey, now); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to persist alert state", "key", alertKey)
+		}
+	}
+}
+
+// podKeyPrefix returns the prefix of the alert keys of a pod
+func (r *PodReconciler) podKeyPrefix(namespace, name string) string {
+	return alertkey.OwnerPrefix(r.Config.Cluster, namespace, alertkey.KindPod, name)
 }
 
 // cleanupCacheEntry removes cache entries for deleted pods
-func (r *PodReconciler) cleanupCacheEntry(podKey string) {
+func (r *PodReconciler) cleanupCacheEntry(ctx context.Context, podPrefix string) {
+	if r.Store != nil {
+		if err := r.Store.ForgetAlerts(ctx, podPrefix); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to forget persisted alert state", "prefix", podPrefix)
+		}
+	}
+
 	r.alertCacheMux.Lock()
 	defer r.alertCacheMux.Unlock()
 
-	// Remove any cache entries that start with this pod key
+	// Remove any cache entries that start with this pod's prefix
 	for key := range r.alertCache {
-		if len(key) > len(podKey) && key[:len(podKey)] == podKey {
+		if strings.HasPrefix(key, podPrefix) {
 			delete(r.alertCache, key)
 		}
 	}
+	for key := range r.slid {
+		if strings.HasPrefix(key, podPrefix) {
+			delete(r.slid, key)
+		}
+	}
+	alertCacheSize.Set(float64(len(r.alertCache)))
 }
 
 // NewPodReconciler creates a new PodReconciler with proper initialization
-func NewPodReconciler(client client.Client, scheme *runtime.Scheme, notifier *slack.Notifier) *PodReconciler {
-	return &PodReconciler{
-		Client:         client,
-		Scheme:         scheme,
-		SlackNotifier:  notifier,
-		alertCache:     make(map[string]time.Time),
-		debounceWindow: 10 * time.Minute, // Configurable debounce window
+func NewPodReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	notifier notify.Notifier,
+	stateStore store.Store,
+	cfg *config.Config,
+) *PodReconciler {
+	r := &PodReconciler{
+		Client:      client,
+		Scheme:      scheme,
+		Notifier:    notifier,
+		Store:       stateStore,
+		Config:      cfg,
+		alertCache:  make(map[string]time.Time),
+		slid:        make(map[string]time.Time),
+		openAlerts:  make(map[string]notify.PodAlert),
+		restored:    make(chan event.TypedGenericEvent[reconcile.Request]),
+		reminders:   make(map[string]reminderState),
+		aggregating: make(map[string]time.Time),
+	}
+	if cfg.Detection.Mode == config.DetectionEventDriven {
+		r.retries = newTimerWheel()
 	}
+	return r
+}
+
+// mapEventToPod enqueues the pod an event refers to
+func mapEventToPod(_ context.Context, obj client.Object) []reconcile.Request {
+	event := obj.(*corev1.Event)
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Namespace: event.InvolvedObject.Namespace,
+			Name:      event.InvolvedObject.Name,
+		},
+	}}
 }
 
 // SetupWithManager sets up the controller with the Manager with custom predicates
@@ -209,10 +995,13 @@ func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Create a predicate to filter events - only watch for status changes that might indicate failures
 	podPredicate := predicate.Funcs{
 		CreateFunc: func(e event.CreateEvent) bool {
-			// Alert on newly created pods that are already failing
+			// Alert on newly created pods that are already failing, and start the pending
+			// timeout of pods that are not running yet
 			pod := e.Object.(*corev1.Pod)
 			shouldAlert, _ := r.shouldAlertForPod(pod)
-			return shouldAlert
+			_, pending := r.pendingFor(pod, time.Now())
+			_, terminating := r.terminatingOverdue(pod, time.Now())
+			return shouldAlert || pending || terminating
 		},
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			oldPod := e.ObjectOld.(*corev1.Pod)
@@ -223,8 +1012,19 @@ func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				return false
 			}
 
-			// Check if the new state warrants an alert
+			// Start the stuck terminating threshold of deleted pods
+			if oldPod.DeletionTimestamp == nil {
+				if _, terminating := r.terminatingOverdue(newPod, time.Now()); terminating {
+					return true
+				}
+			}
+
+			// Check if the new state warrants an alert, or resolves an earlier one
 			shouldAlert, _ := r.shouldAlertForPod(newPod)
+			if !shouldAlert && isPodHealthy(newPod) {
+				return r.hasOpenAlerts(r.podKeyPrefix(newPod.Namespace, newPod.Name)) ||
+					r.hasOpenWorkloadAlerts(newPod.Namespace)
+			}
 			return shouldAlert
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
@@ -236,9 +1036,63 @@ func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		},
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&corev1.Pod{}).
-		WithEventFilter(podPredicate).
-		Named("pod").
-		Complete(r)
+	// Lifecycle hook and probe failures are only visible as events on the pod, so watch those too
+	hookEventPredicate := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		event := obj.(*corev1.Event)
+		return event.InvolvedObject.Kind == "Pod" &&
+			(isHookFailureReason(event.Reason) || (r.Config.Probes.Enabled && isProbeEvent(event)))
+	})
+
+	// Keep the debounce cache bounded for pods that fail repeatedly without being deleted
+	if err := mgr.Add(manager.RunnableFunc(r.runCacheJanitor)); err != nil {
+		return err
+	}
+
+	// Incidents opened by a previous leader are resolved by this one
+	if err := mgr.Add(manager.RunnableFunc(r.restoreOpenAlerts)); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Event{},
+		eventInvolvedObjectUIDField, indexEventsByInvolvedObject); err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Pod{}, builder.WithPredicates(podPredicate)).
+		Watches(&corev1.Event{},
+			handler.EnqueueRequestsFromMapFunc(mapEventToPod),
+			builder.WithPredicates(hookEventPredicate),
+		)
+
+	// In event-driven mode retries arrive from the timer wheel rather than the workqueue
+	if r.retries != nil {
+		if err := mgr.Add(r.retries); err != nil {
+			return err
+		}
+		b = b.WatchesRawSource(source.Channel(r.retries.Events(),
+			handler.TypedEnqueueRequestsFromMapFunc(func(_ context.Context, req reconcile.Request) []reconcile.Request {
+				return []reconcile.Request{req}
+			}),
+		))
+	}
+
+	b = b.WatchesRawSource(source.Channel(r.restored,
+		handler.TypedEnqueueRequestsFromMapFunc(func(_ context.Context, req reconcile.Request) []reconcile.Request {
+			return []reconcile.Request{req}
+		}),
+	))
+
+	if r.Throttling != nil {
+		if err := mgr.Add(r.Throttling); err != nil {
+			return err
+		}
+		b = b.WatchesRawSource(source.Channel(r.Throttling.Events(),
+			handler.TypedEnqueueRequestsFromMapFunc(func(_ context.Context, req reconcile.Request) []reconcile.Request {
+				return []reconcile.Request{req}
+			}),
+		))
+	}
+
+	return b.Named("pod").Complete(r)
 }
@@ -0,0 +1,275 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/store"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// The benchmarks simulate a cluster with a fake client. Their size is set with environment
+// variables, see `make bench`:
+//
+//	BENCH_PODS          pods in the simulated cluster (default 5000)
+//	BENCH_NAMESPACES    namespaces the pods are spread over (default 50)
+//	BENCH_FAILING       share of pods failing at the start, 0-1 (default 0.1)
+//	BENCH_CHURN         share of reconciles preceded by a pod changing state, 0-1 (default 0.05)
+
+// benchFailureReasons are the container waiting reasons failing pods cycle through
+var benchFailureReasons = []string{"CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "InvalidImageName"}
+
+// benchEnv reads a benchmark size from the environment
+func benchEnv[T int | float64](b *testing.B, name string, def T) T {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		b.Fatalf("%s: %v", name, err)
+	}
+	return T(parsed)
+}
+
+// recordingNotifier records when each pod alert arrives
+type recordingNotifier struct {
+	alerts chan time.Time
+}
+
+func (n *recordingNotifier) SendPodAlert(notify.PodAlert) error {
+	select {
+	case n.alerts <- time.Now():
+	default:
+	}
+	return nil
+}
+
+func (n *recordingNotifier) SendMetaAlert(notify.MetaAlert) error {
+	return nil
+}
+
+// benchPod returns a pod of the simulated cluster, failing with the given reason or running
+// and ready if the reason is empty
+func benchPod(namespace string, i int, reason string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("app-%d", i),
+			UID:       types.UID(fmt.Sprintf("%s-%d", namespace, i)),
+			Labels:    map[string]string{"app": fmt.Sprintf("app-%d", i%100)},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "registry.example.com/app:1.0"}}},
+	}
+	setBenchPodState(pod, reason)
+	return pod
+}
+
+// setBenchPodState makes a pod fail with the reason, or run and be ready if it is empty
+func setBenchPodState(pod *corev1.Pod, reason string) {
+	status := corev1.ContainerStatus{Name: "app", Image: pod.Spec.Containers[0].Image}
+	if reason == "" {
+		pod.Status.Phase = corev1.PodRunning
+		status.Ready = true
+		status.State.Running = &corev1.ContainerStateRunning{StartedAt: metav1.Now()}
+	} else {
+		pod.Status.Phase = corev1.PodPending
+		status.RestartCount = 3
+		status.State.Waiting = &corev1.ContainerStateWaiting{Reason: reason, Message: "simulated failure"}
+		status.LastTerminationState.Terminated = &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}
+	}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{status}
+}
+
+// benchCluster is a simulated cluster and a reconciler watching it
+type benchCluster struct {
+	reconciler *PodReconciler
+	client     client.Client
+	pods       []types.NamespacedName
+	notifier   *recordingNotifier
+}
+
+// newBenchCluster creates the simulated cluster. Alerts go through the asynchronous queue,
+// as in the operator, to a notifier recording their arrival.
+func newBenchCluster(b *testing.B, ctx context.Context) *benchCluster {
+	b.Helper()
+	podCount := benchEnv(b, "BENCH_PODS", 5000)
+	namespaces := max(benchEnv(b, "BENCH_NAMESPACES", 50), 1)
+	failing := benchEnv(b, "BENCH_FAILING", 0.1)
+
+	scheme := clientgoscheme.Scheme
+	rng := rand.New(rand.NewSource(1))
+	objects := make([]client.Object, 0, podCount+namespaces)
+	for i := 0; i < namespaces; i++ {
+		objects = append(objects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("team-%d", i)}})
+	}
+
+	cluster := &benchCluster{notifier: &recordingNotifier{alerts: make(chan time.Time, podCount)}}
+	for i := 0; i < podCount; i++ {
+		reason := ""
+		if rng.Float64() < failing {
+			reason = benchFailureReasons[rng.Intn(len(benchFailureReasons))]
+		}
+		pod := benchPod(fmt.Sprintf("team-%d", i%namespaces), i, reason)
+		objects = append(objects, pod)
+		cluster.pods = append(cluster.pods, client.ObjectKeyFromObject(pod))
+	}
+
+	cluster.client = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&corev1.Pod{}).
+		WithIndex(&corev1.Event{}, eventInvolvedObjectUIDField, indexEventsByInvolvedObject).
+		Build()
+
+	cfg, err := config.Parse([]byte("{}"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	stateStore, err := store.New(nil, nil, store.Options{Backend: store.BackendMemory})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	queue := notify.NewQueue(logr.Discard(), cluster.notifier, notify.QueueOptions{
+		Size:     podCount,
+		Workers:  4,
+		Overflow: notify.OverflowBlock,
+	})
+	go func() { _ = queue.Start(ctx) }()
+
+	cluster.reconciler = NewPodReconciler(cluster.client, scheme, queue, stateStore, cfg)
+	return cluster
+}
+
+// churn flips a random pod between failing and healthy
+func (c *benchCluster) churn(ctx context.Context, b *testing.B, rng *rand.Rand) {
+	var pod corev1.Pod
+	if err := c.client.Get(ctx, c.pods[rng.Intn(len(c.pods))], &pod); err != nil {
+		b.Fatal(err)
+	}
+	reason := ""
+	if isPodHealthy(&pod) {
+		reason = benchFailureReasons[rng.Intn(len(benchFailureReasons))]
+	}
+	setBenchPodState(&pod, reason)
+	if err := c.client.Status().Update(ctx, &pod); err != nil {
+		b.Fatal(err)
+	}
+}
+
+// heapInUse returns the bytes of live heap after a collection
+func heapInUse() uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapInuse
+}
+
+// BenchmarkReconcile measures reconcile throughput over a simulated cluster whose pods change
+// state at the configured churn rate. Besides ns/op it reports reconciles per second, the
+// share of reconciles that sent an alert and the reconciler's heap per pod after the run,
+// which covers the debounce cache and open alerts.
+func BenchmarkReconcile(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	before := heapInUse()
+	cluster := newBenchCluster(b, ctx)
+	churnRate := benchEnv(b, "BENCH_CHURN", 0.05)
+	rng := rand.New(rand.NewSource(2))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		if rng.Float64() < churnRate {
+			b.StopTimer()
+			cluster.churn(ctx, b, rng)
+			b.StartTimer()
+		}
+		key := cluster.pods[i%len(cluster.pods)]
+		if _, err := cluster.reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	elapsed := time.Since(start)
+	b.StopTimer()
+
+	b.ReportMetric(float64(b.N)/elapsed.Seconds(), "reconciles/s")
+	b.ReportMetric(float64(len(cluster.notifier.alerts))/float64(b.N), "alerts/op")
+	after := heapInUse()
+	b.ReportMetric(float64(after-min(before, after))/float64(len(cluster.pods)), "heap-B/pod")
+}
+
+// BenchmarkAlertLatency measures the time from a failing pod's reconcile to the alert reaching
+// the notifier through the send queue, for pods failing for the first time. It reports the
+// median and 99th percentile.
+func BenchmarkAlertLatency(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cluster := newBenchCluster(b, ctx)
+	namespaces := max(benchEnv(b, "BENCH_NAMESPACES", 50), 1)
+
+	latencies := make([]time.Duration, 0, b.N)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		// A new pod each time, so debouncing never applies
+		pod := benchPod(fmt.Sprintf("team-%d", i%namespaces), len(cluster.pods)+i, benchFailureReasons[i%len(benchFailureReasons)])
+		if err := cluster.client.Create(ctx, pod); err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+
+		start := time.Now()
+		if _, err := cluster.reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pod)}); err != nil {
+			b.Fatal(err)
+		}
+		select {
+		case received := <-cluster.notifier.alerts:
+			latencies = append(latencies, received.Sub(start))
+		case <-time.After(10 * time.Second):
+			b.Fatalf("no alert for pod %s", pod.Name)
+		}
+	}
+	b.StopTimer()
+
+	slices.Sort(latencies)
+	b.ReportMetric(float64(latencies[len(latencies)/2].Microseconds()), "p50-µs")
+	b.ReportMetric(float64(latencies[len(latencies)*99/100].Microseconds()), "p99-µs")
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Alert reasons for probe failures, which leave the pod Running and so do not show as a
+// failure in its phase
+const (
+	// ReasonLivenessProbeFailed is the alert reason for containers repeatedly restarted by their
+	// liveness probe
+	ReasonLivenessProbeFailed = "LivenessProbeFailed"
+	// ReasonReadinessProbeFailed is the alert reason for containers that stay NotReady because
+	// their readiness probe fails
+	ReasonReadinessProbeFailed = "ReadinessProbeFailed"
+)
+
+// Kubelet event reasons and messages recording probe failures
+const (
+	eventReasonUnhealthy = "Unhealthy"
+	eventReasonKilling   = "Killing"
+	livenessKillMessage  = "failed liveness probe"
+	livenessPrefix       = "Liveness probe failed"
+	readinessPrefix      = "Readiness probe failed"
+)
+
+// probeFailure describes a container failing its liveness or readiness probe
+type probeFailure struct {
+	reason    string
+	container string
+	// restarts counts liveness restarts within the window
+	restarts int32
+	// notReadySince is when the container last became NotReady
+	notReadySince time.Time
+	// lastFailure is the message of the most recent Unhealthy event
+	lastFailure string
+}
+
+// isProbeEvent reports whether a pod event may record a probe failure
+func isProbeEvent(event *corev1.Event) bool {
+	return event.Reason == eventReasonUnhealthy ||
+		(event.Reason == eventReasonKilling && strings.Contains(event.Message, livenessKillMessage))
+}
+
+// findProbeFailure returns a container of the pod restarted by its liveness probe at least the
+// configured number of times within the window, or else one NotReady with failing readiness
+// probes for longer than the threshold. wait is when a NotReady container with failing probes
+// reaches the threshold, so the pod can be looked at again then.
+func (r *PodReconciler) findProbeFailure(ctx context.Context, pod *corev1.Pod, now time.Time) (failure *probeFailure, wait time.Duration, err error) {
+	if !r.Config.Probes.Enabled || pod.DeletionTimestamp != nil {
+		return nil, 0, nil
+	}
+	events, err := r.podEvents(ctx, pod, now.Add(-r.Config.Probes.Window.Duration))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	liveness := make(map[string]*probeFailure)
+	for _, event := range events {
+		container := containerFromFieldPath(event.InvolvedObject.FieldPath)
+		if event.Reason != eventReasonKilling || !strings.Contains(event.Message, livenessKillMessage) {
+			continue
+		}
+		failure := liveness[container]
+		if failure == nil {
+			failure = &probeFailure{reason: ReasonLivenessProbeFailed, container: container}
+			liveness[container] = failure
+		}
+		failure.restarts += max(event.Count, 1)
+	}
+	for _, failure := range liveness {
+		if failure.restarts >= r.Config.Probes.LivenessRestarts {
+			failure.lastFailure = lastProbeFailure(events, failure.container, livenessPrefix)
+			return failure, 0, nil
+		}
+	}
+
+	// Readiness failures only matter while the container runs; a crashing one is alerted on
+	// for its crash
+	notReadySince := podConditionSince(pod, corev1.ContainersReady, corev1.ConditionFalse)
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Ready || status.State.Running == nil || notReadySince.IsZero() {
+			continue
+		}
+		last := lastProbeFailure(events, status.Name, readinessPrefix)
+		if last == "" {
+			continue
+		}
+		// A container restarted since the pod turned NotReady has been NotReady since it started
+		since := notReadySince
+		if status.State.Running.StartedAt.After(since) {
+			since = status.State.Running.StartedAt.Time
+		}
+		if remaining := r.Config.Probes.ReadinessThreshold.Duration - now.Sub(since); remaining > 0 {
+			if wait == 0 || remaining < wait {
+				wait = remaining
+			}
+			continue
+		}
+		return &probeFailure{reason: ReasonReadinessProbeFailed, container: status.Name, notReadySince: since, lastFailure: last}, 0, nil
+	}
+	return nil, wait, nil
+}
+
+// lastProbeFailure returns the message of the container's most recent Unhealthy event for the
+// probe, or an empty string. Events are newest first.
+func lastProbeFailure(events []corev1.Event, container, prefix string) string {
+	for _, event := range events {
+		if event.Reason == eventReasonUnhealthy && strings.HasPrefix(event.Message, prefix) &&
+			containerFromFieldPath(event.InvolvedObject.FieldPath) == container {
+			return strings.TrimSpace(event.Message)
+		}
+	}
+	return ""
+}
+
+// podConditionSince returns when a pod condition last changed to the status, or the zero time
+// if it does not currently have that status
+func podConditionSince(pod *corev1.Pod, conditionType corev1.PodConditionType, status corev1.ConditionStatus) time.Time {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == status {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return time.Time{}
+}
+
+// describe renders the probe failure for the alert message
+func (p *probeFailure) describe(window time.Duration, now time.Time) string {
+	var b strings.Builder
+	if p.reason == ReasonLivenessProbeFailed {
+		fmt.Fprintf(&b, "🩺 Container `%s` was restarted %d time(s) in the last %s because its liveness probe failed.",
+			p.container, p.restarts, formatReminderAge(window))
+	} else {
+		fmt.Fprintf(&b, "🩺 Container `%s` has been NotReady for %s because its readiness probe fails; it receives no Service traffic.",
+			p.container, formatReminderAge(now.Sub(p.notReadySince)))
+	}
+	if p.lastFailure != "" {
+		fmt.Fprintf(&b, "\n\nLast probe failure: %s", p.lastFailure)
+	}
+	return b.String()
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/kubeevent"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// quotaRejectedKey is the alert state key of a quota's rejected pod creations
+const quotaRejectedKey = "rejected"
+
+// exceededQuotaPattern extracts the quota name from the admission error recorded when a
+// controller fails to create a pod, e.g. `pods "web-1" is forbidden: exceeded quota: compute,
+// requested: requests.cpu=1, used: requests.cpu=10, limited: requests.cpu=10`
+var exceededQuotaPattern = regexp.MustCompile(`exceeded quota: ([^,\s]+)`)
+
+// quotaUse is a resource of a quota whose use reached the threshold
+type quotaUse struct {
+	resource   corev1.ResourceName
+	used, hard string
+	percent    float64
+}
+
+// quotaRejections summarizes the pod creations a quota rejected within the window
+type quotaRejections struct {
+	count int
+	// latest is the most recent rejection
+	latest corev1.Event
+}
+
+// QuotaReconciler alerts when a ResourceQuota's use reaches a share of its hard limits, or when
+// it rejects pod creations, so teams learn about quota starvation before deployments stall
+type QuotaReconciler struct {
+	client.Client
+	Notifier notify.Notifier
+	Config   config.QuotasConfig
+
+	mu sync.Mutex
+	// alerted is when each quota resource, or the quota's rejections, was last alerted on,
+	// keyed by quota and then by resource name or quotaRejectedKey
+	alerted map[types.NamespacedName]map[string]time.Time
+}
+
+// Reconcile alerts on the resources of the quota at or above the threshold, and on pod
+// creations it rejected, unless they were alerted on within the window
+func (r *QuotaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	var quota corev1.ResourceQuota
+	if err := r.Get(ctx, req.NamespacedName, &quota); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			r.mu.Lock()
+			delete(r.alerted, req.NamespacedName)
+			r.mu.Unlock()
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	now := time.Now()
+	exhausted := r.exhaustedResources(&quota)
+	rejections, err := r.rejections(ctx, &quota, now)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	exhausted, rejected := r.due(req.NamespacedName, exhausted, rejections.count > 0, now)
+	if len(exhausted) == 0 && !rejected {
+		return ctrl.Result{}, nil
+	}
+	if !rejected {
+		rejections = quotaRejections{}
+	}
+
+	if err := r.Notifier.SendMetaAlert(r.quotaAlert(&quota, exhausted, rejections, now)); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.record(req.NamespacedName, exhausted, rejected, now)
+	logger.Info("ResourceQuota exhausted", "namespace", quota.Namespace, "quota", quota.Name,
+		"resources", len(exhausted), "rejected", rejections.count)
+	return ctrl.Result{}, nil
+}
+
+// exhaustedResources returns the resources of a quota whose use is at or above the threshold
+func (r *QuotaReconciler) exhaustedResources(quota *corev1.ResourceQuota) []quotaUse {
+	var exhausted []quotaUse
+	for name, hard := range quota.Status.Hard {
+		used, ok := quota.Status.Used[name]
+		if !ok || hard.IsZero() {
+			continue
+		}
+		percent := float64(used.MilliValue()) / float64(hard.MilliValue()) * 100
+		if percent >= float64(r.Config.Threshold) {
+			exhausted = append(exhausted, quotaUse{resource: name, used: used.String(), hard: hard.String(), percent: percent})
+		}
+	}
+	sort.Slice(exhausted, func(i, j int) bool { return exhausted[i].resource < exhausted[j].resource })
+	return exhausted
+}
+
+// rejections counts the pod creations the quota rejected within the window, from the
+// FailedCreate events of the controllers in its namespace
+func (r *QuotaReconciler) rejections(ctx context.Context, quota *corev1.ResourceQuota, now time.Time) (quotaRejections, error) {
+	var events corev1.EventList
+	if err := r.List(ctx, &events, client.InNamespace(quota.Namespace)); err != nil {
+		return quotaRejections{}, err
+	}
+
+	var rejections quotaRejections
+	for _, e := range events.Items {
+		if quotaRejecting(&e) != quota.Name || now.Sub(kubeevent.LastObserved(&e)) > r.Config.Window.Duration {
+			continue
+		}
+		rejections.count += max(int(e.Count), 1)
+		if rejections.latest.Name == "" || kubeevent.LastObserved(&e).After(kubeevent.LastObserved(&rejections.latest)) {
+			rejections.latest = e
+		}
+	}
+	return rejections, nil
+}
+
+// quotaRejecting returns the name of the quota that rejected a pod creation reported by an
+// event, or an empty string if the event does not report one
+func quotaRejecting(e *corev1.Event) string {
+	if e.Reason != "FailedCreate" {
+		return ""
+	}
+	match := exceededQuotaPattern.FindStringSubmatch(e.Message)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// due filters the exhausted resources and rejections of a quota down to those not alerted on
+// within the window. Resources that dropped below the threshold are forgotten, so they alert
+// again when they next reach it.
+func (r *QuotaReconciler) due(quota types.NamespacedName, exhausted []quotaUse, rejected bool, now time.Time) ([]quotaUse, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	alerted := r.alerted[quota]
+	for key := range alerted {
+		if key == quotaRejectedKey {
+			continue
+		}
+		if !slices.ContainsFunc(exhausted, func(use quotaUse) bool { return string(use.resource) == key }) {
+			delete(alerted, key)
+		}
+	}
+
+	recent := func(key string) bool {
+		last, ok := alerted[key]
+		return ok && now.Sub(last) < r.Config.Window.Duration
+	}
+	var due []quotaUse
+	for _, use := range exhausted {
+		if !recent(string(use.resource)) {
+			due = append(due, use)
+		}
+	}
+	return due, rejected && !recent(quotaRejectedKey)
+}
+
+// record records that resources and rejections of a quota were alerted on
+func (r *QuotaReconciler) record(quota types.NamespacedName, exhausted []quotaUse, rejected bool, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	alerted := r.alerted[quota]
+	if alerted == nil {
+		alerted = make(map[string]time.Time)
+		r.alerted[quota] = alerted
+	}
+	for _, use := range exhausted {
+		alerted[string(use.resource)] = now
+	}
+	if rejected {
+		alerted[quotaRejectedKey] = now
+	}
+}
+
+// quotaAlert builds the alert for a quota's exhausted resources and rejected pod creations
+func (r *QuotaReconciler) quotaAlert(quota *corev1.ResourceQuota, exhausted []quotaUse, rejections quotaRejections, now time.Time) notify.MetaAlert {
+	var b strings.Builder
+	for _, use := range exhausted {
+		fmt.Fprintf(&b, "• `%s`: %s of %s used (%.0f%%)\n", use.resource, use.used, use.hard, use.percent)
+	}
+
+	title := fmt.Sprintf("📦 ResourceQuota %s/%s is nearly exhausted", quota.Namespace, quota.Name)
+	severity := notify.SeverityWarning
+	if rejections.count > 0 {
+		title = fmt.Sprintf("📦 ResourceQuota %s/%s is rejecting pods", quota.Namespace, quota.Name)
+		severity = notify.SeverityCritical
+		involved := rejections.latest.InvolvedObject
+		fmt.Fprintf(&b, "%d pod creation(s) rejected in the last %s, latest for %s `%s`: %s\n",
+			rejections.count, formatReminderAge(r.Config.Window.Duration), involved.Kind, involved.Name,
+			strings.TrimSpace(rejections.latest.Message))
+		b.WriteString("The controller retries with backoff, so the workload stays short of replicas until quota is freed or raised.")
+	} else {
+		b.WriteString("Pods whose requests do not fit the remaining quota will be rejected; free or raise the quota before the next rollout or scale-up.")
+	}
+
+	return notify.MetaAlert{
+		Title:     title,
+		Text:      b.String(),
+		Severity:  severity,
+		Channel:   r.Config.Channel,
+		Timestamp: now,
+	}
+}
+
+// mapEventToQuota maps a pod creation rejected by a quota to the quota
+func mapEventToQuota(_ context.Context, obj client.Object) []reconcile.Request {
+	e := obj.(*corev1.Event)
+	name := quotaRejecting(e)
+	if name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: e.Namespace, Name: name}}}
+}
+
+// SetupWithManager sets up the controller with the Manager. Quotas are reconciled when their
+// use changes, and when the events of their namespace report a rejected pod creation.
+func (r *QuotaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.alerted = make(map[types.NamespacedName]map[string]time.Time)
+
+	quotaPredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldQuota, newQuota := e.ObjectOld.(*corev1.ResourceQuota), e.ObjectNew.(*corev1.ResourceQuota)
+			return !equality.Semantic.DeepEqual(oldQuota.Status, newQuota.Status)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+	rejectionPredicate := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return quotaRejecting(obj.(*corev1.Event)) != ""
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ResourceQuota{}, builder.WithPredicates(quotaPredicate)).
+		Watches(&corev1.Event{},
+			handler.EnqueueRequestsFromMapFunc(mapEventToQuota),
+			builder.WithPredicates(rejectionPredicate),
+		).
+		Named("resourcequota").
+		Complete(r)
+}
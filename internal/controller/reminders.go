@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// reminderState tracks the reminders of an incident that is still failing
+type reminderState struct {
+	firstAlertAt time.Time
+	sent         int
+}
+
+// remindersApply reports whether repeat alerts for the reason are sent as reminders
+func (r *PodReconciler) remindersApply(reason string) bool {
+	cfg := r.Config.Reminders
+	return cfg.Enabled && len(cfg.Schedule) > 0 && slices.Contains(cfg.Reasons, reason)
+}
+
+// startIncident remembers when the first alert of an incident was sent, so reminders are
+// scheduled relative to it
+func (r *PodReconciler) startIncident(alertKey string, at time.Time) {
+	r.alertCacheMux.Lock()
+	defer r.alertCacheMux.Unlock()
+
+	if _, exists := r.reminders[alertKey]; !exists {
+		r.reminders[alertKey] = reminderState{firstAlertAt: at}
+	}
+}
+
+// reminderDue reports whether a reminder is due for an incident that already alerted, and
+// otherwise how long until the next one. An empty label with zero wait means the key has no
+// incident yet, so a regular alert is sent.
+func (r *PodReconciler) reminderDue(alertKey string, now time.Time) (string, time.Duration) {
+	r.alertCacheMux.RLock()
+	state, exists := r.reminders[alertKey]
+	r.alertCacheMux.RUnlock()
+	if !exists {
+		return "", 0
+	}
+
+	offset := r.reminderOffset(state.sent)
+	if elapsed := now.Sub(state.firstAlertAt); elapsed < offset {
+		return "", offset - elapsed
+	}
+	return formatReminderAge(offset), 0
+}
+
+// reminderSent counts a reminder of an incident
+func (r *PodReconciler) reminderSent(alertKey string) {
+	r.alertCacheMux.Lock()
+	defer r.alertCacheMux.Unlock()
+
+	if state, exists := r.reminders[alertKey]; exists {
+		state.sent++
+		r.reminders[alertKey] = state
+	}
+}
+
+// forgetIncidents drops the reminder state of all incidents whose key starts with the prefix
+func (r *PodReconciler) forgetIncidents(prefix string) {
+	r.alertCacheMux.Lock()
+	defer r.alertCacheMux.Unlock()
+
+	for key := range r.reminders {
+		if strings.HasPrefix(key, prefix) {
+			delete(r.reminders, key)
+		}
+	}
+}
+
+// reminderOffset returns how long after the first alert the nth reminder is due. After the
+// last scheduled step, reminders repeat at the interval of the last step.
+func (r *PodReconciler) reminderOffset(n int) time.Duration {
+	schedule := r.Config.Reminders.Schedule
+	last := schedule[len(schedule)-1].Duration
+	if n < len(schedule) {
+		return schedule[n].Duration
+	}
+	return last * time.Duration(n-len(schedule)+2)
+}
+
+// formatReminderAge renders a reminder offset such as 30m, 1h or 1h30m
+func formatReminderAge(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours, minutes := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+}
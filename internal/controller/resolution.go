@@ -0,0 +1,234 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/alertkey"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/store"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// trackOpenAlert remembers a sent alert until the pod recovers or is deleted. It is persisted,
+// so a new leader can still resolve it.
+func (r *PodReconciler) trackOpenAlert(ctx context.Context, alert notify.PodAlert) {
+	r.alertCacheMux.Lock()
+	r.openAlerts[alert.Key] = alert
+	r.alertCacheMux.Unlock()
+
+	if r.Store != nil {
+		if err := r.Store.PutOpenAlert(ctx, openAlertRecord(alert)); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to persist open alert", "key", alert.Key)
+		}
+	}
+}
+
+// restoreOpenAlerts loads the alerts the previous leader left open, then re-examines their pods
+// and workloads, which may have recovered or been deleted while no leader was watching. It runs
+// once the replica becomes the leader.
+func (r *PodReconciler) restoreOpenAlerts(ctx context.Context) error {
+	if r.Store == nil {
+		return nil
+	}
+	logger := logf.FromContext(ctx)
+
+	// The store may still hold what this replica loaded as a standby
+	if reloader, ok := r.Store.(store.Reloader); ok {
+		reloader.Reload()
+	}
+	records, err := r.Store.OpenAlerts(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to load open alerts; incidents of the previous leader are not resolved")
+		return nil
+	}
+
+	var pods []types.NamespacedName
+	namespaces := make(map[string]bool)
+	r.alertCacheMux.Lock()
+	for _, record := range records {
+		// Alerts sent since this replica took over are newer
+		if _, exists := r.openAlerts[record.Key]; exists {
+			continue
+		}
+		r.openAlerts[record.Key] = podAlertFromRecord(record)
+
+		key, err := alertkey.Parse(record.Key)
+		switch {
+		case err != nil:
+		case key.OwnerKind == alertkey.KindPod:
+			pods = append(pods, types.NamespacedName{Namespace: key.Namespace, Name: key.OwnerName})
+		case key.IsWorkload():
+			namespaces[key.Namespace] = true
+		}
+	}
+	r.alertCacheMux.Unlock()
+	if len(records) > 0 {
+		logger.Info("Restored open alerts", "alerts", len(records))
+	}
+
+	for namespace := range namespaces {
+		if err := r.resolveWorkloadAlerts(ctx, namespace); err != nil {
+			// Retried on the next reconcile of a pod in the namespace
+			logger.Error(err, "Failed to resolve workload alerts", "namespace", namespace)
+		}
+	}
+	for _, pod := range pods {
+		select {
+		case r.restored <- event.TypedGenericEvent[reconcile.Request]{Object: reconcile.Request{NamespacedName: pod}}:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	return nil
+}
+
+// openAlertRecord returns the persisted form of an open alert
+func openAlertRecord(alert notify.PodAlert) store.OpenAlert {
+	return store.OpenAlert{
+		Key:          alert.Key,
+		Cluster:      alert.Cluster,
+		Namespace:    alert.Namespace,
+		Pod:          alert.PodName,
+		Container:    alert.ContainerName,
+		WorkloadKind: alert.WorkloadKind,
+		WorkloadName: alert.WorkloadName,
+		Reason:       alert.Reason,
+		Severity:     string(alert.Severity),
+		Channel:      alert.Channel,
+		Team:         alert.Team,
+		Timestamp:    alert.Timestamp,
+	}
+}
+
+// podAlertFromRecord restores an open alert from its persisted form
+func podAlertFromRecord(record store.OpenAlert) notify.PodAlert {
+	return notify.PodAlert{
+		Key:           record.Key,
+		Cluster:       record.Cluster,
+		Namespace:     record.Namespace,
+		PodName:       record.Pod,
+		ContainerName: record.Container,
+		WorkloadKind:  record.WorkloadKind,
+		WorkloadName:  record.WorkloadName,
+		Reason:        record.Reason,
+		Severity:      notify.Severity(record.Severity),
+		Channel:       record.Channel,
+		Team:          record.Team,
+		Timestamp:     record.Timestamp,
+	}
+}
+
+// OpenAlerts returns the alerts that were sent and have not recovered yet
+func (r *PodReconciler) OpenAlerts() []notify.PodAlert {
+	r.alertCacheMux.RLock()
+	defer r.alertCacheMux.RUnlock()
+
+	alerts := make([]notify.PodAlert, 0, len(r.openAlerts))
+	for _, alert := range r.openAlerts {
+		alerts = append(alerts, alert)
+	}
+	return alerts
+}
+
+// hasOpenAlerts reports whether any alert whose key starts with the prefix, e.g. that of a
+// pod, is still unresolved
+func (r *PodReconciler) hasOpenAlerts(prefix string) bool {
+	r.alertCacheMux.RLock()
+	defer r.alertCacheMux.RUnlock()
+
+	for key := range r.openAlerts {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveAlerts notifies senders that track incidents that every open alert whose key starts
+// with the prefix, e.g. that of a pod or workload, has recovered. Alerts that fail to resolve
+// stay open and are retried on the next reconcile.
+func (r *PodReconciler) resolveAlerts(ctx context.Context, prefix string) error {
+	r.alertCacheMux.Lock()
+	var open []notify.PodAlert
+	for key, alert := range r.openAlerts {
+		if strings.HasPrefix(key, prefix) {
+			open = append(open, alert)
+			delete(r.openAlerts, key)
+		}
+	}
+	r.alertCacheMux.Unlock()
+	r.forgetIncidents(prefix)
+
+	var resolved []string
+	var firstErr error
+	resolver, ok := r.Notifier.(notify.AlertResolver)
+	for _, alert := range open {
+		if !ok {
+			resolved = append(resolved, alert.Key)
+			continue
+		}
+		if err := resolver.ResolvePodAlert(alert); err != nil {
+			r.alertCacheMux.Lock()
+			r.openAlerts[alert.Key] = alert
+			r.alertCacheMux.Unlock()
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		resolved = append(resolved, alert.Key)
+		logf.FromContext(ctx).Info("Resolved pod failure alert",
+			"pod", alert.PodName,
+			"namespace", alert.Namespace,
+			"reason", alert.Reason,
+		)
+	}
+
+	if r.Store != nil && len(resolved) > 0 {
+		if err := r.Store.DeleteOpenAlerts(ctx, resolved); err != nil {
+			// A new leader resolves them again, which notifiers tolerate
+			logf.FromContext(ctx).Error(err, "Failed to forget resolved alerts", "prefix", prefix)
+		}
+	}
+	return firstErr
+}
+
+// isPodHealthy reports whether a pod has recovered: it completed successfully, or it is
+// running with every container ready
+func isPodHealthy(pod *corev1.Pod) bool {
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return true
+	case corev1.PodRunning:
+		for _, status := range pod.Status.ContainerStatuses {
+			if !status.Ready {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/store"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+const (
+	// revisionAnnotation holds the revision of a Deployment and of each of its ReplicaSets
+	revisionAnnotation = "deployment.kubernetes.io/revision"
+	// revisionHistoryAnnotation lists the earlier revisions of a ReplicaSet that was reused,
+	// which is what `kubectl rollout undo` and reverting to an identical template do
+	revisionHistoryAnnotation = "deployment.kubernetes.io/revision-history"
+	// rollbackToAnnotation is set by older clients requesting a rollback
+	rollbackToAnnotation = "deprecated.deployment.rollback.to"
+	// rollbackHistoryLimit bounds how much alert history is searched for linked alerts
+	rollbackHistoryLimit = 500
+)
+
+// RollbackReconciler detects Deployment rollbacks and posts a follow-up listing the failure
+// alerts sent for the replaced revisions shortly before, so the channel learns whether the
+// failure was answered by rolling back
+type RollbackReconciler struct {
+	client.Client
+	Notifier notify.Notifier
+	// Store holds the alert history searched for linked alerts
+	Store  store.Store
+	Config config.RollbacksConfig
+
+	mu sync.Mutex
+	// revisions is the last seen revision per Deployment
+	revisions map[types.NamespacedName]int64
+}
+
+// rollback describes a detected rollback
+type rollback struct {
+	// from is the revision that was replaced
+	from int64
+	// to is the revision whose template was restored, 0 if unknown
+	to int64
+	// current is the ReplicaSet now running the Deployment
+	current string
+}
+
+// Reconcile compares the Deployment's revision with the last one seen and reports rollbacks
+func (r *RollbackReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	var deployment appsv1.Deployment
+	if err := r.Get(ctx, req.NamespacedName, &deployment); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			r.mu.Lock()
+			delete(r.revisions, req.NamespacedName)
+			r.mu.Unlock()
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	revision, err := strconv.ParseInt(deployment.Annotations[revisionAnnotation], 10, 64)
+	if err != nil {
+		// Not rolled out by the deployment controller yet
+		return ctrl.Result{}, nil
+	}
+
+	r.mu.Lock()
+	previous, seen := r.revisions[req.NamespacedName]
+	r.revisions[req.NamespacedName] = revision
+	r.mu.Unlock()
+
+	// The first sighting after a restart cannot be compared with anything
+	if !seen || revision == previous {
+		return ctrl.Result{}, nil
+	}
+
+	replicaSets, err := deploymentReplicaSets(ctx, r.Client, &deployment)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	detected, ok := detectRollback(&deployment, replicaSets, previous, revision)
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	linked, err := r.linkedAlerts(ctx, deployment.Namespace, replicaSets, detected.current, now)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	logger.Info("Detected Deployment rollback",
+		"deployment", deployment.Name,
+		"namespace", deployment.Namespace,
+		"from", detected.from,
+		"to", detected.to,
+		"linkedAlerts", len(linked),
+	)
+	if len(linked) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.Notifier.SendMetaAlert(r.followUp(&deployment, detected, linked, now)); err != nil {
+		// The revision is already recorded, so the follow-up is not retried
+		logger.Error(err, "Failed to send rollback follow-up",
+			"deployment", deployment.Name,
+			"namespace", deployment.Namespace,
+		)
+	}
+	return ctrl.Result{}, nil
+}
+
+// deploymentReplicaSets returns the ReplicaSets controlled by the Deployment
+func deploymentReplicaSets(ctx context.Context, c client.Reader, deployment *appsv1.Deployment) ([]appsv1.ReplicaSet, error) {
+	var list appsv1.ReplicaSetList
+	if err := c.List(ctx, &list, client.InNamespace(deployment.Namespace)); err != nil {
+		return nil, err
+	}
+
+	var owned []appsv1.ReplicaSet
+	for _, replicaSet := range list.Items {
+		if ref := metav1.GetControllerOf(&replicaSet); ref != nil && ref.UID == deployment.UID {
+			owned = append(owned, replicaSet)
+		}
+	}
+	return owned, nil
+}
+
+// detectRollback decides whether a revision change was a rollback. A rollback reuses the
+// ReplicaSet of an earlier revision, which then records that revision in its history.
+func detectRollback(deployment *appsv1.Deployment, replicaSets []appsv1.ReplicaSet, previous, revision int64) (rollback, bool) {
+	detected := rollback{from: previous}
+	if to, err := strconv.ParseInt(deployment.Annotations[rollbackToAnnotation], 10, 64); err == nil {
+		detected.to = to
+	}
+
+	var reused bool
+	for _, replicaSet := range replicaSets {
+		if replicaSet.Annotations[revisionAnnotation] != strconv.FormatInt(revision, 10) {
+			continue
+		}
+		detected.current = replicaSet.Name
+		if history := replicaSet.Annotations[revisionHistoryAnnotation]; history != "" {
+			reused = true
+			earlier := strings.Split(history, ",")
+			if to, err := strconv.ParseInt(earlier[len(earlier)-1], 10, 64); err == nil && detected.to == 0 {
+				detected.to = to
+			}
+		}
+	}
+
+	_, undoRequested := deployment.Annotations[rollbackToAnnotation]
+	return detected, reused || undoRequested || revision < previous
+}
+
+// linkedAlerts returns the failure alerts sent within the window for pods of the Deployment's
+// ReplicaSets other than the one now running it, newest first
+func (r *RollbackReconciler) linkedAlerts(ctx context.Context, namespace string, replicaSets []appsv1.ReplicaSet, current string, now time.Time) ([]store.HistoryEntry, error) {
+	history, err := r.Store.History(ctx, rollbackHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	var linked []store.HistoryEntry
+	for _, entry := range history {
+		if now.Sub(entry.Timestamp) > r.Config.Window.Duration {
+			break
+		}
+		if entry.Namespace != namespace {
+			continue
+		}
+		for _, replicaSet := range replicaSets {
+			if replicaSet.Name != current && strings.HasPrefix(entry.Pod, replicaSet.Name+"-") {
+				linked = append(linked, entry)
+				break
+			}
+		}
+	}
+	return linked, nil
+}
+
+// followUp builds the message linking the failure alerts to the rollback
+func (r *RollbackReconciler) followUp(deployment *appsv1.Deployment, detected rollback, linked []store.HistoryEntry, now time.Time) notify.MetaAlert {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Deployment `%s` in `%s` was rolled back from revision %d", deployment.Name, deployment.Namespace, detected.from)
+	if detected.to != 0 {
+		fmt.Fprintf(&b, " to the template of revision %d", detected.to)
+	}
+	fmt.Fprintf(&b, " after %d failure alert(s) in the last %s:\n", len(linked), formatReminderAge(r.Config.Window.Duration))
+	for i := len(linked) - 1; i >= 0; i-- {
+		entry := linked[i]
+		fmt.Fprintf(&b, "• %s `%s` %s", entry.Timestamp.UTC().Format("15:04 MST"), entry.Pod, entry.Reason)
+		if entry.Channel != "" {
+			fmt.Fprintf(&b, " in %s", entry.Channel)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\nPods of the restored revision are watched as usual; a new alert means the rollback did not fix the failure.")
+
+	channel := r.Config.Channel
+	if channel == "" {
+		channel = linked[0].Channel
+	}
+	return notify.MetaAlert{
+		Title:     fmt.Sprintf("↩️ Rollback of %s/%s", deployment.Namespace, deployment.Name),
+		Text:      b.String(),
+		Severity:  notify.SeverityInfo,
+		Channel:   channel,
+		Timestamp: now,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager. Only annotation changes can carry
+// a new revision, so other Deployment updates are ignored.
+func (r *RollbackReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.revisions = make(map[types.NamespacedName]int64)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1.Deployment{}, builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		Named("rollback").
+		Complete(r)
+}
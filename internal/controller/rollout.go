@@ -0,0 +1,260 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+const (
+	// progressDeadlineExceeded is the Progressing condition reason of a stalled rollout
+	progressDeadlineExceeded = "ProgressDeadlineExceeded"
+	// changeCauseAnnotation records the command or pipeline that changed a Deployment
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// RolloutReconciler alerts once per revision when a Deployment rollout exceeds its progress
+// deadline, with the image change it was rolling out, so the responsible deploy is obvious.
+// Alerts are routed like pod alerts, see routeObject.
+type RolloutReconciler struct {
+	client.Client
+	Notifier notify.Notifier
+	Config   config.RolloutsConfig
+	Routing  config.RoutingConfig
+
+	mu sync.Mutex
+	// stalled is the revision of each Deployment whose stalled rollout was alerted on
+	stalled map[types.NamespacedName]string
+}
+
+// Reconcile alerts on a Deployment whose Progressing condition is False with
+// ProgressDeadlineExceeded, unless its revision was already alerted on
+func (r *RolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	var deployment appsv1.Deployment
+	if err := r.Get(ctx, req.NamespacedName, &deployment); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			r.setStalled(req.NamespacedName, "")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	condition := progressingCondition(&deployment)
+	if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != progressDeadlineExceeded {
+		r.setStalled(req.NamespacedName, "")
+		return ctrl.Result{}, nil
+	}
+
+	revision := deployment.Annotations[revisionAnnotation]
+	r.mu.Lock()
+	alerted := r.stalled[req.NamespacedName] == revision
+	r.mu.Unlock()
+	if alerted {
+		return ctrl.Result{}, nil
+	}
+
+	replicaSets, err := deploymentReplicaSets(ctx, r.Client, &deployment)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	channel, err := routeObject(ctx, r.Client, r.Routing.Ownership, &deployment)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	current, previous := rolloutReplicaSets(replicaSets, revision)
+	if err := r.Notifier.SendMetaAlert(stalledRolloutAlert(&deployment, condition, current, previous, channel, time.Now())); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.setStalled(req.NamespacedName, revision)
+	logger.Info("Rollout stalled", "namespace", deployment.Namespace, "deployment", deployment.Name, "revision", revision)
+	return ctrl.Result{}, nil
+}
+
+// setStalled records the revision of a Deployment whose stalled rollout was alerted on, or
+// forgets the Deployment if empty
+func (r *RolloutReconciler) setStalled(deployment types.NamespacedName, revision string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if revision == "" {
+		delete(r.stalled, deployment)
+		return
+	}
+	r.stalled[deployment] = revision
+}
+
+// progressingCondition returns the Progressing condition of a Deployment, or nil
+func progressingCondition(deployment *appsv1.Deployment) *appsv1.DeploymentCondition {
+	for i := range deployment.Status.Conditions {
+		if deployment.Status.Conditions[i].Type == appsv1.DeploymentProgressing {
+			return &deployment.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// rolloutReplicaSets returns the ReplicaSet of the revision being rolled out and that of the
+// latest earlier revision, either nil if not found
+func rolloutReplicaSets(replicaSets []appsv1.ReplicaSet, revision string) (*appsv1.ReplicaSet, *appsv1.ReplicaSet) {
+	target, _ := strconv.ParseInt(revision, 10, 64)
+
+	var current, previous *appsv1.ReplicaSet
+	var previousRevision int64
+	for i := range replicaSets {
+		rs, err := strconv.ParseInt(replicaSets[i].Annotations[revisionAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch {
+		case rs == target:
+			current = &replicaSets[i]
+		case rs < target && rs > previousRevision:
+			previous, previousRevision = &replicaSets[i], rs
+		}
+	}
+	return current, previous
+}
+
+// imageChanges lists the containers whose image differs between two pod templates, e.g.
+// "api: shop/api:1.4 → shop/api:1.5", including containers added by the new template
+func imageChanges(previous, current *corev1.PodSpec) []string {
+	images := make(map[string]string)
+	for _, container := range previous.InitContainers {
+		images[container.Name] = container.Image
+	}
+	for _, container := range previous.Containers {
+		images[container.Name] = container.Image
+	}
+
+	var changes []string
+	for _, container := range append(append([]corev1.Container{}, current.InitContainers...), current.Containers...) {
+		old, existed := images[container.Name]
+		switch {
+		case !existed:
+			changes = append(changes, fmt.Sprintf("%s: added with %s", container.Name, container.Image))
+		case old != container.Image:
+			changes = append(changes, fmt.Sprintf("%s: %s → %s", container.Name, old, container.Image))
+		}
+	}
+	return changes
+}
+
+// stalledRolloutAlert builds the alert for a rollout that exceeded its progress deadline
+func stalledRolloutAlert(deployment *appsv1.Deployment, condition *appsv1.DeploymentCondition, current, previous *appsv1.ReplicaSet, channel string, now time.Time) notify.MetaAlert {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Deployment `%s/%s` made no progress for %ds rolling out revision %s",
+		deployment.Namespace, deployment.Name, progressDeadline(deployment), deployment.Annotations[revisionAnnotation])
+	if message := strings.TrimSpace(condition.Message); message != "" {
+		fmt.Fprintf(&b, ": %s", message)
+	}
+	b.WriteString(".\n")
+
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	updated := deployment.Status.UpdatedReplicas
+	percent := 100
+	if desired > 0 {
+		percent = int(updated) * 100 / int(desired)
+	}
+	fmt.Fprintf(&b, "*Updated:* %d of %d replicas (%d%%), %d unavailable\n", updated, desired, percent, deployment.Status.UnavailableReplicas)
+
+	switch {
+	case current == nil || previous == nil:
+		b.WriteString("*Images:* the previous revision is no longer available to compare\n")
+	default:
+		if changes := imageChanges(&previous.Spec.Template.Spec, &current.Spec.Template.Spec); len(changes) > 0 {
+			fmt.Fprintf(&b, "*Images:* %s\n", strings.Join(changes, "; "))
+		} else {
+			b.WriteString("*Images:* unchanged, the rollout changed other fields of the pod template\n")
+		}
+	}
+	if cause := deployment.Annotations[changeCauseAnnotation]; cause != "" {
+		fmt.Fprintf(&b, "*Change cause:* %s\n", cause)
+	}
+
+	if current != nil {
+		fmt.Fprintf(&b, "The new pods belong to ReplicaSet `%s`; their alerts explain why they do not become ready. ", current.Name)
+	}
+	fmt.Fprintf(&b, "Roll back with `kubectl rollout undo deployment/%s -n %s`.", deployment.Name, deployment.Namespace)
+
+	return notify.MetaAlert{
+		Title:     fmt.Sprintf("⏸️ Rollout of %s/%s stalled", deployment.Namespace, deployment.Name),
+		Text:      b.String(),
+		Severity:  notify.SeverityWarning,
+		Channel:   channel,
+		Timestamp: now,
+	}
+}
+
+// progressDeadline returns the progress deadline of a Deployment in seconds
+func progressDeadline(deployment *appsv1.Deployment) int32 {
+	if deployment.Spec.ProgressDeadlineSeconds != nil {
+		return *deployment.Spec.ProgressDeadlineSeconds
+	}
+	return 600
+}
+
+// SetupWithManager sets up the controller with the Manager. Only changes of the Progressing
+// condition are reconciled.
+func (r *RolloutReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.stalled = make(map[types.NamespacedName]string)
+
+	progressChanged := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			condition := progressingCondition(e.Object.(*appsv1.Deployment))
+			return condition != nil && condition.Reason == progressDeadlineExceeded
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCondition := progressingCondition(e.ObjectOld.(*appsv1.Deployment))
+			newCondition := progressingCondition(e.ObjectNew.(*appsv1.Deployment))
+			if oldCondition == nil || newCondition == nil {
+				return newCondition != nil
+			}
+			return oldCondition.Status != newCondition.Status || oldCondition.Reason != newCondition.Reason
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1.Deployment{}, builder.WithPredicates(progressChanged)).
+		Named("rollout").
+		Complete(r)
+}
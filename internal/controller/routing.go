@@ -0,0 +1,233 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// ChannelAnnotation routes alerts for a pod to a specific Slack channel.
+// It may be set on the pod, its owning workload, or its namespace.
+const ChannelAnnotation = "slackgenie.io/channel"
+
+// MentionAnnotation adds a Slack mention, e.g. <!subteam^S0123ABCD>, to alerts for a pod.
+// Like the channel annotation it may be set on the pod, its owning workload, or its namespace.
+const MentionAnnotation = "slackgenie.io/mention"
+
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// routeAlert sets the destination channel and owning team of an alert. Ownership rules from
+// the configuration win over annotations, so platform-managed workloads (ingress, monitoring,
+// mesh sidecars) reach the platform team even when they fail inside a tenant namespace.
+func (r *PodReconciler) routeAlert(ctx context.Context, pod *corev1.Pod, alert *notify.PodAlert, trace *alertTrace) error {
+	// Rules that do not depend on the node still apply when the node cannot be read
+	node, nodeErr := r.nodeForRouting(ctx, pod)
+	if nodeErr != nil {
+		trace.add("node: unreadable, node rules skipped")
+	}
+
+	if applyOwnership(r.Config.Routing.Ownership, pod, node, alert, trace) {
+		return nodeErr
+	}
+
+	channel, source, err := r.resolveAnnotation(ctx, pod, ChannelAnnotation)
+	alert.Channel = channel
+	switch {
+	case err != nil:
+		trace.add("channel: default, annotation lookup failed")
+	case source != "":
+		trace.add("channel: %s from %s annotation", channelName(channel), source)
+	default:
+		trace.add("channel: default, no %s annotation", ChannelAnnotation)
+	}
+	return errors.Join(nodeErr, err)
+}
+
+// mentionAlert adds the mentions of the matching rules and of the mention annotation to alerts
+// severe enough to notify anyone. It runs after routing, so rules can select alerts by the
+// team ownership rules attributed them to.
+func (r *PodReconciler) mentionAlert(ctx context.Context, pod *corev1.Pod, alert *notify.PodAlert, trace *alertTrace) error {
+	mentions := r.Config.Routing.Mentions
+	severity := alert.AlertSeverity()
+	for i, rule := range mentions.Rules {
+		minSeverity := rule.MinSeverity
+		if minSeverity == "" {
+			minSeverity = mentions.MinSeverity
+		}
+		if !rule.Matches(pod.Namespace, pod.Labels, alert.Team) {
+			continue
+		}
+		if !severity.AtLeast(notify.Severity(minSeverity)) {
+			trace.add("mentions: rule #%d matched, %s below %s", i+1, severity, minSeverity)
+			continue
+		}
+		if !slices.Contains(alert.Mentions, rule.Mention) {
+			alert.Mentions = append(alert.Mentions, rule.Mention)
+		}
+		trace.add("mentions: rule #%d matched", i+1)
+	}
+
+	if !severity.AtLeast(notify.Severity(mentions.MinSeverity)) {
+		return nil
+	}
+	mention, source, err := r.resolveAnnotation(ctx, pod, MentionAnnotation)
+	if err != nil {
+		trace.add("mentions: annotation lookup failed")
+		return err
+	}
+	if mention != "" {
+		if !slices.Contains(alert.Mentions, mention) {
+			alert.Mentions = append(alert.Mentions, mention)
+		}
+		trace.add("mentions: from %s annotation", source)
+	}
+	return nil
+}
+
+// OnCallResolver returns the Slack mention of the engineer on call for a schedule
+type OnCallResolver interface {
+	Mention(ctx context.Context, schedule string) (string, error)
+}
+
+// mentionOnCall adds the on-call engineer of the alert's namespace or team to alerts severe
+// enough to page them. A failed lookup leaves the alert without the mention.
+func (r *PodReconciler) mentionOnCall(ctx context.Context, pod *corev1.Pod, alert *notify.PodAlert, trace *alertTrace) error {
+	onCall := r.Config.Routing.OnCall
+	if r.OnCall == nil || !alert.AlertSeverity().AtLeast(notify.Severity(onCall.MinSeverity)) {
+		return nil
+	}
+	schedule := onCall.ScheduleFor(pod.Namespace, alert.Team)
+	if schedule == "" {
+		return nil
+	}
+
+	mention, err := r.OnCall.Mention(ctx, schedule)
+	switch {
+	case err != nil:
+		trace.add("on-call: lookup in schedule %s failed", schedule)
+		return err
+	case mention == "":
+		trace.add("on-call: nobody on call in schedule %s", schedule)
+	default:
+		if !slices.Contains(alert.Mentions, mention) {
+			alert.Mentions = append(alert.Mentions, mention)
+		}
+		trace.add("on-call: from schedule %s", schedule)
+	}
+	return nil
+}
+
+// applyOwnership attributes the alert to the first ownership rule claiming the pod and
+// reports whether one did
+func applyOwnership(rules []config.OwnershipRule, pod *corev1.Pod, node *corev1.Node, alert *notify.PodAlert, trace *alertTrace) bool {
+	for i, rule := range rules {
+		if rule.Matches(pod.Namespace, pod.Labels, alert.ContainerName, node) {
+			alert.Team = rule.Team
+			alert.Channel = rule.Channel
+			trace.add("ownership: rule #%d (team %s) matched", i+1, rule.Team)
+			trace.add("channel: %s from ownership rule", channelName(rule.Channel))
+			return true
+		}
+	}
+	if len(rules) > 0 {
+		trace.add("ownership: none of %d rules matched", len(rules))
+	}
+	return false
+}
+
+// channelName describes a channel for the routing trace
+func channelName(channel string) string {
+	if channel == "" {
+		return "default"
+	}
+	return channel
+}
+
+// nodeForRouting returns the node the pod runs on when an ownership rule matches on node
+// labels or taints, so infrastructure alerts from e.g. CI or GPU nodes reach the team owning
+// those nodes regardless of namespace
+func (r *PodReconciler) nodeForRouting(ctx context.Context, pod *corev1.Pod) (*corev1.Node, error) {
+	if pod.Spec.NodeName == "" || !slices.ContainsFunc(r.Config.Routing.Ownership, config.OwnershipRule.NeedsNode) {
+		return nil, nil
+	}
+
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, &node); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return &node, nil
+}
+
+// resolveAnnotation returns the value of a routing annotation for a pod, checking the pod
+// itself, then its owning workload, then its namespace, along with where it was found. For the
+// channel annotation an empty result means the default destination.
+func (r *PodReconciler) resolveAnnotation(ctx context.Context, pod *corev1.Pod, key string) (string, string, error) {
+	if value := pod.Annotations[key]; value != "" {
+		return value, "pod", nil
+	}
+
+	owner, err := r.resolveOwner(ctx, pod)
+	if err != nil {
+		return "", "", err
+	}
+	if owner != nil {
+		if value := owner.Annotations[key]; value != "" {
+			return value, strings.TrimSpace(fmt.Sprintf("%s %s", owner.Kind, owner.Name)), nil
+		}
+	}
+
+	var namespace corev1.Namespace
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Namespace}, &namespace); err != nil {
+		return "", "", client.IgnoreNotFound(err)
+	}
+
+	if value := namespace.Annotations[key]; value != "" {
+		return value, "namespace", nil
+	}
+	return "", "", nil
+}
+
+// routeObject returns the channel for alerts about a resource other than a pod, routed the way
+// pod alerts are: ownership rules matching its namespace or labels win, then the channel
+// annotation on the resource and then on its namespace. An empty result means the default
+// destination.
+func routeObject(ctx context.Context, c client.Reader, rules []config.OwnershipRule, obj client.Object) (string, error) {
+	for _, rule := range rules {
+		if rule.Matches(obj.GetNamespace(), obj.GetLabels(), "", nil) {
+			return rule.Channel, nil
+		}
+	}
+	if channel := obj.GetAnnotations()[ChannelAnnotation]; channel != "" {
+		return channel, nil
+	}
+
+	var namespace corev1.Namespace
+	if err := c.Get(ctx, client.ObjectKey{Name: obj.GetNamespace()}, &namespace); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	return namespace.Annotations[ChannelAnnotation], nil
+}
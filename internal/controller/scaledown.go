@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ScaledToZeroAnnotation marks a workload as deliberately scaled down, e.g. outside business
+// hours, so termination alerts from its pods are suppressed
+const ScaledToZeroAnnotation = "slackgenie.io/scaled-to-zero"
+
+// downscalerAnnotation is set by kube-downscaler on workloads it scaled down
+const downscalerAnnotation = "downscaler/original-replicas"
+
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch
+
+// isTerminationReason reports whether a failure reason is what pods typically report while
+// being stopped, as opposed to failing on their own
+func isTerminationReason(reason string) bool {
+	switch reason {
+	case "Error", string(corev1.PodFailed), ReasonFailedPreStopHook:
+		return true
+	default:
+		return false
+	}
+}
+
+// intentionalScaleDown reports whether the pod's workload is being scaled down on purpose,
+// and why. Pods stopped by a scale-down often exit with errors on SIGTERM, which would
+// otherwise produce confusing alerts.
+func (r *PodReconciler) intentionalScaleDown(ctx context.Context, pod *corev1.Pod) (bool, string, error) {
+	owner, err := r.resolveOwner(ctx, pod)
+	if err != nil || owner == nil {
+		return false, "", err
+	}
+
+	if owner.Annotations[ScaledToZeroAnnotation] == "true" {
+		return true, fmt.Sprintf("%s %s is annotated %s", owner.Kind, owner.Name, ScaledToZeroAnnotation), nil
+	}
+	if _, ok := owner.Annotations[downscalerAnnotation]; ok {
+		return true, fmt.Sprintf("%s %s was scaled down by kube-downscaler", owner.Kind, owner.Name), nil
+	}
+
+	var desired *int32
+	var current int32
+	key := client.ObjectKey{Namespace: owner.Namespace, Name: owner.Name}
+	switch owner.Kind {
+	case "Deployment":
+		var deployment appsv1.Deployment
+		if err := r.Get(ctx, key, &deployment); err != nil {
+			return false, "", client.IgnoreNotFound(err)
+		}
+		desired, current = deployment.Spec.Replicas, deployment.Status.Replicas
+	case "StatefulSet":
+		var statefulSet appsv1.StatefulSet
+		if err := r.Get(ctx, key, &statefulSet); err != nil {
+			return false, "", client.IgnoreNotFound(err)
+		}
+		desired, current = statefulSet.Spec.Replicas, statefulSet.Status.Replicas
+	default:
+		return false, "", nil
+	}
+
+	if desired != nil && *desired == 0 {
+		return true, fmt.Sprintf("%s %s is scaled to zero", owner.Kind, owner.Name), nil
+	}
+
+	// Only a pod that is being deleted can be a casualty of a partial scale-down
+	if pod.DeletionTimestamp == nil || desired == nil || *desired >= current {
+		return false, "", nil
+	}
+
+	var hpas autoscalingv2.HorizontalPodAutoscalerList
+	if err := r.List(ctx, &hpas, client.InNamespace(owner.Namespace)); err != nil {
+		return false, "", err
+	}
+	for _, hpa := range hpas.Items {
+		if hpa.Spec.ScaleTargetRef.Kind == owner.Kind && hpa.Spec.ScaleTargetRef.Name == owner.Name {
+			return true, fmt.Sprintf("HorizontalPodAutoscaler %s is scaling %s %s down from %d to %d replicas",
+				hpa.Name, owner.Kind, owner.Name, current, *desired), nil
+		}
+	}
+
+	return true, fmt.Sprintf("%s %s is being scaled down from %d to %d replicas", owner.Kind, owner.Name, current, *desired), nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/slack"
+)
+
+// SlackSecretReconciler reloads Slack credentials when the referenced Secret changes
+type SlackSecretReconciler struct {
+	client.Client
+	SlackNotifier *slack.Notifier
+	SecretKey     types.NamespacedName
+}
+
+// +kubebuilder:rbac:groups=core,namespace=system,resources=secrets,verbs=get;list;watch
+
+// Reconcile pushes the current Secret contents into the notifier
+func (r *SlackSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	var secret corev1.Secret
+	if err := r.Get(ctx, req.NamespacedName, &secret); err != nil {
+		// Keep using the last known credentials if the Secret disappears
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if err := r.SlackNotifier.UpdateCredentials(slack.CredentialsFromSecret(&secret)); err != nil {
+		// Invalid contents are a user error; keep the previous credentials and wait for the next change
+		logger.Error(err, "Ignoring invalid Slack credentials in Secret",
+			"secret", req.NamespacedName,
+		)
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("Reloaded Slack credentials from Secret",
+		"secret", req.NamespacedName,
+		"resourceVersion", secret.ResourceVersion,
+	)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager, watching only the referenced Secret
+func (r *SlackSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	secretPredicate := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetNamespace() == r.SecretKey.Namespace && obj.GetName() == r.SecretKey.Name
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Secret{}, builder.WithPredicates(secretPredicate)).
+		Named("slack-secret").
+		Complete(r)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ReasonGracefulShutdownExceeded is the alert reason for containers that were SIGKILLed
+// because they did not exit within the termination grace period
+const ReasonGracefulShutdownExceeded = "GracefulShutdownExceeded"
+
+// sigkillExitCode is the exit code of a container killed by SIGKILL (128 + 9)
+const sigkillExitCode = 137
+
+// shutdownKillTolerance allows for the delay between the grace period expiring and the kubelet
+// recording the container as terminated, and for clock skew between the API server and node
+const shutdownKillTolerance = 2 * time.Second
+
+// shutdownOverrun describes a container that was killed at the end of its grace period
+type shutdownOverrun struct {
+	container   string
+	gracePeriod int64
+	finishedAt  time.Time
+}
+
+// findShutdownOverrun returns the first container of a terminating pod that was SIGKILLed
+// when its termination grace period ran out. Such kills look like random Error exits, but
+// point at shutdown handling that ignores SIGTERM or takes too long.
+func findShutdownOverrun(pod *corev1.Pod) *shutdownOverrun {
+	if pod.DeletionTimestamp == nil {
+		return nil
+	}
+
+	// The deletion timestamp is when the grace period ends
+	deadline := pod.DeletionTimestamp.Add(-shutdownKillTolerance)
+	for _, status := range pod.Status.ContainerStatuses {
+		terminated := status.State.Terminated
+		if terminated == nil || terminated.ExitCode != sigkillExitCode || terminated.Reason == "OOMKilled" {
+			continue
+		}
+		if terminated.FinishedAt.Time.Before(deadline) {
+			continue
+		}
+		return &shutdownOverrun{
+			container:   status.Name,
+			gracePeriod: gracePeriodSeconds(pod),
+			finishedAt:  terminated.FinishedAt.Time,
+		}
+	}
+	return nil
+}
+
+// gracePeriodSeconds returns the grace period the pod is being deleted with, falling back to
+// the one in its spec and the Kubernetes default
+func gracePeriodSeconds(pod *corev1.Pod) int64 {
+	switch {
+	case pod.DeletionGracePeriodSeconds != nil:
+		return *pod.DeletionGracePeriodSeconds
+	case pod.Spec.TerminationGracePeriodSeconds != nil:
+		return *pod.Spec.TerminationGracePeriodSeconds
+	default:
+		return corev1.DefaultTerminationGracePeriodSeconds
+	}
+}
+
+// describe renders the overrun for the alert message
+func (s *shutdownOverrun) describe() string {
+	return fmt.Sprintf("Container %s did not exit within terminationGracePeriodSeconds=%d after SIGTERM and was "+
+		"killed with SIGKILL (exit code %d). Check that it handles SIGTERM and finishes shutting down in time, "+
+		"or raise the grace period.", s.container, s.gracePeriod, sigkillExitCode)
+}
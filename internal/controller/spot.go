@@ -0,0 +1,200 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/kubeevent"
+)
+
+// ReasonSpotInterruption is the alert reason for failures of pods on an interrupted spot or
+// preemptible node. It has info severity: the provider reclaimed the capacity and the pods'
+// controllers reschedule them elsewhere.
+const ReasonSpotInterruption = "SpotInterruption"
+
+const (
+	// spotEventWindow is how far back node events are searched for an interruption notice
+	spotEventWindow = time.Hour
+	// spotPodListLimit bounds how many affected pods the alert lists
+	spotPodListLimit = 10
+)
+
+// spotNodeLabels identify spot and preemptible nodes of the common providers
+var spotNodeLabels = []string{
+	"eks.amazonaws.com/capacityType=SPOT",
+	"karpenter.sh/capacity-type=spot",
+	"cloud.google.com/gke-spot=true",
+	"cloud.google.com/gke-preemptible=true",
+	"kubernetes.azure.com/scalesetpriority=spot",
+}
+
+// interruptionTaints are set on nodes that received an interruption notice
+var interruptionTaints = []string{
+	"aws-node-termination-handler/spot-itn",
+	"cloud.google.com/impending-node-termination",
+}
+
+// interruptionEventReasons are the node events recording an interruption notice, from the AWS
+// node termination handler and Karpenter. They outlive the node, so interruptions are
+// recognized after the node is gone.
+var interruptionEventReasons = []string{"SpotInterruption", "SpotInterrupted"}
+
+// spotInterruption describes the interruption of the node a pod ran on
+type spotInterruption struct {
+	node string
+	// signal is how the interruption was recognized
+	signal string
+}
+
+// findSpotInterruption returns the interruption of the spot node the pod ran on, or nil if the
+// node is not a spot node or was not interrupted
+func (r *PodReconciler) findSpotInterruption(ctx context.Context, pod *corev1.Pod) (*spotInterruption, error) {
+	if pod.Spec.NodeName == "" {
+		return nil, nil
+	}
+	interruption := &spotInterruption{node: pod.Spec.NodeName}
+
+	var node corev1.Node
+	nodeExists := true
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, &node); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+		nodeExists = false
+	}
+
+	if nodeExists {
+		if !r.isSpotNode(&node) {
+			return nil, nil
+		}
+		for _, taint := range node.Spec.Taints {
+			if slices.Contains(interruptionTaints, taint.Key) || slices.Contains(r.Config.Spot.Taints, taint.Key) {
+				interruption.signal = fmt.Sprintf("node tainted %s", taint.Key)
+				return interruption, nil
+			}
+		}
+	}
+
+	event, err := r.interruptionEvent(ctx, pod.Spec.NodeName, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if event != nil {
+		interruption.signal = fmt.Sprintf("node event %s: %s", event.Reason, strings.TrimSpace(event.Message))
+		return interruption, nil
+	}
+
+	// Without a notice, a shutdown of a known spot node is taken as its interruption
+	if nodeExists && shutDownWithNode(pod) {
+		interruption.signal = "pod shut down with the node"
+		return interruption, nil
+	}
+	return nil, nil
+}
+
+// isSpotNode reports whether a node carries one of the spot node labels
+func (r *PodReconciler) isSpotNode(node *corev1.Node) bool {
+	for _, label := range slices.Concat(spotNodeLabels, r.Config.Spot.NodeLabels) {
+		key, value, _ := strings.Cut(label, "=")
+		if node.Labels[key] == value {
+			return true
+		}
+	}
+	return false
+}
+
+// interruptionEvent returns the latest interruption notice recorded for a node within the
+// event window, or nil if there is none. Events of cluster-scoped objects live in the default
+// namespace.
+func (r *PodReconciler) interruptionEvent(ctx context.Context, node string, now time.Time) (*corev1.Event, error) {
+	var events corev1.EventList
+	if err := r.List(ctx, &events, client.InNamespace(metav1.NamespaceDefault)); err != nil {
+		return nil, err
+	}
+
+	var latest *corev1.Event
+	for i := range events.Items {
+		event := &events.Items[i]
+		if event.InvolvedObject.Kind != "Node" || event.InvolvedObject.Name != node ||
+			!slices.Contains(interruptionEventReasons, event.Reason) ||
+			now.Sub(kubeevent.LastObserved(event)) > spotEventWindow {
+			continue
+		}
+		if latest == nil || kubeevent.LastObserved(event).After(kubeevent.LastObserved(latest)) {
+			latest = event
+		}
+	}
+	return latest, nil
+}
+
+// shutDownWithNode reports whether the kubelet terminated the pod because its node shut down.
+// Pressure evictions by the kubelet carry the same condition and are told apart by their reason.
+func shutDownWithNode(pod *corev1.Pod) bool {
+	if pod.Status.Reason == ReasonEvicted {
+		return false
+	}
+	if pod.Status.Reason == "Terminated" || pod.Status.Reason == "NodeShutdown" || pod.Status.Reason == "Shutdown" {
+		return true
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.DisruptionTarget && condition.Status == corev1.ConditionTrue &&
+			condition.Reason == "TerminationByKubelet" {
+			return true
+		}
+	}
+	return false
+}
+
+// describeSpotInterruption lists the failing pods on the interrupted node for the alert message
+func (r *PodReconciler) describeSpotInterruption(ctx context.Context, interruption *spotInterruption) (string, error) {
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods); err != nil {
+		return "", err
+	}
+
+	var lines []string
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName != interruption.node {
+			continue
+		}
+		if shouldAlert, reason := r.shouldAlertForPod(pod); shouldAlert {
+			lines = append(lines, fmt.Sprintf("• `%s/%s` %s", pod.Namespace, pod.Name, reason))
+		}
+	}
+	slices.Sort(lines)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "⚡ Spot node `%s` was interrupted (%s).", interruption.node, interruption.signal)
+	if len(lines) > 0 {
+		fmt.Fprintf(&b, " %d pod(s) on it failed:\n%s", len(lines), strings.Join(lines[:min(len(lines), spotPodListLimit)], "\n"))
+		if len(lines) > spotPodListLimit {
+			fmt.Fprintf(&b, "\n• and %d more", len(lines)-spotPodListLimit)
+		}
+	}
+	b.WriteString("\n\nThe provider reclaimed the capacity; controllers reschedule the pods on other nodes. Act only if they stay pending.")
+	return b.String(), nil
+}
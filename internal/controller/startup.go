@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// startupSummaryLimit bounds the pods listed in the startup summary
+const startupSummaryLimit = 20
+
+// startupSnapshot holds the pods that were failing when the operator started
+type startupSnapshot struct {
+	once sync.Once
+	mu   sync.Mutex
+	// pods maps the UID of each pod failing at startup to its failure reason
+	pods map[types.UID]string
+}
+
+// snapshotPreExisting records the pods failing when the operator starts, on the first
+// reconcile, when the controller's cache has synced. In summary mode it sends one message
+// listing them.
+func (r *PodReconciler) snapshotPreExisting(ctx context.Context) {
+	if r.Config.Startup.PreExisting == config.PreExistingAlert {
+		return
+	}
+
+	r.startup.once.Do(func() {
+		logger := logf.FromContext(ctx)
+		r.startup.pods = make(map[types.UID]string)
+
+		var pods corev1.PodList
+		if err := r.List(ctx, &pods); err != nil {
+			logger.Error(err, "Failed to list pods failing at startup, alerting on them as usual")
+			return
+		}
+		var failing []corev1.Pod
+		for _, pod := range pods.Items {
+			if shouldAlert, reason := r.shouldAlertForPod(&pod); shouldAlert {
+				r.startup.pods[pod.UID] = reason
+				failing = append(failing, pod)
+			}
+		}
+		logger.Info("Found pods failing at startup", "pods", len(failing), "handling", r.Config.Startup.PreExisting)
+
+		if r.Config.Startup.PreExisting != config.PreExistingSummary || len(failing) == 0 {
+			return
+		}
+		if err := r.Notifier.SendMetaAlert(r.startupSummary(failing, time.Now())); err != nil {
+			logger.Error(err, "Failed to send the summary of pods failing at startup")
+		}
+	})
+}
+
+// isPreExisting reports whether the pod was failing when the operator started and has not
+// recovered since
+func (r *PodReconciler) isPreExisting(pod *corev1.Pod) bool {
+	r.startup.mu.Lock()
+	defer r.startup.mu.Unlock()
+
+	_, found := r.startup.pods[pod.UID]
+	return found
+}
+
+// forgetPreExisting drops a recovered pod from the startup snapshot, so its next failure alerts
+func (r *PodReconciler) forgetPreExisting(pod *corev1.Pod) {
+	r.startup.mu.Lock()
+	defer r.startup.mu.Unlock()
+
+	delete(r.startup.pods, pod.UID)
+}
+
+// startupSummary builds the message listing the pods failing at startup, counted per reason
+func (r *PodReconciler) startupSummary(failing []corev1.Pod, now time.Time) notify.MetaAlert {
+	counts := make(map[string]int)
+	for _, pod := range failing {
+		counts[r.startup.pods[pod.UID]]++
+	}
+	reasons := make([]string, 0, len(counts))
+	for reason := range counts {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if counts[reasons[i]] != counts[reasons[j]] {
+			return counts[reasons[i]] > counts[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "The operator started and found %d pod(s) already failing. They are not alerted on individually until they recover and fail again.\n", len(failing))
+	for _, reason := range reasons {
+		fmt.Fprintf(&b, "• %s: %d\n", reason, counts[reason])
+	}
+
+	sort.Slice(failing, func(i, j int) bool {
+		return failing[i].Namespace+"/"+failing[i].Name < failing[j].Namespace+"/"+failing[j].Name
+	})
+	b.WriteString("\n")
+	for i, pod := range failing {
+		if i == startupSummaryLimit {
+			fmt.Fprintf(&b, "... and %d more\n", len(failing)-startupSummaryLimit)
+			break
+		}
+		fmt.Fprintf(&b, "`%s/%s` %s\n", pod.Namespace, pod.Name, r.startup.pods[pod.UID])
+	}
+
+	return notify.MetaAlert{
+		Title:     fmt.Sprintf("🔁 %d pre-existing failure(s) found at startup", len(failing)),
+		Text:      strings.TrimSuffix(b.String(), "\n"),
+		Severity:  notify.SeverityInfo,
+		Timestamp: now,
+	}
+}
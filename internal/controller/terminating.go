@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ReasonStuckTerminating is the alert reason for pods still terminating well past their grace
+// period, typically held by a finalizer or a kubelet that cannot stop containers or unmount
+// volumes
+const ReasonStuckTerminating = "StuckTerminating"
+
+// terminatingOverdue returns how long a deleted pod has outlived its grace period, or false if
+// it is not being deleted or stuck terminating detection is disabled. The API server sets the
+// deletion timestamp of a pod to the end of its grace period.
+func (r *PodReconciler) terminatingOverdue(pod *corev1.Pod, now time.Time) (time.Duration, bool) {
+	if !r.Config.Terminating.Enabled || pod.DeletionTimestamp == nil {
+		return 0, false
+	}
+	return now.Sub(pod.DeletionTimestamp.Time), true
+}
+
+// stuckTerminating reports whether a deleted pod has been terminating past its grace period
+// for longer than the threshold
+func (r *PodReconciler) stuckTerminating(pod *corev1.Pod, now time.Time) bool {
+	overdue, ok := r.terminatingOverdue(pod, now)
+	return ok && overdue >= r.Config.Terminating.Threshold.Duration
+}
+
+// terminatingRecheck returns when a terminating pod reaches the threshold, or false if it is
+// not terminating or has already reached it
+func (r *PodReconciler) terminatingRecheck(pod *corev1.Pod, now time.Time) (time.Duration, bool) {
+	overdue, ok := r.terminatingOverdue(pod, now)
+	if !ok || overdue >= r.Config.Terminating.Threshold.Duration {
+		return 0, false
+	}
+	return r.Config.Terminating.Threshold.Duration - overdue, true
+}
+
+// describeStuckTerminating explains what holds a terminating pod: its finalizers, or else the
+// kubelet, with the containers still running and the latest warning event, such as a failed
+// unmount
+func (r *PodReconciler) describeStuckTerminating(ctx context.Context, pod *corev1.Pod, now time.Time) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "🗑️ Terminating for %s past its grace period.", formatReminderAge(now.Sub(pod.DeletionTimestamp.Time)))
+	if len(pod.Finalizers) > 0 {
+		fmt.Fprintf(&b, "\nBlocked by finalizers: `%s`. The pod is removed once their controllers clear them.",
+			strings.Join(pod.Finalizers, "`, `"))
+	} else if pod.Spec.NodeName != "" {
+		fmt.Fprintf(&b, "\nNo finalizers: the kubelet on node `%s` has not confirmed the containers stopped and volumes unmounted.", pod.Spec.NodeName)
+	}
+
+	var running []string
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Running != nil {
+			running = append(running, "`"+status.Name+"`")
+		}
+	}
+	if len(running) > 0 {
+		fmt.Fprintf(&b, "\nStill running: %s", strings.Join(running, ", "))
+	}
+
+	events, err := r.podEvents(ctx, pod, pod.DeletionTimestamp.Add(-time.Duration(deletionGracePeriod(pod))*time.Second))
+	if err != nil {
+		return b.String(), err
+	}
+	for i := range events {
+		if events[i].Type == corev1.EventTypeWarning {
+			fmt.Fprintf(&b, "\nLatest warning: %s: %s", events[i].Reason, strings.TrimSpace(events[i].Message))
+			break
+		}
+	}
+	return b.String(), nil
+}
+
+// deletionGracePeriod returns the grace period in seconds the pod was deleted with
+func deletionGracePeriod(pod *corev1.Pod) int64 {
+	if pod.DeletionGracePeriodSeconds != nil {
+		return *pod.DeletionGracePeriodSeconds
+	}
+	return 0
+}
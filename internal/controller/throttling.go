@@ -0,0 +1,348 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/kubeevent"
+)
+
+// ReasonCPUThrottling is the alert reason for liveness probe failures under heavy CPU throttling
+const ReasonCPUThrottling = "CPUThrottling"
+
+const (
+	// cadvisorThrottledPeriods and cadvisorPeriods count CFS periods per container
+	cadvisorThrottledPeriods = "container_cpu_cfs_throttled_periods_total"
+	cadvisorPeriods          = "container_cpu_cfs_periods_total"
+	// scrapeTimeout bounds each node's cAdvisor scrape
+	scrapeTimeout = 15 * time.Second
+)
+
+// +kubebuilder:rbac:groups=core,resources=nodes/proxy,verbs=get
+
+// containerKey identifies a container across samples
+type containerKey struct {
+	namespace string
+	pod       string
+	container string
+}
+
+// throttleState is the sampling state of a container
+type throttleState struct {
+	throttled float64
+	periods   float64
+	sampledAt time.Time
+	// heavySince is when the current streak of heavily throttled samples started, zero if the
+	// last sample was below the threshold
+	heavySince time.Time
+	// minPercent is the lowest throttled share of the current streak
+	minPercent float64
+}
+
+// throttledContainer describes a container that has been heavily throttled for the sustained
+// duration
+type throttledContainer struct {
+	container string
+	percent   float64
+	since     time.Time
+	// probeFailures and lastProbeFailure describe the liveness probe failures in the streak
+	probeFailures    int32
+	lastProbeFailure string
+	cpuLimit         string
+}
+
+// ThrottlingMonitor samples container CPU throttling from each node's cAdvisor and asks the
+// pod reconciler to re-examine pods that stay heavily throttled. Throttling alone is not a
+// failure; the reconciler alerts when it coincides with liveness probe failures, which
+// restart containers without any trace in the pod status.
+type ThrottlingMonitor struct {
+	Client    client.Client
+	Clientset kubernetes.Interface
+	Config    config.ThrottlingConfig
+
+	mu         sync.Mutex
+	containers map[containerKey]*throttleState
+	pods       chan event.TypedGenericEvent[reconcile.Request]
+}
+
+// NewThrottlingMonitor creates a monitor for the given configuration
+func NewThrottlingMonitor(c client.Client, clientset kubernetes.Interface, cfg config.ThrottlingConfig) *ThrottlingMonitor {
+	return &ThrottlingMonitor{
+		Client:     c,
+		Clientset:  clientset,
+		Config:     cfg,
+		containers: make(map[containerKey]*throttleState),
+		pods:       make(chan event.TypedGenericEvent[reconcile.Request], 1024),
+	}
+}
+
+// NeedLeaderElection runs the monitor on the leader, which runs the pod reconciler
+func (m *ThrottlingMonitor) NeedLeaderElection() bool {
+	return true
+}
+
+// Events returns the channel pods to re-examine are delivered on
+func (m *ThrottlingMonitor) Events() <-chan event.TypedGenericEvent[reconcile.Request] {
+	return m.pods
+}
+
+// Start samples throttling until the context is cancelled
+func (m *ThrottlingMonitor) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.Config.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			m.sample(ctx, now)
+		}
+	}
+}
+
+// sample scrapes every node and updates the throttling state of their containers
+func (m *ThrottlingMonitor) sample(ctx context.Context, now time.Time) {
+	logger := logf.FromContext(ctx)
+
+	var nodes corev1.NodeList
+	if err := m.Client.List(ctx, &nodes); err != nil {
+		logger.Error(err, "Failed to list nodes for throttling sampling")
+		return
+	}
+
+	counters := make(map[containerKey][2]float64)
+	for _, node := range nodes.Items {
+		if err := m.scrape(ctx, node.Name, counters); err != nil {
+			logger.V(1).Info("Failed to scrape cAdvisor metrics", "node", node.Name, "error", err.Error())
+		}
+	}
+
+	for _, pod := range m.update(counters, now) {
+		select {
+		case m.pods <- event.TypedGenericEvent[reconcile.Request]{Object: reconcile.Request{NamespacedName: pod}}:
+		default:
+			// The reconciler is behind; the pod is offered again on the next sample
+		}
+	}
+}
+
+// scrape reads the CFS period counters of every container on a node
+func (m *ThrottlingMonitor) scrape(ctx context.Context, node string, counters map[containerKey][2]float64) error {
+	ctx, cancel := context.WithTimeout(ctx, scrapeTimeout)
+	defer cancel()
+
+	raw, err := m.Clientset.CoreV1().RESTClient().Get().
+		AbsPath("/api/v1/nodes", node, "proxy", "metrics", "cadvisor").
+		DoRaw(ctx)
+	if err != nil {
+		return err
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("failed to parse cAdvisor metrics: %w", err)
+	}
+
+	for i, name := range []string{cadvisorThrottledPeriods, cadvisorPeriods} {
+		family, ok := families[name]
+		if !ok {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			var key containerKey
+			for _, label := range metric.GetLabel() {
+				switch label.GetName() {
+				case "namespace":
+					key.namespace = label.GetValue()
+				case "pod":
+					key.pod = label.GetValue()
+				case "container":
+					key.container = label.GetValue()
+				}
+			}
+			// Pod-level cgroups and sandboxes have no container name
+			if key.pod == "" || key.container == "" || key.container == "POD" {
+				continue
+			}
+			values := counters[key]
+			values[i] = metric.GetCounter().GetValue()
+			counters[key] = values
+		}
+	}
+	return nil
+}
+
+// update records a sample and returns the pods to re-examine: those with a container that
+// is heavily throttled for the sustained duration, and those whose streak just ended, so
+// their alerts can resolve
+func (m *ThrottlingMonitor) update(counters map[containerKey][2]float64, now time.Time) []types.NamespacedName {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	recheck := make(map[types.NamespacedName]bool)
+	for key, values := range counters {
+		throttled, periods := values[0], values[1]
+		state, seen := m.containers[key]
+		if !seen {
+			m.containers[key] = &throttleState{throttled: throttled, periods: periods, sampledAt: now}
+			continue
+		}
+
+		wasSustained := m.sustainedLocked(state, now)
+		var percent float64
+		if delta := periods - state.periods; delta > 0 {
+			percent = 100 * (throttled - state.throttled) / delta
+		}
+		switch {
+		case periods < state.periods || throttled < state.throttled:
+			// Counters reset when the container restarts, e.g. after a failed liveness probe,
+			// which must not end the streak
+		case percent >= float64(m.Config.MinPercent):
+			if state.heavySince.IsZero() {
+				state.heavySince, state.minPercent = state.sampledAt, percent
+			}
+			state.minPercent = min(state.minPercent, percent)
+		default:
+			state.heavySince = time.Time{}
+		}
+		state.throttled, state.periods, state.sampledAt = throttled, periods, now
+
+		if m.sustainedLocked(state, now) || wasSustained {
+			recheck[types.NamespacedName{Namespace: key.namespace, Name: key.pod}] = true
+		}
+	}
+
+	for key := range m.containers {
+		if _, ok := counters[key]; !ok {
+			delete(m.containers, key)
+		}
+	}
+
+	pods := make([]types.NamespacedName, 0, len(recheck))
+	for pod := range recheck {
+		pods = append(pods, pod)
+	}
+	return pods
+}
+
+// sustainedLocked reports whether a container has been heavily throttled long enough
+func (m *ThrottlingMonitor) sustainedLocked(state *throttleState, now time.Time) bool {
+	return !state.heavySince.IsZero() && now.Sub(state.heavySince) >= m.Config.Sustained.Duration
+}
+
+// throttled returns the containers of a pod that are heavily throttled for the sustained duration
+func (m *ThrottlingMonitor) throttled(pod *corev1.Pod, now time.Time) []throttledContainer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var containers []throttledContainer
+	for _, container := range pod.Spec.Containers {
+		state, ok := m.containers[containerKey{namespace: pod.Namespace, pod: pod.Name, container: container.Name}]
+		if !ok || !m.sustainedLocked(state, now) {
+			continue
+		}
+		throttled := throttledContainer{container: container.Name, percent: state.minPercent, since: state.heavySince}
+		if limit, ok := container.Resources.Limits[corev1.ResourceCPU]; ok {
+			throttled.cpuLimit = limit.String()
+		}
+		containers = append(containers, throttled)
+	}
+	return containers
+}
+
+// findThrottledProbeFailure returns a container of the pod whose liveness probe failed while
+// it was heavily throttled, or nil if there is none
+func (r *PodReconciler) findThrottledProbeFailure(ctx context.Context, pod *corev1.Pod) (*throttledContainer, error) {
+	if r.Throttling == nil {
+		return nil, nil
+	}
+	containers := r.Throttling.throttled(pod, time.Now())
+	if len(containers) == 0 {
+		return nil, nil
+	}
+
+	earliest := containers[0].since
+	for _, throttled := range containers {
+		if throttled.since.Before(earliest) {
+			earliest = throttled.since
+		}
+	}
+	events, err := r.podEvents(ctx, pod, earliest)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range containers {
+		throttled := &containers[i]
+		for _, event := range events {
+			if event.Reason != "Unhealthy" || !strings.HasPrefix(event.Message, "Liveness probe failed") ||
+				containerFromFieldPath(event.InvolvedObject.FieldPath) != throttled.container ||
+				kubeevent.LastObserved(&event).Before(throttled.since) {
+				continue
+			}
+			// Events are newest first
+			if throttled.lastProbeFailure == "" {
+				throttled.lastProbeFailure = event.Message
+			}
+			throttled.probeFailures += max(event.Count, 1)
+		}
+		if throttled.probeFailures > 0 {
+			return throttled, nil
+		}
+	}
+	return nil, nil
+}
+
+// describe renders the throttling and probe failures for the alert message
+func (t *throttledContainer) describe(now time.Time) string {
+	limit := "its CPU limit"
+	if t.cpuLimit != "" {
+		limit = fmt.Sprintf("its CPU limit (%s)", t.cpuLimit)
+	}
+	return fmt.Sprintf("🐢 Container `%s` was CPU throttled in at least %.0f%% of its CFS periods for the last %s "+
+		"while its liveness probe failed %d time(s). Probes that time out under throttling restart the container; "+
+		"consider raising %s or the probe's timeoutSeconds.\n\nLast probe failure: %s",
+		t.container, t.percent, formatReminderAge(now.Sub(t.since)), t.probeFailures, limit, t.lastProbeFailure)
+}
+
+// containerRestarts returns the restart count of a container of the pod
+func containerRestarts(pod *corev1.Pod, containerName string) int32 {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == containerName {
+			return status.RestartCount
+		}
+	}
+	return 0
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+)
+
+// Timer wheel geometry: one slot per tick, so a full revolution covers timerWheelSlots ticks.
+// Longer delays wait additional revolutions.
+const (
+	timerWheelTick  = time.Second
+	timerWheelSlots = 512
+)
+
+// timerWheelEntry is the position of a pending key on the wheel
+type timerWheelEntry struct {
+	slot   int
+	rounds int
+}
+
+// timerWheel schedules pods for re-examination without controller requeues. Scheduling and
+// cancelling are O(1), a tick only visits the keys of one slot, and each pod has at most one
+// pending timer, so repeated failures of the same pod do not pile up work.
+type timerWheel struct {
+	mu      sync.Mutex
+	slots   []map[types.NamespacedName]struct{}
+	pending map[types.NamespacedName]timerWheelEntry
+	cursor  int
+	fired   chan event.TypedGenericEvent[reconcile.Request]
+}
+
+func newTimerWheel() *timerWheel {
+	slots := make([]map[types.NamespacedName]struct{}, timerWheelSlots)
+	for i := range slots {
+		slots[i] = make(map[types.NamespacedName]struct{})
+	}
+	return &timerWheel{
+		slots:   slots,
+		pending: make(map[types.NamespacedName]timerWheelEntry),
+		fired:   make(chan event.TypedGenericEvent[reconcile.Request], timerWheelSlots),
+	}
+}
+
+// Schedule fires key after delay, rounded up to the next tick. If key is already pending, the
+// earlier of the two deadlines is kept.
+func (w *timerWheel) Schedule(key types.NamespacedName, delay time.Duration) {
+	ticks := max(int((delay+timerWheelTick-1)/timerWheelTick), 1)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if entry, ok := w.pending[key]; ok {
+		if w.ticksUntilLocked(entry) <= ticks {
+			return
+		}
+		delete(w.slots[entry.slot], key)
+	}
+
+	entry := timerWheelEntry{
+		slot:   (w.cursor + ticks) % timerWheelSlots,
+		rounds: (ticks - 1) / timerWheelSlots,
+	}
+	w.slots[entry.slot][key] = struct{}{}
+	w.pending[key] = entry
+	timerWheelPending.Set(float64(len(w.pending)))
+}
+
+// Cancel drops the pending timer of key, if any
+func (w *timerWheel) Cancel(key types.NamespacedName) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if entry, ok := w.pending[key]; ok {
+		delete(w.slots[entry.slot], key)
+		delete(w.pending, key)
+		timerWheelPending.Set(float64(len(w.pending)))
+	}
+}
+
+// ticksUntilLocked returns the number of ticks before entry fires. Callers must hold w.mu.
+func (w *timerWheel) ticksUntilLocked(entry timerWheelEntry) int {
+	ticks := (entry.slot - w.cursor + timerWheelSlots) % timerWheelSlots
+	if ticks == 0 {
+		ticks = timerWheelSlots
+	}
+	return ticks + entry.rounds*timerWheelSlots
+}
+
+// Events returns the channel the due keys are delivered on
+func (w *timerWheel) Events() <-chan event.TypedGenericEvent[reconcile.Request] {
+	return w.fired
+}
+
+// Start advances the wheel until ctx is cancelled
+func (w *timerWheel) Start(ctx context.Context) error {
+	ticker := time.NewTicker(timerWheelTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			for _, key := range w.advance() {
+				select {
+				case w.fired <- event.TypedGenericEvent[reconcile.Request]{Object: reconcile.Request{NamespacedName: key}}:
+					timerWheelFiredTotal.Inc()
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// advance moves the cursor one slot and returns the keys that are due
+func (w *timerWheel) advance() []types.NamespacedName {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.cursor = (w.cursor + 1) % timerWheelSlots
+	var due []types.NamespacedName
+	for key := range w.slots[w.cursor] {
+		entry := w.pending[key]
+		if entry.rounds > 0 {
+			entry.rounds--
+			w.pending[key] = entry
+			continue
+		}
+		delete(w.slots[w.cursor], key)
+		delete(w.pending, key)
+		due = append(due, key)
+	}
+	timerWheelPending.Set(float64(len(w.pending)))
+	return due
+}
+
+// retryLater schedules another look at a pod after a failed delivery or resolution. In
+// requeue mode this is a controller requeue; in event-driven mode the timer wheel fires it, so
+// retries of the same pod coalesce and the workqueue only ever holds pods with news.
+func (r *PodReconciler) retryLater(key types.NamespacedName) ctrl.Result {
+	if r.retries == nil {
+		podRetriesTotal.WithLabelValues(config.DetectionRequeue).Inc()
+		return ctrl.Result{RequeueAfter: r.Config.Detection.RetryInterval.Duration}
+	}
+
+	podRetriesTotal.WithLabelValues(config.DetectionEventDriven).Inc()
+	r.retries.Schedule(key, r.Config.Detection.RetryInterval.Duration)
+	return ctrl.Result{}
+}
+
+// recheckAfter schedules another look at a pod once something time-based, such as a reminder,
+// becomes due
+func (r *PodReconciler) recheckAfter(key types.NamespacedName, delay time.Duration) ctrl.Result {
+	if r.retries == nil {
+		return ctrl.Result{RequeueAfter: delay}
+	}
+	r.retries.Schedule(key, delay)
+	return ctrl.Result{}
+}
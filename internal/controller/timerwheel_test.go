@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TestTimerWheelFiresOnTick checks the tick each timer fires on, counted from the last
+// Schedule call, across revolution boundaries, cancellation and rescheduling
+func TestTimerWheelFiresOnTick(t *testing.T) {
+	revolution := time.Duration(timerWheelSlots) * timerWheelTick
+
+	tests := []struct {
+		name string
+		// offset advances the wheel before scheduling, so the timer wraps around the slots
+		offset int
+		// delays are scheduled in order for the same key
+		delays []time.Duration
+		cancel bool
+		// want is the tick the key fires on; 0 means it never fires
+		want int
+	}{
+		{name: "zero delay fires on the next tick", delays: []time.Duration{0}, want: 1},
+		{name: "partial tick rounds up", delays: []time.Duration{1500 * time.Millisecond}, want: 2},
+		{name: "shorter than a revolution", delays: []time.Duration{5 * time.Second}, want: 5},
+		{name: "shorter than a revolution wrapping around", offset: 500, delays: []time.Duration{20 * time.Second}, want: 20},
+		{name: "last tick of a revolution", delays: []time.Duration{revolution - timerWheelTick}, want: timerWheelSlots - 1},
+		{name: "exactly one revolution", delays: []time.Duration{revolution}, want: timerWheelSlots},
+		{name: "exactly one revolution after wrapping", offset: 100, delays: []time.Duration{revolution}, want: timerWheelSlots},
+		{name: "one tick past a revolution", delays: []time.Duration{revolution + timerWheelTick}, want: timerWheelSlots + 1},
+		{name: "several revolutions", delays: []time.Duration{3*revolution + 7*timerWheelTick}, want: 3*timerWheelSlots + 7},
+		{name: "several revolutions wrapping around", offset: 300, delays: []time.Duration{2*revolution + 250*timerWheelTick}, want: 2*timerWheelSlots + 250},
+		{name: "cancelled", delays: []time.Duration{10 * time.Second}, cancel: true},
+		{name: "cancelled after several revolutions", delays: []time.Duration{2*revolution + 10*timerWheelTick}, cancel: true},
+		{name: "rescheduled earlier", delays: []time.Duration{100 * time.Second, 10 * time.Second}, want: 10},
+		{name: "rescheduled later keeps the earlier deadline", delays: []time.Duration{10 * time.Second, 100 * time.Second}, want: 10},
+		{name: "rescheduled into an earlier revolution", delays: []time.Duration{2 * revolution, 600 * time.Second}, want: 600},
+		{name: "rescheduled with the same delay", delays: []time.Duration{30 * time.Second, 30 * time.Second}, want: 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newTimerWheel()
+			for range tt.offset {
+				w.advance()
+			}
+
+			key := types.NamespacedName{Namespace: "default", Name: "pod"}
+			for _, delay := range tt.delays {
+				w.Schedule(key, delay)
+			}
+			if tt.cancel {
+				w.Cancel(key)
+			}
+
+			fired := 0
+			for tick := 1; tick <= 4*timerWheelSlots; tick++ {
+				for _, due := range w.advance() {
+					if due != key {
+						t.Fatalf("tick %d fired unexpected key %s", tick, due)
+					}
+					if fired != 0 {
+						t.Fatalf("key fired again on tick %d after tick %d", tick, fired)
+					}
+					fired = tick
+				}
+			}
+			if fired != tt.want {
+				t.Errorf("key fired on tick %d, want %d", fired, tt.want)
+			}
+			if len(w.pending) != 0 {
+				t.Errorf("%d timers still pending", len(w.pending))
+			}
+		})
+	}
+}
+
+// BenchmarkTimerWheelSchedule measures scheduling retries for a large number of failing pods
+func BenchmarkTimerWheelSchedule(b *testing.B) {
+	w := newTimerWheel()
+	keys := make([]types.NamespacedName, 10000)
+	for i := range keys {
+		keys[i] = types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("pod-%d", i)}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.Schedule(keys[i%len(keys)], time.Duration(i%900)*time.Second)
+	}
+}
+
+// BenchmarkTimerWheelAdvance measures a tick of a wheel holding 100000 pending pods
+func BenchmarkTimerWheelAdvance(b *testing.B) {
+	w := newTimerWheel()
+	for i := 0; i < 100000; i++ {
+		w.Schedule(types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("pod-%d", i)},
+			time.Duration(timerWheelSlots+i%timerWheelSlots)*timerWheelTick)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.advance()
+	}
+}
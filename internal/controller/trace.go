@@ -0,0 +1,57 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Verdicts of the decision log
+const (
+	DecisionNotFailing = "not_failing"
+	DecisionSuppressed = "suppressed"
+	DecisionHeld       = "held"
+	DecisionSent       = "sent"
+	DecisionSendFailed = "send_failed"
+)
+
+// alertTrace collects the checks applied to an alert and the routing decision, so admins can
+// see from the alert itself why it was sent where it went
+type alertTrace []string
+
+// add records a step of the decision
+func (t *alertTrace) add(format string, args ...any) {
+	*t = append(*t, fmt.Sprintf(format, args...))
+}
+
+// logDecision logs the verdict of an alert evaluation with the trace that led to it, so admins
+// can find out why a pod was or was not alerted on. Nothing is logged unless decision logging
+// is enabled.
+func (r *PodReconciler) logDecision(pod *corev1.Pod, reason, verdict string, trace alertTrace, keysAndValues ...any) {
+	if r.Decisions.GetSink() == nil {
+		return
+	}
+	r.Decisions.Info("Alert decision", append([]any{
+		"pod", pod.Name,
+		"namespace", pod.Namespace,
+		"reason", reason,
+		"verdict", verdict,
+		"trace", []string(trace),
+	}, keysAndValues...)...)
+}
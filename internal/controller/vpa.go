@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// vpaListGVK is the Vertical Pod Autoscaler list kind. The VPA CRDs are optional, so VPAs
+// are read as unstructured objects rather than through the VPA client library.
+var vpaListGVK = schema.GroupVersionKind{
+	Group:   "autoscaling.k8s.io",
+	Version: "v1",
+	Kind:    "VerticalPodAutoscalerList",
+}
+
+// +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;list;watch
+
+// sizingReason reports whether a failure reason points at undersized resources, so the
+// workload's VPA recommendation is an actionable hint
+func sizingReason(reason string) bool {
+	return reason == "OOMKilled"
+}
+
+// vpaRecommendation describes the recommendation of the VPA targeting the pod's workload for
+// the given container, next to the container's current requests and limits. It returns an
+// empty string when no VPA targets the workload, the VPA has no recommendation yet, or the
+// VPA CRDs are not installed.
+func (r *PodReconciler) vpaRecommendation(ctx context.Context, pod *corev1.Pod, container string) (string, error) {
+	owner, err := r.resolveOwner(ctx, pod)
+	if err != nil || owner == nil {
+		return "", err
+	}
+
+	vpas := &unstructured.UnstructuredList{}
+	vpas.SetGroupVersionKind(vpaListGVK)
+	if err := r.List(ctx, vpas, client.InNamespace(pod.Namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	for _, vpa := range vpas.Items {
+		kind, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "kind")
+		name, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "name")
+		if kind != owner.Kind || name != owner.Name {
+			continue
+		}
+
+		recommendations, _, _ := unstructured.NestedSlice(vpa.Object, "status", "recommendation", "containerRecommendations")
+		for _, item := range recommendations {
+			rec, ok := item.(map[string]interface{})
+			if !ok || rec["containerName"] != container {
+				continue
+			}
+			return fmt.Sprintf("VPA %s recommends for container %s: %s\nCurrent: %s",
+				vpa.GetName(), container, formatRecommendation(rec), formatCurrentResources(pod, container)), nil
+		}
+	}
+	return "", nil
+}
+
+// formatRecommendation renders the target and bounds of a VPA container recommendation
+func formatRecommendation(rec map[string]interface{}) string {
+	var parts []string
+	for _, resource := range []string{"memory", "cpu"} {
+		target, found, _ := unstructured.NestedString(rec, "target", resource)
+		if !found {
+			continue
+		}
+		part := fmt.Sprintf("%s %s", resource, target)
+		lower, hasLower, _ := unstructured.NestedString(rec, "lowerBound", resource)
+		upper, hasUpper, _ := unstructured.NestedString(rec, "upperBound", resource)
+		if hasLower && hasUpper {
+			part += fmt.Sprintf(" (range %s–%s)", lower, upper)
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return "no target yet"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatCurrentResources renders the requests and limits of a container in the pod spec
+func formatCurrentResources(pod *corev1.Pod, container string) string {
+	for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if c.Name != container {
+			continue
+		}
+		var parts []string
+		for _, resource := range []corev1.ResourceName{corev1.ResourceMemory, corev1.ResourceCPU} {
+			request, hasRequest := c.Resources.Requests[resource]
+			limit, hasLimit := c.Resources.Limits[resource]
+			switch {
+			case hasRequest && hasLimit:
+				parts = append(parts, fmt.Sprintf("%s request %s, limit %s", resource, request.String(), limit.String()))
+			case hasRequest:
+				parts = append(parts, fmt.Sprintf("%s request %s, no limit", resource, request.String()))
+			case hasLimit:
+				parts = append(parts, fmt.Sprintf("%s limit %s", resource, limit.String()))
+			}
+		}
+		if len(parts) > 0 {
+			return strings.Join(parts, "; ")
+		}
+	}
+	return "no requests or limits"
+}
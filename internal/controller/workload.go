@@ -0,0 +1,195 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/alertkey"
+)
+
+// aggregationStale is how long after the hold ended an aggregation entry is still honoured.
+// Older entries belong to an earlier incident whose alert was never sent, e.g. because the
+// pods recovered during the hold.
+const aggregationStale = time.Minute
+
+// aggregationWait returns how much longer the first alert of a workload incident is held so
+// that replicas failing at about the same time are counted in it. The hold starts the first
+// time it is asked for.
+func (r *PodReconciler) aggregationWait(alertKey string, now time.Time) time.Duration {
+	delay := r.Config.Debounce.WorkloadDelay.Duration
+
+	r.alertCacheMux.Lock()
+	defer r.alertCacheMux.Unlock()
+
+	since, exists := r.aggregating[alertKey]
+	if !exists || now.Sub(since) > delay+aggregationStale {
+		r.aggregating[alertKey] = now
+		return delay
+	}
+	return delay - now.Sub(since)
+}
+
+// aggregationDone ends the hold of a workload incident once its alert was sent
+func (r *PodReconciler) aggregationDone(alertKey string) {
+	r.alertCacheMux.Lock()
+	defer r.alertCacheMux.Unlock()
+
+	delete(r.aggregating, alertKey)
+}
+
+// workloadPods returns the pods in the namespace whose top-level owner is the workload
+func (r *PodReconciler) workloadPods(ctx context.Context, workload *metav1.PartialObjectMetadata) ([]corev1.Pod, error) {
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.InNamespace(workload.Namespace)); err != nil {
+		return nil, err
+	}
+
+	// Replicas share their controller, so each controller is resolved once
+	owners := make(map[types.UID]types.UID)
+	var owned []corev1.Pod
+	for _, pod := range pods.Items {
+		ref := metav1.GetControllerOf(&pod)
+		if ref == nil {
+			continue
+		}
+		ownerUID, resolved := owners[ref.UID]
+		if !resolved {
+			owner, err := r.resolveOwner(ctx, &pod)
+			if err != nil {
+				return nil, err
+			}
+			if owner != nil {
+				ownerUID = owner.UID
+			}
+			owners[ref.UID] = ownerUID
+		}
+		if ownerUID == workload.UID {
+			owned = append(owned, pod)
+		}
+	}
+	return owned, nil
+}
+
+// workloadSummary counts the workload's pods failing with the reason, e.g.
+// "📦 20/20 pods of Deployment payments-api are ImagePullBackOff"
+func (r *PodReconciler) workloadSummary(ctx context.Context, pod *corev1.Pod, workload *metav1.PartialObjectMetadata, reason string) (string, error) {
+	pods, err := r.workloadPods(ctx, workload)
+	if err != nil {
+		return "", err
+	}
+
+	failing := 0
+	for i := range pods {
+		if pods[i].UID == pod.UID {
+			// The alerting pod counts even when its reason comes from events, e.g. a hook failure
+			failing++
+			continue
+		}
+		if shouldAlert, podReason := r.shouldAlertForPod(&pods[i]); shouldAlert && podReason == reason {
+			failing++
+		}
+	}
+	return fmt.Sprintf("📦 %d/%d pods of %s %s are %s", failing, max(len(pods), failing), workload.Kind, workload.Name, reason), nil
+}
+
+// hasOpenWorkloadAlerts reports whether any workload-level alert in the namespace is still
+// unresolved
+func (r *PodReconciler) hasOpenWorkloadAlerts(namespace string) bool {
+	r.alertCacheMux.RLock()
+	defer r.alertCacheMux.RUnlock()
+
+	for key, alert := range r.openAlerts {
+		if alert.Namespace == namespace && isWorkloadAlert(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveWorkloadAlerts resolves the workload-level alerts in the namespace whose workload
+// has no failing pod left
+func (r *PodReconciler) resolveWorkloadAlerts(ctx context.Context, namespace string) error {
+	workloads := make(map[string]*metav1.PartialObjectMetadata)
+	r.alertCacheMux.RLock()
+	for key, alert := range r.openAlerts {
+		if alert.Namespace == namespace && isWorkloadAlert(key) {
+			workload := &metav1.PartialObjectMetadata{}
+			workload.Kind, workload.Namespace, workload.Name = alert.WorkloadKind, alert.Namespace, alert.WorkloadName
+			prefix := alertkey.OwnerPrefix(r.Config.Cluster, alert.Namespace, alert.WorkloadKind, alert.WorkloadName)
+			workloads[prefix] = workload
+		}
+	}
+	r.alertCacheMux.RUnlock()
+
+	for prefix, workload := range workloads {
+		failing, err := r.workloadFailing(ctx, workload)
+		if err != nil {
+			return err
+		}
+		if failing {
+			continue
+		}
+		if err := r.resolveAlerts(ctx, prefix); err != nil {
+			return err
+		}
+		logf.FromContext(ctx).V(1).Info("Workload recovered",
+			"workload", workload.Name,
+			"kind", workload.Kind,
+			"namespace", workload.Namespace,
+		)
+	}
+	return nil
+}
+
+// workloadFailing reports whether any pod of the workload still warrants an alert. Pods are
+// matched by their owner's kind and name, since the alert does not record the owner's UID.
+func (r *PodReconciler) workloadFailing(ctx context.Context, workload *metav1.PartialObjectMetadata) (bool, error) {
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.InNamespace(workload.Namespace)); err != nil {
+		return false, err
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if shouldAlert, _ := r.shouldAlertForPod(pod); !shouldAlert {
+			continue
+		}
+		owner, err := r.resolveOwner(ctx, pod)
+		if err != nil {
+			return false, err
+		}
+		if owner != nil && owner.Kind == workload.Kind && owner.Name == workload.Name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isWorkloadAlert reports whether an alert key is keyed by the workload rather than the pod
+func isWorkloadAlert(key string) bool {
+	parsed, err := alertkey.Parse(key)
+	return err == nil && parsed.IsWorkload()
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cost looks up what running a workload costs from the OpenCost or Kubecost
+// allocation API, so alerts can show the financial impact of a failure.
+package cost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+)
+
+// cacheTTL is how long a workload's cost is reused before it is queried again. Allocation
+// data is aggregated over hours, so fresher values would not differ.
+const cacheTTL = 15 * time.Minute
+
+// allocation is the subset of an OpenCost allocation we use
+type allocation struct {
+	Name      string  `json:"name"`
+	TotalCost float64 `json:"totalCost"`
+	Minutes   float64 `json:"minutes"`
+}
+
+// allocationResponse is the response of the allocation API, one map per step of the window
+type allocationResponse struct {
+	Code    int                     `json:"code"`
+	Message string                  `json:"message,omitempty"`
+	Data    []map[string]allocation `json:"data"`
+}
+
+// cachedCost is a looked up hourly cost
+type cachedCost struct {
+	hourly    float64
+	found     bool
+	fetchedAt time.Time
+}
+
+// Client queries the allocation API of OpenCost, or of Kubecost which serves the same API
+// under /model
+type Client struct {
+	baseURL    string
+	window     string
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	cache map[string]cachedCost
+}
+
+// NewClient creates a client for the configured allocation API
+func NewClient(cfg config.CostConfig) *Client {
+	return &Client{
+		baseURL:    strings.TrimSuffix(cfg.URL, "/"),
+		window:     fmt.Sprintf("%dm", max(int(cfg.Window.Minutes()), 60)),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		cache:      make(map[string]cachedCost),
+	}
+}
+
+// HourlyCost returns the average hourly cost of a workload over the configured window. The
+// boolean is false when the allocation API has no data for the workload.
+func (c *Client) HourlyCost(ctx context.Context, namespace, kind, name string) (float64, bool, error) {
+	key := namespace + "/" + strings.ToLower(kind) + "/" + name
+
+	c.mu.Lock()
+	cached, ok := c.cache[key]
+	c.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < cacheTTL {
+		return cached.hourly, cached.found, nil
+	}
+
+	hourly, found, err := c.query(ctx, namespace, kind, name)
+	if err != nil {
+		return 0, false, err
+	}
+
+	c.mu.Lock()
+	now := time.Now()
+	for k, entry := range c.cache {
+		if now.Sub(entry.fetchedAt) >= cacheTTL {
+			delete(c.cache, k)
+		}
+	}
+	c.cache[key] = cachedCost{hourly: hourly, found: found, fetchedAt: now}
+	c.mu.Unlock()
+
+	return hourly, found, nil
+}
+
+// query fetches the allocations of a namespace aggregated by controller and sums up those of
+// the workload
+func (c *Client) query(ctx context.Context, namespace, kind, name string) (float64, bool, error) {
+	query := url.Values{}
+	query.Set("window", c.window)
+	query.Set("aggregate", "controller")
+	query.Set("accumulate", "true")
+	query.Set("filter", fmt.Sprintf("namespace:%q", namespace))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/allocation?"+query.Encode(), nil)
+	if err != nil {
+		return 0, false, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to query cost allocation API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, false, fmt.Errorf("cost allocation API returned status code: %d", resp.StatusCode)
+	}
+
+	var body allocationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, false, fmt.Errorf("failed to decode cost allocation response: %w", err)
+	}
+	if body.Code != 0 && body.Code != http.StatusOK {
+		return 0, false, fmt.Errorf("cost allocation API returned error: %s", body.Message)
+	}
+
+	// Controllers are keyed as kind:name, or by name alone in older versions
+	qualified := strings.ToLower(kind) + ":" + name
+	var total, minutes float64
+	for _, step := range body.Data {
+		for key, alloc := range step {
+			if key != qualified && key != name {
+				continue
+			}
+			total += alloc.TotalCost
+			minutes += alloc.Minutes
+		}
+	}
+	if minutes == 0 {
+		return 0, false, nil
+	}
+	return total / minutes * 60, true, nil
+}
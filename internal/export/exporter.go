@@ -0,0 +1,208 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package export periodically exports alert history for retention beyond Slack scrollback.
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/alertkey"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/store"
+)
+
+const (
+	// exportKey records the end of the last export in the state store, so restarts neither
+	// repeat nor skip entries
+	exportKey = alertkey.HistoryExport
+	// namePrefix starts the file name of every export
+	namePrefix = "slackgenie-history-"
+	// nameTime formats the end of the exported period in file names
+	nameTime = "20060102T150405Z"
+	// finalExportTimeout bounds the export on shutdown
+	finalExportTimeout = 30 * time.Second
+)
+
+// target stores exports under slash-separated names
+type target interface {
+	put(ctx context.Context, name string, data []byte) error
+	list(ctx context.Context) ([]string, error)
+	remove(ctx context.Context, name string) error
+}
+
+// Exporter writes the alert history sent since the previous export to a directory or a bucket,
+// one file per interval, and deletes exports older than the retention
+type Exporter struct {
+	Store  store.Store
+	Config config.HistoryExportConfig
+
+	target target
+}
+
+// New returns an exporter for the configured destination
+func New(st store.Store, cfg config.HistoryExportConfig) (*Exporter, error) {
+	e := &Exporter{Store: st, Config: cfg}
+	if cfg.S3 != nil {
+		t, err := newS3Target(*cfg.S3)
+		if err != nil {
+			return nil, err
+		}
+		e.target = t
+	} else {
+		e.target = dirTarget(cfg.Path)
+	}
+	return e, nil
+}
+
+// NeedLeaderElection ensures only the leader exports, so entries are not exported twice
+func (e *Exporter) NeedLeaderElection() bool {
+	return true
+}
+
+// Start exports every interval until the context is cancelled, then exports once more so
+// entries sent since the last tick are not left to the next leader
+func (e *Exporter) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("history-export")
+	ticker := time.NewTicker(e.Config.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			finalCtx, cancel := context.WithTimeout(context.Background(), finalExportTimeout)
+			defer cancel()
+			if err := e.run(finalCtx, time.Now()); err != nil {
+				log.Error(err, "Final history export failed")
+			}
+			return nil
+		case now := <-ticker.C:
+			if err := e.run(ctx, now); err != nil {
+				log.Error(err, "History export failed")
+			}
+		}
+	}
+}
+
+// run exports the entries recorded after the previous export up to now, then prunes
+func (e *Exporter) run(ctx context.Context, now time.Time) error {
+	since, _, err := e.Store.LastAlert(ctx, exportKey)
+	if err != nil {
+		return err
+	}
+
+	history, err := e.Store.History(ctx, 0)
+	if err != nil {
+		return err
+	}
+	var entries []store.HistoryEntry
+	for _, entry := range history {
+		if entry.Timestamp.After(since) && !entry.Timestamp.After(now) {
+			entries = append(entries, entry)
+		}
+	}
+
+	if len(entries) > 0 {
+		// History is newest first; exports read oldest first
+		slices.Reverse(entries)
+		data, err := e.encode(entries)
+		if err != nil {
+			return err
+		}
+		if err := e.target.put(ctx, e.name(now), data); err != nil {
+			return fmt.Errorf("writing export: %w", err)
+		}
+		logf.FromContext(ctx).V(1).Info("Exported alert history", "entries", len(entries))
+	}
+	if err := e.Store.RecordAlert(ctx, exportKey, now); err != nil {
+		return err
+	}
+	return e.prune(ctx, now)
+}
+
+// name is the export's name, grouped by day so directories and bucket listings stay browsable
+func (e *Exporter) name(end time.Time) string {
+	end = end.UTC()
+	ext := ".jsonl"
+	if e.Config.Format == config.ExportFormatCSV {
+		ext = ".csv"
+	}
+	return path.Join(end.Format("2006/01/02"), namePrefix+end.Format(nameTime)+ext)
+}
+
+// encode renders entries as JSON Lines or CSV
+func (e *Exporter) encode(entries []store.HistoryEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	if e.Config.Format == config.ExportFormatCSV {
+		w := csv.NewWriter(&buf)
+		_ = w.Write([]string{"timestamp", "key", "namespace", "pod", "reason", "channel"})
+		for _, entry := range entries {
+			_ = w.Write([]string{
+				entry.Timestamp.UTC().Format(time.RFC3339), entry.Key, entry.Namespace,
+				entry.Pod, entry.Reason, entry.Channel,
+			})
+		}
+		w.Flush()
+		return buf.Bytes(), w.Error()
+	}
+
+	enc := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// prune deletes exports whose period ended before the retention
+func (e *Exporter) prune(ctx context.Context, now time.Time) error {
+	if e.Config.Retention.Duration == 0 {
+		return nil
+	}
+	names, err := e.target.list(ctx)
+	if err != nil {
+		return fmt.Errorf("listing exports: %w", err)
+	}
+
+	cutoff := now.Add(-e.Config.Retention.Duration)
+	for _, name := range names {
+		base := path.Base(name)
+		if !strings.HasPrefix(base, namePrefix) {
+			continue
+		}
+		stamp := strings.TrimPrefix(base, namePrefix)
+		stamp = strings.TrimSuffix(stamp, path.Ext(stamp))
+		end, err := time.Parse(nameTime, stamp)
+		if err != nil || !end.Before(cutoff) {
+			continue
+		}
+		if err := e.target.remove(ctx, name); err != nil {
+			return fmt.Errorf("deleting export %s: %w", name, err)
+		}
+	}
+	return nil
+}
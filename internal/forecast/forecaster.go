@@ -0,0 +1,327 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package forecast predicts node pool capacity exhaustion from utilization trends.
+package forecast
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/store"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+const (
+	// sampleRetention bounds how much utilization history is used for trends
+	sampleRetention = 7 * 24 * time.Hour
+	// minSamples is the minimum number of samples before a trend is trusted
+	minSamples = 3
+	// warningCooldown avoids repeating the same pool warning more than once a day
+	warningCooldown = 24 * time.Hour
+	// defaultPool groups nodes without any of the configured pool labels
+	defaultPool = "default"
+)
+
+// sample is a point-in-time utilization measurement of a node pool
+type sample struct {
+	at          time.Time
+	utilization float64
+}
+
+// poolUsage aggregates allocatable resources and pod requests for a node pool
+type poolUsage struct {
+	nodes       int
+	cpuAlloc    int64
+	memAlloc    int64
+	cpuRequests int64
+	memRequests int64
+	evicted     int
+}
+
+// utilization is the larger of the CPU and memory request ratios
+func (u poolUsage) utilization() float64 {
+	var cpu, mem float64
+	if u.cpuAlloc > 0 {
+		cpu = float64(u.cpuRequests) / float64(u.cpuAlloc)
+	}
+	if u.memAlloc > 0 {
+		mem = float64(u.memRequests) / float64(u.memAlloc)
+	}
+	return max(cpu, mem)
+}
+
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+
+// Forecaster periodically samples node pool utilization and warns when the trend predicts
+// that a pool will become unschedulable within the configured horizon
+type Forecaster struct {
+	Client   client.Client
+	Notifier notify.Notifier
+	Store    store.Store
+	Config   config.ForecastConfig
+
+	samples    map[string][]sample
+	lastWarned map[string]time.Time
+	lastReport time.Time
+}
+
+// NeedLeaderElection ensures only the leader posts forecasts
+func (f *Forecaster) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs the sampling loop until the context is cancelled
+func (f *Forecaster) Start(ctx context.Context) error {
+	f.samples = make(map[string][]sample)
+	f.lastWarned = make(map[string]time.Time)
+	f.lastReport = time.Now()
+
+	ticker := time.NewTicker(f.Config.SampleInterval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			if err := f.run(ctx, now); err != nil {
+				logf.FromContext(ctx).Error(err, "Capacity forecast failed")
+			}
+		}
+	}
+}
+
+// run takes a sample and posts warnings and the periodic report when due
+func (f *Forecaster) run(ctx context.Context, now time.Time) error {
+	usage, err := f.collect(ctx)
+	if err != nil {
+		return err
+	}
+
+	for pool, u := range usage {
+		samples := append(f.samples[pool], sample{at: now, utilization: u.utilization()})
+		for len(samples) > 0 && now.Sub(samples[0].at) > sampleRetention {
+			samples = samples[1:]
+		}
+		f.samples[pool] = samples
+	}
+
+	signals, err := f.recentSignals(ctx, now)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to read alert history for forecast")
+	}
+
+	for pool, u := range usage {
+		eta, ok := f.timeToFull(pool, u.utilization())
+		if !ok || eta > f.Config.Horizon.Duration || now.Sub(f.lastWarned[pool]) < warningCooldown {
+			continue
+		}
+
+		alert := notify.MetaAlert{
+			Title: fmt.Sprintf("Node pool %s predicted to become unschedulable", pool),
+			Text: fmt.Sprintf("At current pod growth, node pool *%s* will be unschedulable within ~%s.\n"+
+				"Requests are at %.0f%% of allocatable across %d node(s), %d evicted pod(s) in the pool.\n%s",
+				pool, humanizeDuration(eta), u.utilization()*100, u.nodes, u.evicted, signals),
+			Severity:  notify.SeverityWarning,
+			Channel:   f.Config.Channel,
+			Timestamp: now,
+		}
+		if err := f.Notifier.SendMetaAlert(alert); err != nil {
+			return err
+		}
+		f.lastWarned[pool] = now
+	}
+
+	if now.Sub(f.lastReport) >= f.Config.ReportInterval.Duration {
+		if err := f.Notifier.SendMetaAlert(f.report(usage, signals, now)); err != nil {
+			return err
+		}
+		f.lastReport = now
+	}
+
+	return nil
+}
+
+// collect aggregates allocatable resources and pod requests by node pool
+func (f *Forecaster) collect(ctx context.Context) (map[string]poolUsage, error) {
+	var nodes corev1.NodeList
+	if err := f.Client.List(ctx, &nodes); err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]poolUsage)
+	nodePool := make(map[string]string, len(nodes.Items))
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		pool := f.poolOf(&node)
+		nodePool[node.Name] = pool
+
+		u := usage[pool]
+		u.nodes++
+		u.cpuAlloc += node.Status.Allocatable.Cpu().MilliValue()
+		u.memAlloc += node.Status.Allocatable.Memory().Value()
+		usage[pool] = u
+	}
+
+	var pods corev1.PodList
+	if err := f.Client.List(ctx, &pods); err != nil {
+		return nil, err
+	}
+
+	for _, pod := range pods.Items {
+		pool, scheduled := nodePool[pod.Spec.NodeName]
+		if !scheduled {
+			continue
+		}
+
+		u := usage[pool]
+		if pod.Status.Reason == "Evicted" {
+			u.evicted++
+		}
+		if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			cpu, mem := podRequests(&pod)
+			u.cpuRequests += cpu
+			u.memRequests += mem
+		}
+		usage[pool] = u
+	}
+
+	return usage, nil
+}
+
+// poolOf returns the node pool a node belongs to
+func (f *Forecaster) poolOf(node *corev1.Node) string {
+	for _, label := range f.Config.NodePoolLabels {
+		if pool := node.Labels[label]; pool != "" {
+			return pool
+		}
+	}
+	return defaultPool
+}
+
+// timeToFull extrapolates the utilization trend of a pool to 100% using a least squares fit
+func (f *Forecaster) timeToFull(pool string, current float64) (time.Duration, bool) {
+	samples := f.samples[pool]
+	if len(samples) < minSamples {
+		return 0, false
+	}
+
+	origin := samples[0].at
+	var sumX, sumY, sumXY, sumXX float64
+	for _, s := range samples {
+		x := s.at.Sub(origin).Hours()
+		sumX += x
+		sumY += s.utilization
+		sumXY += x * s.utilization
+		sumXX += x * x
+	}
+
+	n := float64(len(samples))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0, false
+	}
+
+	slopePerHour := (n*sumXY - sumX*sumY) / denominator
+	if slopePerHour <= 0 {
+		return 0, false
+	}
+	if current >= 1 {
+		return 0, true
+	}
+
+	return time.Duration((1 - current) / slopePerHour * float64(time.Hour)), true
+}
+
+// recentSignals summarizes scheduling failures and evictions from the alert history
+func (f *Forecaster) recentSignals(ctx context.Context, now time.Time) (string, error) {
+	history, err := f.Store.History(ctx, 0)
+	if err != nil {
+		return "", err
+	}
+
+	var failedScheduling, evictions int
+	for _, entry := range history {
+		if now.Sub(entry.Timestamp) > sampleRetention {
+			break
+		}
+		switch entry.Reason {
+		case "FailedScheduling":
+			failedScheduling++
+		case "Evicted":
+			evictions++
+		}
+	}
+
+	return fmt.Sprintf("Last 7 days: %d FailedScheduling alert(s), %d eviction alert(s).", failedScheduling, evictions), nil
+}
+
+// report renders the periodic summary of all node pools
+func (f *Forecaster) report(usage map[string]poolUsage, signals string, now time.Time) notify.MetaAlert {
+	pools := make([]string, 0, len(usage))
+	for pool := range usage {
+		pools = append(pools, pool)
+	}
+	sort.Strings(pools)
+
+	var b strings.Builder
+	for _, pool := range pools {
+		u := usage[pool]
+		trend := "stable or shrinking"
+		if eta, ok := f.timeToFull(pool, u.utilization()); ok {
+			trend = "full in ~" + humanizeDuration(eta)
+		}
+		fmt.Fprintf(&b, "• *%s*: %.0f%% requested, %d node(s), %s\n", pool, u.utilization()*100, u.nodes, trend)
+	}
+	b.WriteString(signals)
+
+	return notify.MetaAlert{
+		Title:     "Weekly node pool capacity forecast",
+		Text:      b.String(),
+		Severity:  notify.SeverityInfo,
+		Channel:   f.Config.Channel,
+		Timestamp: now,
+	}
+}
+
+// podRequests sums the CPU (millicores) and memory (bytes) requests of a pod's containers
+func podRequests(pod *corev1.Pod) (int64, int64) {
+	var cpu, mem int64
+	for _, container := range pod.Spec.Containers {
+		cpu += container.Resources.Requests.Cpu().MilliValue()
+		mem += container.Resources.Requests.Memory().Value()
+	}
+	return cpu, mem
+}
+
+// humanizeDuration renders a duration in hours or days
+func humanizeDuration(d time.Duration) string {
+	if d < 48*time.Hour {
+		return fmt.Sprintf("%.0f hours", d.Hours())
+	}
+	return fmt.Sprintf("%.0f days", d.Hours()/24)
+}
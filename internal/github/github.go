@@ -0,0 +1,215 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package github finds open GitHub issues tracking a failure and opens new ones, so alerts for
+// known problems can link to their issue instead of starting a fresh investigation.
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// cacheTTL is how long a lookup is reused. The search API allows 30 requests per minute, so
+// a crash-looping fleet must not search on every alert.
+const cacheTTL = 5 * time.Minute
+
+// issue is the subset of a GitHub issue we use
+type issue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+}
+
+// searchResponse is the response of the issue search API
+type searchResponse struct {
+	Items []issue `json:"items"`
+}
+
+// cachedIssue is a looked up fingerprint; issue is nil when no open issue matched
+type cachedIssue struct {
+	issue     *notify.Issue
+	fetchedAt time.Time
+}
+
+// NewIssue describes a failure to open an issue for
+type NewIssue struct {
+	Fingerprint string
+	Namespace   string
+	Pod         string
+	Container   string
+	Reason      string
+	// OpenedBy names who asked for the issue, e.g. a Slack user
+	OpenedBy string
+}
+
+// Client searches and opens issues in the configured repository
+type Client struct {
+	apiURL     string
+	repository string
+	labels     []string
+	token      string
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	cache map[string]cachedIssue
+}
+
+// NewClient creates a client for the configured repository. The token needs read access to
+// issues for lookups and write access to open issues; public repositories can be searched
+// without one.
+func NewClient(cfg config.GitHubConfig, token string) *Client {
+	return &Client{
+		apiURL:     strings.TrimSuffix(cfg.APIURL, "/"),
+		repository: cfg.Repository,
+		labels:     cfg.Labels,
+		token:      token,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		cache:      make(map[string]cachedIssue),
+	}
+}
+
+// FindOpen returns the open issue whose body mentions the fingerprint, or nil if there is none
+func (c *Client) FindOpen(ctx context.Context, fingerprint string) (*notify.Issue, error) {
+	c.mu.Lock()
+	cached, ok := c.cache[fingerprint]
+	c.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < cacheTTL {
+		return cached.issue, nil
+	}
+
+	query := url.Values{}
+	query.Set("q", fmt.Sprintf("%q repo:%s is:issue is:open in:body", fingerprint, c.repository))
+	query.Set("per_page", "1")
+
+	var result searchResponse
+	if err := c.call(ctx, http.MethodGet, "/search/issues?"+query.Encode(), nil, http.StatusOK, &result); err != nil {
+		return nil, err
+	}
+
+	var found *notify.Issue
+	if len(result.Items) > 0 {
+		found = result.Items[0].toIssue()
+	}
+	c.remember(fingerprint, found)
+	return found, nil
+}
+
+// Create opens an issue for a failure, unless an open one already tracks it
+func (c *Client) Create(ctx context.Context, req NewIssue) (*notify.Issue, bool, error) {
+	if existing, err := c.FindOpen(ctx, req.Fingerprint); err != nil || existing != nil {
+		return existing, false, err
+	}
+
+	subject := req.Namespace + "/" + req.Pod
+	if req.Container != "" {
+		subject += fmt.Sprintf(" (container `%s`)", req.Container)
+	}
+	body := fmt.Sprintf("Kube-SlackGenie detected **%s** in pod `%s/%s`", req.Reason, req.Namespace, req.Pod)
+	if req.Container != "" {
+		body += fmt.Sprintf(", container `%s`", req.Container)
+	}
+	body += ".\n\n"
+	if req.OpenedBy != "" {
+		body += fmt.Sprintf("Opened from Slack by %s.\n\n", req.OpenedBy)
+	}
+	// Later alerts find this issue by searching for the fingerprint
+	body += fmt.Sprintf("Fingerprint: `%s`\n", req.Fingerprint)
+
+	payload := map[string]any{
+		"title": fmt.Sprintf("%s in %s", req.Reason, subject),
+		"body":  body,
+	}
+	if len(c.labels) > 0 {
+		payload["labels"] = c.labels
+	}
+
+	var created issue
+	if err := c.call(ctx, http.MethodPost, "/repos/"+c.repository+"/issues", payload, http.StatusCreated, &created); err != nil {
+		return nil, false, err
+	}
+	opened := created.toIssue()
+	c.remember(req.Fingerprint, opened)
+	return opened, true, nil
+}
+
+// remember caches a lookup, evicting expired entries
+func (c *Client) remember(fingerprint string, found *notify.Issue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for key, entry := range c.cache {
+		if now.Sub(entry.fetchedAt) >= cacheTTL {
+			delete(c.cache, key)
+		}
+	}
+	c.cache[fingerprint] = cachedIssue{issue: found, fetchedAt: now}
+}
+
+// call sends a request to the GitHub API and decodes the response
+func (c *Client) call(ctx context.Context, method, path string, body any, wantStatus int, out any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call GitHub API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != wantStatus {
+		return fmt.Errorf("GitHub API returned status code: %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode GitHub API response: %w", err)
+	}
+	return nil
+}
+
+func (i issue) toIssue() *notify.Issue {
+	return &notify.Issue{Number: i.Number, Title: i.Title, URL: i.HTMLURL}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package loglink serves recent container logs behind short-lived signed URLs, so responders
+// without cluster access can read the logs of a failing pod from the alert.
+package loglink
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// pathPrefix is the URL path logs are served under
+const pathPrefix = "/logs/"
+
+// maxLogBytes caps the response size regardless of the configured line count
+const maxLogBytes = 1 << 20
+
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
+
+// claims is the signed content of a log link
+type claims struct {
+	AlertID   string `json:"id"`
+	Namespace string `json:"ns"`
+	Pod       string `json:"pod"`
+	Container string `json:"c,omitempty"`
+	Previous  bool   `json:"prev,omitempty"`
+	Expires   int64  `json:"exp"`
+}
+
+// Server signs log links for alerts and serves the logs they point to. It runs on every
+// replica, so all replicas must share the signing key for links to stay valid.
+type Server struct {
+	Clientset kubernetes.Interface
+	Config    config.LogLinksConfig
+
+	key []byte
+}
+
+// minKeyBytes is the shortest signing key accepted, the output size of SHA-256
+const minKeyBytes = 32
+
+// NewServer creates a log link server. The key must be shared by all replicas and kept across
+// restarts, so it is required rather than generated: a per-process key would break links
+// served by another replica or after a restart.
+func NewServer(clientset kubernetes.Interface, cfg config.LogLinksConfig, key []byte) (*Server, error) {
+	if len(key) < minKeyBytes {
+		return nil, fmt.Errorf("log links require a signing key of at least %d bytes in LOG_LINK_SIGNING_KEY", minKeyBytes)
+	}
+
+	return &Server{
+		Clientset: clientset,
+		Config:    cfg,
+		key:       key,
+	}, nil
+}
+
+// NeedLeaderElection lets every replica serve links
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// URL returns a signed link to the logs of the alerting container. Logs of the previous
+// instance are linked when the container has restarted, since a crashed container has none.
+func (s *Server) URL(alert notify.PodAlert) (string, error) {
+	payload, err := json.Marshal(claims{
+		AlertID:   alert.Key,
+		Namespace: alert.Namespace,
+		Pod:       alert.PodName,
+		Container: alert.ContainerName,
+		Previous:  alert.RestartCount > 0,
+		Expires:   time.Now().Add(s.Config.TTL.Duration).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return strings.TrimSuffix(s.Config.ExternalURL, "/") + pathPrefix + encoded + "." + s.sign(encoded), nil
+}
+
+// sign returns the URL-safe HMAC of an encoded payload
+func (s *Server) sign(encoded string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// verify checks the signature and expiry of a token and returns its claims
+func (s *Server) verify(token string, now time.Time) (*claims, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(s.sign(encoded))) {
+		return nil, errors.New("invalid signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return nil, err
+	}
+	if now.Unix() > c.Expires {
+		return nil, errors.New("link expired")
+	}
+	return &c, nil
+}
+
+// Start serves log links until the context is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(pathPrefix, s.handleLogs)
+
+	srv := &http.Server{
+		Addr:              s.Config.BindAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	logf.FromContext(ctx).Info("Serving log links", "address", s.Config.BindAddress)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// handleLogs streams the last lines of the container referenced by a valid token
+func (s *Server) handleLogs(w http.ResponseWriter, req *http.Request) {
+	logger := logf.FromContext(req.Context())
+
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	c, err := s.verify(strings.TrimPrefix(req.URL.Path, pathPrefix), time.Now())
+	if err != nil {
+		http.Error(w, "invalid or expired link", http.StatusForbidden)
+		return
+	}
+
+	tailLines := s.Config.TailLines
+	limitBytes := int64(maxLogBytes)
+	stream, err := s.Clientset.CoreV1().Pods(c.Namespace).GetLogs(c.Pod, &corev1.PodLogOptions{
+		Container:  c.Container,
+		Previous:   c.Previous,
+		TailLines:  &tailLines,
+		LimitBytes: &limitBytes,
+		Timestamps: true,
+	}).Stream(req.Context())
+	if err != nil {
+		logger.Error(err, "Failed to fetch logs for log link",
+			"alert", c.AlertID,
+			"pod", c.Pod,
+			"namespace", c.Namespace,
+		)
+		http.Error(w, "logs are no longer available", http.StatusNotFound)
+		return
+	}
+	defer stream.Close()
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	fmt.Fprintf(w, "# %s/%s container=%s previous=%t alert=%s\n\n", c.Namespace, c.Pod, c.Container, c.Previous, c.AlertID)
+	if _, err := io.Copy(w, stream); err != nil {
+		logger.Error(err, "Failed to stream logs for log link", "alert", c.AlertID)
+	}
+}
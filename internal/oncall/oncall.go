@@ -0,0 +1,219 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package oncall looks up who is on call in PagerDuty or Opsgenie schedules, so critical
+// alerts can mention them in Slack.
+package oncall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// cacheTTL is how long an on-call lookup is reused. Shifts change rarely, and a crash-looping
+// fleet must not query the schedule on every alert.
+const cacheTTL = 5 * time.Minute
+
+// Default API endpoints
+const (
+	pagerDutyAPIURL = "https://api.pagerduty.com"
+	opsgenieAPIURL  = "https://api.opsgenie.com"
+)
+
+// UserLookup finds the Slack user ID of an email address
+type UserLookup interface {
+	LookupUserByEmail(email string) (string, error)
+}
+
+// cachedMention is a looked up schedule; mention is empty when nobody is on call
+type cachedMention struct {
+	mention   string
+	fetchedAt time.Time
+}
+
+// Resolver returns Slack mentions of the users currently on call
+type Resolver struct {
+	provider   string
+	apiURL     string
+	token      string
+	users      map[string]string
+	slackUsers UserLookup
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	cache map[string]cachedMention
+}
+
+// NewResolver creates a resolver for the configured provider. The token is a PagerDuty REST API
+// key or an Opsgenie API key with read access to schedules. Users missing from the configured
+// map are looked up in Slack by email when slackUsers is set.
+func NewResolver(cfg config.OnCallConfig, token string, slackUsers UserLookup) *Resolver {
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = pagerDutyAPIURL
+		if cfg.Provider == config.OnCallOpsgenie {
+			apiURL = opsgenieAPIURL
+		}
+	}
+	users := make(map[string]string, len(cfg.Users))
+	for email, userID := range cfg.Users {
+		users[strings.ToLower(email)] = userID
+	}
+	return &Resolver{
+		provider:   cfg.Provider,
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		token:      token,
+		users:      users,
+		slackUsers: slackUsers,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		cache:      make(map[string]cachedMention),
+	}
+}
+
+// Mention returns the Slack mention of the user on call for the schedule, or an empty string
+// if nobody is on call
+func (r *Resolver) Mention(ctx context.Context, schedule string) (string, error) {
+	r.mu.Lock()
+	cached, ok := r.cache[schedule]
+	r.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < cacheTTL {
+		return cached.mention, nil
+	}
+
+	var email string
+	var err error
+	if r.provider == config.OnCallOpsgenie {
+		email, err = r.opsgenieOnCall(ctx, schedule)
+	} else {
+		email, err = r.pagerDutyOnCall(ctx, schedule)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var mention string
+	if email != "" {
+		userID, err := r.slackUser(email)
+		if err != nil {
+			return "", fmt.Errorf("mapping on-call user %s to Slack: %w", email, err)
+		}
+		mention = "<@" + userID + ">"
+	}
+
+	r.mu.Lock()
+	r.cache[schedule] = cachedMention{mention: mention, fetchedAt: time.Now()}
+	r.mu.Unlock()
+	return mention, nil
+}
+
+// slackUser maps an email address to a Slack user ID, from the configuration or from Slack
+func (r *Resolver) slackUser(email string) (string, error) {
+	if userID, ok := r.users[strings.ToLower(email)]; ok {
+		return userID, nil
+	}
+	if r.slackUsers == nil {
+		return "", fmt.Errorf("no Slack user configured")
+	}
+	return r.slackUsers.LookupUserByEmail(email)
+}
+
+// pagerDutyOnCalls is the subset of the PagerDuty on-calls response we use
+type pagerDutyOnCalls struct {
+	OnCalls []struct {
+		EscalationLevel int `json:"escalation_level"`
+		User            struct {
+			Email string `json:"email"`
+		} `json:"user"`
+	} `json:"oncalls"`
+}
+
+// pagerDutyOnCall returns the email of the first-level on-call user of a PagerDuty schedule
+func (r *Resolver) pagerDutyOnCall(ctx context.Context, schedule string) (string, error) {
+	query := url.Values{}
+	query.Set("schedule_ids[]", schedule)
+	query.Set("include[]", "users")
+	query.Set("earliest", "true")
+
+	var result pagerDutyOnCalls
+	if err := r.get(ctx, "/oncalls?"+query.Encode(), "Token token="+r.token, &result); err != nil {
+		return "", err
+	}
+	for _, oncall := range result.OnCalls {
+		if oncall.EscalationLevel <= 1 && oncall.User.Email != "" {
+			return oncall.User.Email, nil
+		}
+	}
+	return "", nil
+}
+
+// opsgenieOnCalls is the subset of the Opsgenie on-calls response we use
+type opsgenieOnCalls struct {
+	Data struct {
+		OnCallRecipients []string `json:"onCallRecipients"`
+	} `json:"data"`
+}
+
+// opsgenieOnCall returns the username, an email address, of the user on call for an Opsgenie
+// schedule
+func (r *Resolver) opsgenieOnCall(ctx context.Context, schedule string) (string, error) {
+	query := url.Values{}
+	query.Set("scheduleIdentifierType", "id")
+	query.Set("flat", "true")
+
+	var result opsgenieOnCalls
+	if err := r.get(ctx, "/v2/schedules/"+url.PathEscape(schedule)+"/on-calls?"+query.Encode(), "GenieKey "+r.token, &result); err != nil {
+		return "", err
+	}
+	if len(result.Data.OnCallRecipients) == 0 {
+		return "", nil
+	}
+	return result.Data.OnCallRecipients[0], nil
+}
+
+// get calls the provider's API and decodes the response
+func (r *Resolver) get(ctx context.Context, path, authorization string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Accept", "application/json")
+	if r.provider == config.OnCallPagerDuty {
+		req.Header.Set("Accept", "application/vnd.pagerduty+json;version=2")
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to look up on-call schedule: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return notify.StatusError(r.provider+" API", resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode on-call schedule: %w", err)
+	}
+	return nil
+}
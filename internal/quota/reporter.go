@@ -0,0 +1,244 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package quota reports namespace ResourceQuota utilization for capacity planning.
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/alertkey"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/store"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+const (
+	// checkInterval is how often the reporter checks whether a report is due
+	checkInterval = time.Hour
+	// reportKey records the last report in the state store so restarts do not repeat it
+	reportKey = alertkey.QuotaReport
+)
+
+// podMetricsGVK is the metrics-server PodMetrics list, read as unstructured to avoid
+// depending on the metrics client
+var podMetricsGVK = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetricsList"}
+
+// +kubebuilder:rbac:groups=core,resources=resourcequotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
+
+// namespaceUsage compares quota, requests and actual usage of a namespace
+type namespaceUsage struct {
+	cpuHard, cpuRequested, cpuUsed resource.Quantity
+	memHard, memRequested, memUsed resource.Quantity
+	metrics                        bool
+}
+
+// Reporter posts a monthly report of requests vs. quota vs. actual usage per namespace
+type Reporter struct {
+	Client   client.Client
+	Notifier notify.Notifier
+	Store    store.Store
+	Config   config.QuotaReportConfig
+}
+
+// NeedLeaderElection ensures only the leader posts reports
+func (r *Reporter) NeedLeaderElection() bool {
+	return true
+}
+
+// Start checks hourly whether the monthly report is due until the context is cancelled
+func (r *Reporter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			if err := r.run(ctx, now); err != nil {
+				logf.FromContext(ctx).Error(err, "Quota report failed")
+			}
+		}
+	}
+}
+
+// run posts the report once per calendar month, on or after the configured day
+func (r *Reporter) run(ctx context.Context, now time.Time) error {
+	if now.Day() < r.Config.DayOfMonth {
+		return nil
+	}
+
+	last, found, err := r.Store.LastAlert(ctx, reportKey)
+	if err != nil {
+		return err
+	}
+	if found && last.Year() == now.Year() && last.Month() == now.Month() {
+		return nil
+	}
+
+	usage, err := r.collect(ctx)
+	if err != nil {
+		return err
+	}
+	if len(usage) == 0 {
+		return nil
+	}
+
+	if err := r.Notifier.SendMetaAlert(r.report(usage, now)); err != nil {
+		return err
+	}
+	return r.Store.RecordAlert(ctx, reportKey, now)
+}
+
+// collect aggregates quota, requests and metrics-server usage for namespaces with CPU or
+// memory quotas
+func (r *Reporter) collect(ctx context.Context) (map[string]*namespaceUsage, error) {
+	var quotas corev1.ResourceQuotaList
+	if err := r.Client.List(ctx, &quotas); err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]*namespaceUsage)
+	for _, quota := range quotas.Items {
+		cpuHard, cpuUsed, hasCPU := quotaPair(quota.Status, corev1.ResourceRequestsCPU, corev1.ResourceCPU)
+		memHard, memUsed, hasMem := quotaPair(quota.Status, corev1.ResourceRequestsMemory, corev1.ResourceMemory)
+		if !hasCPU && !hasMem {
+			continue
+		}
+
+		u, exists := usage[quota.Namespace]
+		if !exists {
+			u = &namespaceUsage{}
+			usage[quota.Namespace] = u
+		}
+		u.cpuHard.Add(cpuHard)
+		u.cpuRequested.Add(cpuUsed)
+		u.memHard.Add(memHard)
+		u.memRequested.Add(memUsed)
+	}
+
+	podMetrics := &unstructured.UnstructuredList{}
+	podMetrics.SetGroupVersionKind(podMetricsGVK)
+	if err := r.Client.List(ctx, podMetrics); err != nil {
+		if !meta.IsNoMatchError(err) {
+			logf.FromContext(ctx).Error(err, "Failed to read pod metrics, reporting without actual usage")
+		}
+		return usage, nil
+	}
+
+	for _, item := range podMetrics.Items {
+		u, exists := usage[item.GetNamespace()]
+		if !exists {
+			continue
+		}
+		u.metrics = true
+
+		containers, _, _ := unstructured.NestedSlice(item.Object, "containers")
+		for _, c := range containers {
+			container, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			cpu, _, _ := unstructured.NestedString(container, "usage", "cpu")
+			mem, _, _ := unstructured.NestedString(container, "usage", "memory")
+			if q, err := resource.ParseQuantity(cpu); err == nil {
+				u.cpuUsed.Add(q)
+			}
+			if q, err := resource.ParseQuantity(mem); err == nil {
+				u.memUsed.Add(q)
+			}
+		}
+	}
+
+	return usage, nil
+}
+
+// quotaPair returns the hard limit and used amount of the first resource name present in
+// the quota, preferring requests.* over the bare resource name
+func quotaPair(status corev1.ResourceQuotaStatus, names ...corev1.ResourceName) (resource.Quantity, resource.Quantity, bool) {
+	for _, name := range names {
+		if hard, ok := status.Hard[name]; ok {
+			return hard, status.Used[name], true
+		}
+	}
+	return resource.Quantity{}, resource.Quantity{}, false
+}
+
+// report renders the monthly summary of all namespaces with quotas
+func (r *Reporter) report(usage map[string]*namespaceUsage, now time.Time) notify.MetaAlert {
+	namespaces := make([]string, 0, len(usage))
+	for ns := range usage {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	var b strings.Builder
+	for _, ns := range namespaces {
+		u := usage[ns]
+		fmt.Fprintf(&b, "• *%s*: CPU %s / %s requested (%s)", ns,
+			formatCPU(u.cpuRequested), formatCPU(u.cpuHard), percent(u.cpuRequested, u.cpuHard))
+		if u.metrics {
+			fmt.Fprintf(&b, ", %s used", formatCPU(u.cpuUsed))
+		}
+		fmt.Fprintf(&b, "; memory %s / %s requested (%s)",
+			formatMemory(u.memRequested), formatMemory(u.memHard), percent(u.memRequested, u.memHard))
+		if u.metrics {
+			fmt.Fprintf(&b, ", %s used", formatMemory(u.memUsed))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("Requests are the quota's used amount; actual usage is a current metrics-server snapshot.")
+
+	return notify.MetaAlert{
+		Title:     fmt.Sprintf("Namespace quota utilization report for %s", now.Format("January 2006")),
+		Text:      b.String(),
+		Severity:  notify.SeverityInfo,
+		Channel:   r.Config.Channel,
+		Timestamp: now,
+	}
+}
+
+// percent renders part as a percentage of whole
+func percent(part, whole resource.Quantity) string {
+	if whole.IsZero() {
+		return "no quota"
+	}
+	return fmt.Sprintf("%.0f%%", float64(part.MilliValue())/float64(whole.MilliValue())*100)
+}
+
+// formatCPU renders a CPU quantity in cores
+func formatCPU(q resource.Quantity) string {
+	return fmt.Sprintf("%.1f cores", float64(q.MilliValue())/1000)
+}
+
+// formatMemory renders a memory quantity in GiB
+func formatMemory(q resource.Quantity) string {
+	return fmt.Sprintf("%.1fGi", float64(q.Value())/(1<<30))
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package silences
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+)
+
+// alertmanagerSilencesPath is the Alertmanager API listing silences
+const alertmanagerSilencesPath = "/api/v2/silences"
+
+// amSilence is the subset of an Alertmanager silence we use
+type amSilence struct {
+	ID       string      `json:"id"`
+	Matchers []amMatcher `json:"matchers"`
+	EndsAt   time.Time   `json:"endsAt"`
+	Status   struct {
+		State string `json:"state"`
+	} `json:"status"`
+}
+
+// amMatcher matches a label of an alert. IsEqual defaults to true for Alertmanager versions
+// that predate negative matchers.
+type amMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual *bool  `json:"isEqual"`
+}
+
+// matcher is a compiled Alertmanager matcher
+type matcher struct {
+	name  string
+	equal bool
+	value string
+	regex *regexp.Regexp
+}
+
+// matches reports whether the matcher matches a label set; a missing label has the empty value
+func (m matcher) matches(labels map[string]string) bool {
+	value := labels[m.name]
+	matched := value == m.value
+	if m.regex != nil {
+		matched = m.regex.MatchString(value)
+	}
+	return matched == m.equal
+}
+
+// activeSilence is an active Alertmanager silence
+type activeSilence struct {
+	id       string
+	matchers []matcher
+	endsAt   time.Time
+}
+
+// AlertmanagerSync polls the silences of an Alertmanager, so pod alerts matching a silence
+// there are suppressed without silencing them again in the operator. A pod alert is matched
+// as an alert with the pod's labels and the labels namespace, pod and alertname, the latter
+// set to the failure reason. If a poll fails, the silences of the last successful poll stay in
+// effect until they end.
+type AlertmanagerSync struct {
+	Config config.AlertmanagerSilencesConfig
+	// Token is sent as a bearer token if set, for Alertmanagers behind an authenticating proxy
+	Token      string
+	HTTPClient *http.Client
+
+	mu       sync.RWMutex
+	silences []activeSilence
+}
+
+// NeedLeaderElection runs the sync on every replica, so a new leader has the silences at hand
+func (s *AlertmanagerSync) NeedLeaderElection() bool {
+	return false
+}
+
+// Start polls silences until the context is cancelled
+func (s *AlertmanagerSync) Start(ctx context.Context) error {
+	if s.HTTPClient == nil {
+		s.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	logger := logf.FromContext(ctx)
+
+	ticker := time.NewTicker(s.Config.Interval.Duration)
+	defer ticker.Stop()
+
+	for {
+		if err := s.sync(ctx); err != nil {
+			logger.Error(err, "Failed to fetch Alertmanager silences, keeping the last known ones", "url", s.Config.URL)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync replaces the known silences with the active silences of the Alertmanager
+func (s *AlertmanagerSync) sync(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(s.Config.URL, "/")+alertmanagerSilencesPath, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("alertmanager returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var listed []amSilence
+	if err := json.NewDecoder(resp.Body).Decode(&listed); err != nil {
+		return fmt.Errorf("decoding silences: %w", err)
+	}
+
+	var active []activeSilence
+	for _, silence := range listed {
+		if silence.Status.State != "active" {
+			continue
+		}
+		compiled, err := compileMatchers(silence.Matchers)
+		if err != nil {
+			logf.FromContext(ctx).Info("Skipping Alertmanager silence with invalid matcher", "silence", silence.ID, "error", err.Error())
+			continue
+		}
+		active = append(active, activeSilence{id: silence.ID, matchers: compiled, endsAt: silence.EndsAt})
+	}
+
+	s.mu.Lock()
+	s.silences = active
+	s.mu.Unlock()
+	logf.FromContext(ctx).V(1).Info("Synchronized Alertmanager silences", "active", len(active))
+	return nil
+}
+
+// compileMatchers compiles the matchers of a silence. Regular expressions are anchored, as in
+// Alertmanager.
+func compileMatchers(matchers []amMatcher) ([]matcher, error) {
+	compiled := make([]matcher, 0, len(matchers))
+	for _, m := range matchers {
+		c := matcher{name: m.Name, equal: m.IsEqual == nil || *m.IsEqual, value: m.Value}
+		if m.IsRegex {
+			regex, err := regexp.Compile("^(?:" + m.Value + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("matcher %s: %w", m.Name, err)
+			}
+			c.regex = regex
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+// Silenced returns the ID of an active Alertmanager silence matching a pod alert, or an empty
+// string
+func (s *AlertmanagerSync) Silenced(namespace, pod, reason string, podLabels map[string]string) string {
+	labels := make(map[string]string, len(podLabels)+3)
+	for name, value := range podLabels {
+		labels[name] = value
+	}
+	labels["namespace"], labels["pod"], labels["alertname"] = namespace, pod, reason
+
+	now := time.Now()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, silence := range s.silences {
+		if !now.Before(silence.endsAt) || len(silence.matchers) == 0 {
+			continue
+		}
+		matchesAll := true
+		for _, m := range silence.matchers {
+			if !m.matches(labels) {
+				matchesAll = false
+				break
+			}
+		}
+		if matchesAll {
+			return silence.id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,309 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package silences
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	certutil "k8s.io/client-go/util/cert"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	geniev1alpha1 "github.com/ahmadrazalab/kube-slackgenie-operator/api/v1alpha1"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/store"
+)
+
+// apiPath is the collection all silence requests are made against
+const apiPath = "/api/v1/silences"
+
+// resource is the virtual resource RBAC rules grant access to silences on, e.g.
+// verbs list, create, update and delete on silences.genie.slackgenie.io
+const resource = "silences"
+
+// maxBodyBytes bounds the size of API requests
+const maxBodyBytes = 1 << 20
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// API serves batch silence management over HTTPS. Callers authenticate with a Kubernetes bearer
+// token issued for the configured audience and need RBAC permission on
+// silences.genie.slackgenie.io for the verb of the request. Silences are kept by the leader, so
+// the API runs on the leader only.
+type API struct {
+	Manager *Manager
+	// Client creates token and access reviews
+	Client client.Client
+	Config config.SilenceAPIConfig
+	// CertDir, CertName and KeyName locate the serving certificate and key. Without them a
+	// self-signed certificate is used.
+	CertDir  string
+	CertName string
+	KeyName  string
+	// TLSOpts adjust the TLS configuration, e.g. to disable HTTP/2
+	TLSOpts []func(*tls.Config)
+}
+
+// createRequest is the body of a POST request
+type createRequest struct {
+	Silences []Spec `json:"silences"`
+}
+
+// updateRequest is the body of PATCH and DELETE requests
+type updateRequest struct {
+	IDs []string `json:"ids"`
+	// ExtendBy is a Go duration added to the end of each silence
+	ExtendBy string `json:"extendBy,omitempty"`
+}
+
+// listResponse is the body of successful GET, POST and PATCH responses
+type listResponse struct {
+	Silences []store.Silence `json:"silences"`
+}
+
+// NeedLeaderElection runs the API on the leader, which owns the state store
+func (a *API) NeedLeaderElection() bool {
+	return true
+}
+
+// Start serves the API until the context is cancelled
+func (a *API) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(apiPath, a.handle)
+
+	tlsConfig, err := a.tlsConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Addr:              a.Config.BindAddress,
+		Handler:           mux,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	logf.FromContext(ctx).Info("Serving silence API", "address", a.Config.BindAddress)
+	// The certificates are in the TLS configuration
+	if err := srv.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// tlsConfig returns the TLS configuration of the server. A certificate from CertDir is reloaded
+// when it is renewed.
+func (a *API) tlsConfig(ctx context.Context) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	for _, op := range a.TLSOpts {
+		op(cfg)
+	}
+
+	if a.CertDir != "" {
+		watcher, err := certwatcher.New(filepath.Join(a.CertDir, a.CertName), filepath.Join(a.CertDir, a.KeyName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load silence API certificate: %w", err)
+		}
+		go func() {
+			if err := watcher.Start(ctx); err != nil {
+				logf.FromContext(ctx).Error(err, "Silence API certificate watcher failed")
+			}
+		}()
+		cfg.GetCertificate = watcher.GetCertificate
+		return cfg, nil
+	}
+
+	cert, key, err := certutil.GenerateSelfSignedCertKeyWithFixtures("localhost", []net.IP{{127, 0, 0, 1}}, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate self-signed certificate for silence API: %w", err)
+	}
+	keyPair, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create self-signed key pair for silence API: %w", err)
+	}
+	cfg.Certificates = []tls.Certificate{keyPair}
+	return cfg, nil
+}
+
+// handle authorizes the request and runs the silence operation matching its method
+func (a *API) handle(w http.ResponseWriter, req *http.Request) {
+	verb, ok := map[string]string{
+		http.MethodGet:    "list",
+		http.MethodPost:   "create",
+		http.MethodPatch:  "update",
+		http.MethodDelete: "delete",
+	}[req.Method]
+	if !ok {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	ctx := req.Context()
+	actor, status := a.authorize(ctx, req, verb)
+	if status != http.StatusOK {
+		writeError(w, status, http.StatusText(status))
+		return
+	}
+	ctx = logf.IntoContext(ctx, logf.FromContext(ctx).WithValues("user", actor))
+
+	switch req.Method {
+	case http.MethodGet:
+		silences, err := a.Manager.List(ctx, req.URL.Query().Get("all") == "true")
+		if err != nil {
+			writeFailure(ctx, w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, listResponse{Silences: silences})
+	case http.MethodPost:
+		var body createRequest
+		if !decode(w, req, &body) {
+			return
+		}
+		created, err := a.Manager.Create(ctx, body.Silences, actor)
+		if err != nil {
+			writeFailure(ctx, w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, listResponse{Silences: created})
+	case http.MethodPatch:
+		var body updateRequest
+		if !decode(w, req, &body) {
+			return
+		}
+		by, err := time.ParseDuration(body.ExtendBy)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "extendBy must be a duration such as 2h")
+			return
+		}
+		extended, err := a.Manager.Extend(ctx, body.IDs, by, actor)
+		if err != nil {
+			writeFailure(ctx, w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, listResponse{Silences: extended})
+	case http.MethodDelete:
+		var body updateRequest
+		if !decode(w, req, &body) {
+			return
+		}
+		if err := a.Manager.Delete(ctx, body.IDs, actor); err != nil {
+			writeFailure(ctx, w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// authorize authenticates the bearer token of the request and checks that its user may perform
+// verb on silences. It returns the user name and http.StatusOK on success.
+func (a *API) authorize(ctx context.Context, req *http.Request, verb string) (string, int) {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return "", http.StatusUnauthorized
+	}
+	logger := logf.FromContext(ctx)
+
+	// Requiring a dedicated audience keeps callers from sending tokens the API server accepts
+	review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{
+		Token:     token,
+		Audiences: []string{a.Config.Audience},
+	}}
+	if err := a.Client.Create(ctx, review); err != nil {
+		logger.Error(err, "Failed to review silence API token")
+		return "", http.StatusInternalServerError
+	}
+	if !review.Status.Authenticated || !slices.Contains(review.Status.Audiences, a.Config.Audience) {
+		return "", http.StatusUnauthorized
+	}
+	user := review.Status.User
+
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	access := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+		User:   user.Username,
+		UID:    user.UID,
+		Groups: user.Groups,
+		Extra:  extra,
+		ResourceAttributes: &authorizationv1.ResourceAttributes{
+			Group:    geniev1alpha1.GroupVersion.Group,
+			Resource: resource,
+			Verb:     verb,
+		},
+	}}
+	if err := a.Client.Create(ctx, access); err != nil {
+		logger.Error(err, "Failed to review silence API access", "user", user.Username)
+		return "", http.StatusInternalServerError
+	}
+	if !access.Status.Allowed {
+		logger.V(1).Info("Denied silence API request", "user", user.Username, "verb", verb)
+		return "", http.StatusForbidden
+	}
+	return user.Username, http.StatusOK
+}
+
+// decode reads a JSON request body, answering malformed requests itself
+func decode(w http.ResponseWriter, req *http.Request, into any) bool {
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxBodyBytes)).Decode(into); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return false
+	}
+	return true
+}
+
+// writeFailure answers with the error of a silence operation
+func writeFailure(ctx context.Context, w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrInvalid) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	logf.FromContext(ctx).Error(err, "Failed to update silences")
+	writeError(w, http.StatusInternalServerError, "failed to update silences")
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package silences manages silences in bulk on behalf of API clients and Slack users, recording
+// who created each one.
+package silences
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/store"
+)
+
+// maxBatch bounds the number of silences created, extended or deleted in one request
+const maxBatch = 100
+
+// ErrInvalid marks requests rejected before any silence was changed
+var ErrInvalid = errors.New("invalid silence request")
+
+// Spec describes a silence to create. EndsAt takes precedence over Duration.
+type Spec struct {
+	Namespace string `json:"namespace,omitempty"`
+	Pod       string `json:"pod,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	// Selector is a label selector on the pod's labels, e.g. app=web,tier!=db
+	Selector string `json:"selector,omitempty"`
+	// StartsAt defaults to now, so maintenance windows can be silenced ahead of time
+	StartsAt time.Time `json:"startsAt,omitzero"`
+	EndsAt   time.Time `json:"endsAt,omitzero"`
+	// Duration is a Go duration such as 2h30m, counted from StartsAt
+	Duration string `json:"duration,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// Manager creates, lists, extends and deletes silences in the state store
+type Manager struct {
+	Store store.Store
+}
+
+// List returns the silences, leaving out expired ones unless all is set
+func (m *Manager) List(ctx context.Context, all bool) ([]store.Silence, error) {
+	silences, err := m.Store.Silences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if all {
+		return silences, nil
+	}
+	now := time.Now()
+	var current []store.Silence
+	for _, silence := range silences {
+		if silence.EndsAt.After(now) {
+			current = append(current, silence)
+		}
+	}
+	return current, nil
+}
+
+// Create validates every spec before storing any of them, so a batch is rejected as a whole
+func (m *Manager) Create(ctx context.Context, specs []Spec, actor string) ([]store.Silence, error) {
+	if len(specs) == 0 || len(specs) > maxBatch {
+		return nil, fmt.Errorf("%w: between 1 and %d silences are required", ErrInvalid, maxBatch)
+	}
+
+	now := time.Now()
+	created := make([]store.Silence, 0, len(specs))
+	for i, spec := range specs {
+		silence, err := spec.silence(now, actor)
+		if err != nil {
+			return nil, fmt.Errorf("%w: silence %d: %v", ErrInvalid, i, err)
+		}
+		created = append(created, silence)
+	}
+
+	logger := logf.FromContext(ctx)
+	for i, silence := range created {
+		if err := m.Store.PutSilence(ctx, silence); err != nil {
+			return created[:i], err
+		}
+		logger.Info("Created silence",
+			"silence", silence.ID,
+			"namespace", silence.Namespace,
+			"pod", silence.Pod,
+			"reason", silence.Reason,
+			"selector", silence.Selector,
+			"startsAt", silence.StartsAt,
+			"endsAt", silence.EndsAt,
+			"user", actor,
+		)
+	}
+	return created, nil
+}
+
+// Extend pushes back the end of each silence by the given duration. Silences that already
+// expired are extended from now.
+func (m *Manager) Extend(ctx context.Context, ids []string, by time.Duration, actor string) ([]store.Silence, error) {
+	if by <= 0 {
+		return nil, fmt.Errorf("%w: the extension must be positive", ErrInvalid)
+	}
+	found, err := m.lookup(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	logger := logf.FromContext(ctx)
+	for i := range found {
+		from := found[i].EndsAt
+		if from.Before(now) {
+			from = now
+		}
+		found[i].EndsAt = from.Add(by)
+		if err := m.Store.PutSilence(ctx, found[i]); err != nil {
+			return found[:i], err
+		}
+		logger.Info("Extended silence", "silence", found[i].ID, "endsAt", found[i].EndsAt, "user", actor)
+	}
+	return found, nil
+}
+
+// Delete removes the given silences
+func (m *Manager) Delete(ctx context.Context, ids []string, actor string) error {
+	found, err := m.lookup(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	logger := logf.FromContext(ctx)
+	for _, silence := range found {
+		if err := m.Store.DeleteSilence(ctx, silence.ID); err != nil {
+			return err
+		}
+		logger.Info("Deleted silence", "silence", silence.ID, "createdBy", silence.CreatedBy, "user", actor)
+	}
+	return nil
+}
+
+// lookup returns the silences with the given IDs, failing if any of them does not exist
+func (m *Manager) lookup(ctx context.Context, ids []string) ([]store.Silence, error) {
+	if len(ids) == 0 || len(ids) > maxBatch {
+		return nil, fmt.Errorf("%w: between 1 and %d silence IDs are required", ErrInvalid, maxBatch)
+	}
+	silences, err := m.Store.Silences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]store.Silence, len(silences))
+	for _, silence := range silences {
+		byID[silence.ID] = silence
+	}
+
+	found := make([]store.Silence, 0, len(ids))
+	var missing []string
+	for _, id := range ids {
+		if silence, ok := byID[id]; ok {
+			found = append(found, silence)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: unknown silences %s", ErrInvalid, strings.Join(missing, ", "))
+	}
+	return found, nil
+}
+
+// silence validates the spec and turns it into a silence created by actor
+func (s Spec) silence(now time.Time, actor string) (store.Silence, error) {
+	if s.Namespace == "" && s.Pod == "" && s.Reason == "" && s.Selector == "" {
+		return store.Silence{}, errors.New("at least one of namespace, pod, reason or selector is required")
+	}
+	if s.Selector != "" {
+		if _, err := labels.Parse(s.Selector); err != nil {
+			return store.Silence{}, fmt.Errorf("invalid selector: %v", err)
+		}
+	}
+
+	startsAt := s.StartsAt
+	if startsAt.IsZero() {
+		startsAt = now
+	}
+	endsAt := s.EndsAt
+	if endsAt.IsZero() {
+		if s.Duration == "" {
+			return store.Silence{}, errors.New("endsAt or duration is required")
+		}
+		duration, err := time.ParseDuration(s.Duration)
+		if err != nil {
+			return store.Silence{}, fmt.Errorf("invalid duration: %v", err)
+		}
+		endsAt = startsAt.Add(duration)
+	}
+	if !endsAt.After(startsAt) || !endsAt.After(now) {
+		return store.Silence{}, errors.New("the silence must end after it starts and in the future")
+	}
+
+	return store.Silence{
+		ID:        newID(),
+		Namespace: s.Namespace,
+		Pod:       s.Pod,
+		Reason:    s.Reason,
+		Selector:  s.Selector,
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		CreatedBy: actor,
+		CreatedAt: now,
+		Comment:   s.Comment,
+	}, nil
+}
+
+// newID returns a short random silence ID
+func newID() string {
+	b := make([]byte, 6)
+	_, _ = rand.Read(b)
+	return "sil-" + hex.EncodeToString(b)
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slackapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/silences"
+)
+
+// commandUsage explains the /silence slash command
+const commandUsage = "Usage:\n" +
+	"• `/silence list`\n" +
+	"• `/silence add <duration> [namespace=…] [pod=…] [reason=…] [selector=…] [comment]`\n" +
+	"• `/silence extend <id>… <duration>`\n" +
+	"• `/silence delete <id>…`"
+
+// Commands answers the /silence slash command, the Slack counterpart of the silence API
+type Commands struct {
+	Silences *silences.Manager
+	// Users are the Slack user IDs allowed to run the command; empty allows everyone
+	Users []string
+}
+
+// run executes a /silence command on behalf of a Slack user and returns the reply
+func (c *Commands) run(ctx context.Context, userID, username, text string) string {
+	if len(c.Users) > 0 && !slices.Contains(c.Users, userID) {
+		return ":no_entry: You are not allowed to manage silences."
+	}
+	actor := "slack:" + userID
+	if username != "" {
+		actor = "slack:" + username
+	}
+
+	args := strings.Fields(text)
+	if len(args) == 0 {
+		return commandUsage
+	}
+	var reply string
+	var err error
+	switch args[0] {
+	case "list", "ls":
+		reply, err = c.list(ctx)
+	case "add", "create":
+		reply, err = c.add(ctx, args[1:], actor)
+	case "extend":
+		reply, err = c.extend(ctx, args[1:], actor)
+	case "delete", "rm", "expire":
+		reply, err = c.delete(ctx, args[1:], actor)
+	default:
+		return commandUsage
+	}
+	if errors.Is(err, silences.ErrInvalid) {
+		return fmt.Sprintf(":warning: %v\n%s", err, commandUsage)
+	}
+	if err != nil {
+		return fmt.Sprintf(":warning: Failed to update silences: %v", err)
+	}
+	return reply
+}
+
+func (c *Commands) list(ctx context.Context) (string, error) {
+	current, err := c.Silences.List(ctx, false)
+	if err != nil {
+		return "", err
+	}
+	if len(current) == 0 {
+		return "No active silences.", nil
+	}
+	lines := make([]string, 0, len(current))
+	for i, silence := range current {
+		if i == maxSilences {
+			lines = append(lines, fmt.Sprintf("_…and %d more_", len(current)-maxSilences))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("`%s` %s", silence.ID, describeSilence(silence)))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// add creates a silence from a duration followed by key=value matchers, the remaining words
+// forming the comment
+func (c *Commands) add(ctx context.Context, args []string, actor string) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf("%w: a duration is required", silences.ErrInvalid)
+	}
+	spec := silences.Spec{Duration: args[0]}
+	var comment []string
+	for _, arg := range args[1:] {
+		key, value, ok := strings.Cut(arg, "=")
+		switch {
+		case ok && (key == "namespace" || key == "ns"):
+			spec.Namespace = value
+		case ok && key == "pod":
+			spec.Pod = value
+		case ok && key == "reason":
+			spec.Reason = value
+		case ok && key == "selector":
+			spec.Selector = value
+		default:
+			comment = append(comment, arg)
+		}
+	}
+	spec.Comment = strings.Join(comment, " ")
+
+	created, err := c.Silences.Create(ctx, []silences.Spec{spec}, actor)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Created silence `%s` %s", created[0].ID, describeSilence(created[0])), nil
+}
+
+// extend takes silence IDs followed by the duration to extend them by
+func (c *Commands) extend(ctx context.Context, args []string, actor string) (string, error) {
+	if len(args) < 2 {
+		return "", fmt.Errorf("%w: silence IDs and a duration are required", silences.ErrInvalid)
+	}
+	by, err := time.ParseDuration(args[len(args)-1])
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid duration %q", silences.ErrInvalid, args[len(args)-1])
+	}
+	extended, err := c.Silences.Extend(ctx, args[:len(args)-1], by, actor)
+	if err != nil {
+		return "", err
+	}
+	lines := make([]string, 0, len(extended))
+	for _, silence := range extended {
+		lines = append(lines, fmt.Sprintf("Extended `%s` %s", silence.ID, describeSilence(silence)))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func (c *Commands) delete(ctx context.Context, ids []string, actor string) (string, error) {
+	if err := c.Silences.Delete(ctx, ids, actor); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Deleted %d silence(s).", len(ids)), nil
+}
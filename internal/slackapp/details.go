@@ -0,0 +1,236 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slackapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/kubeevent"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/slack"
+)
+
+// Limits of the details posted for a compact alert
+const (
+	detailsTailLines = 50
+	detailsMaxEvents = 10
+	// maxSectionText is Slack's limit for the text of a section block
+	maxSectionText = 3000
+)
+
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
+
+// ThreadPoster resolves compact messages to their alerts and posts details into their threads
+type ThreadPoster interface {
+	DetailsEmoji() string
+	AlertForMessage(channel, ts string) (slack.AlertRef, bool)
+	ClaimExpansion(channel, ts string) bool
+	PostThreadReply(channel, threadTS, text string, blocks []slack.Block) error
+}
+
+// Details posts the logs, events and status of a pod into the thread of a compact alert when
+// someone reacts with the details emoji or clicks its Details button
+type Details struct {
+	Clientset kubernetes.Interface
+	Slack     ThreadPoster
+}
+
+// handleReaction expands the compact message a details emoji was added to
+func (d *Details) handleReaction(ctx context.Context, reaction, channel, ts string) {
+	if reaction != d.Slack.DetailsEmoji() {
+		return
+	}
+	ref, ok := d.Slack.AlertForMessage(channel, ts)
+	if !ok || !d.Slack.ClaimExpansion(channel, ts) {
+		return
+	}
+	go d.post(ctx, ref, channel, ts)
+}
+
+// handleButton expands the compact message whose Details button was clicked
+func (d *Details) handleButton(ctx context.Context, value, channel, messageTS, threadTS string) {
+	var ref slack.AlertRef
+	if err := json.Unmarshal([]byte(value), &ref); err != nil || ref.Namespace == "" || ref.Pod == "" {
+		logf.FromContext(ctx).V(1).Info("Ignoring Details click with invalid alert reference", "value", value)
+		return
+	}
+	if !d.Slack.ClaimExpansion(channel, messageTS) {
+		return
+	}
+	go d.post(ctx, ref, channel, threadTS)
+}
+
+// post fetches the details of the alert's pod and replies with them in the thread
+func (d *Details) post(parent context.Context, ref slack.AlertRef, channel, threadTS string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(parent), asyncTimeout)
+	defer cancel()
+	logger := logf.FromContext(ctx).WithValues("pod", ref.Pod, "namespace", ref.Namespace)
+
+	var blocks []slack.Block
+	pod, err := d.Clientset.CoreV1().Pods(ref.Namespace).Get(ctx, ref.Pod, metav1.GetOptions{})
+	if err != nil {
+		blocks = append(blocks, section(fmt.Sprintf("_Pod `%s/%s` is no longer available: %s_", ref.Namespace, ref.Pod, err)))
+	} else {
+		blocks = append(blocks, section(truncateText("*Status*\n"+describePod(pod), maxSectionText)))
+	}
+
+	if logs, err := d.logs(ctx, ref); err != nil {
+		logger.V(1).Info("Failed to fetch logs for details", "error", err.Error())
+		blocks = append(blocks, section("*Logs*\n_Not available_"))
+	} else {
+		blocks = append(blocks, section("*Logs*"+codeBlock(logs)))
+	}
+
+	if events, err := d.events(ctx, ref); err != nil {
+		logger.V(1).Info("Failed to fetch events for details", "error", err.Error())
+	} else if events != "" {
+		blocks = append(blocks, section("*Events*"+codeBlock(events)))
+	}
+
+	text := fmt.Sprintf("Details for %s/%s", ref.Namespace, ref.Pod)
+	if err := d.Slack.PostThreadReply(channel, threadTS, text, blocks); err != nil {
+		logger.Error(err, "Failed to post alert details")
+	}
+}
+
+// logs returns the tail of the container's logs, of its previous instance if it restarted
+func (d *Details) logs(ctx context.Context, ref slack.AlertRef) (string, error) {
+	tailLines := int64(detailsTailLines)
+	stream, err := d.Clientset.CoreV1().Pods(ref.Namespace).GetLogs(ref.Pod, &corev1.PodLogOptions{
+		Container: ref.Container,
+		Previous:  ref.RestartCount > 0,
+		TailLines: &tailLines,
+	}).Stream(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+
+	data, err := io.ReadAll(io.LimitReader(stream, maxBodyBytes))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// events returns the most recent events of the pod, oldest first
+func (d *Details) events(ctx context.Context, ref slack.AlertRef) (string, error) {
+	list, err := d.Clientset.CoreV1().Events(ref.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.Set{"involvedObject.kind": "Pod", "involvedObject.name": ref.Pod}.String(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	events := list.Items
+	sort.Slice(events, func(i, j int) bool {
+		return kubeevent.LastObserved(&events[i]).Before(kubeevent.LastObserved(&events[j]))
+	})
+	if len(events) > detailsMaxEvents {
+		events = events[len(events)-detailsMaxEvents:]
+	}
+
+	var b strings.Builder
+	for _, event := range events {
+		fmt.Fprintf(&b, "%s %s %s: %s", kubeevent.LastObserved(&event).Format("15:04:05"), event.Type, event.Reason, event.Message)
+		if event.Count > 1 {
+			fmt.Fprintf(&b, " (x%d)", event.Count)
+		}
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// describePod summarizes the pod's phase, node, failing conditions and container states
+func describePod(pod *corev1.Pod) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Phase: `%s`", pod.Status.Phase)
+	if pod.Spec.NodeName != "" {
+		fmt.Fprintf(&b, " · Node: `%s`", pod.Spec.NodeName)
+	}
+	b.WriteString("\n")
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			fmt.Fprintf(&b, "Condition %s: %s %s\n", condition.Type, condition.Reason, condition.Message)
+		}
+	}
+
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		fmt.Fprintf(&b, "Container `%s`: %s, %d restarts", status.Name, containerState(status.State), status.RestartCount)
+		if last := status.LastTerminationState.Terminated; last != nil {
+			fmt.Fprintf(&b, ", last terminated %s (exit code %d)", last.Reason, last.ExitCode)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// containerState renders the current state of a container
+func containerState(state corev1.ContainerState) string {
+	switch {
+	case state.Waiting != nil:
+		return "waiting (" + state.Waiting.Reason + ")"
+	case state.Terminated != nil:
+		return fmt.Sprintf("terminated (%s, exit code %d)", state.Terminated.Reason, state.Terminated.ExitCode)
+	case state.Running != nil:
+		return "running"
+	default:
+		return "unknown"
+	}
+}
+
+// codeBlock wraps text in a preformatted block, keeping its tail within the section limit
+func codeBlock(text string) string {
+	text = strings.TrimRight(text, "\n")
+	if text == "" {
+		return "\n_Empty_"
+	}
+	limit := maxSectionText - 32
+	if len(text) > limit {
+		text = text[len(text)-limit:]
+		for !utf8.ValidString(text) {
+			text = text[1:]
+		}
+		text = "…" + text
+	}
+	return "\n```" + strings.ReplaceAll(text, "```", "ˋˋˋ") + "```"
+}
+
+// truncateText keeps the beginning of text within limit bytes
+func truncateText(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	text = text[:limit-3]
+	for !utf8.ValidString(text) {
+		text = text[:len(text)-1]
+	}
+	return text + "…"
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slackapp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/store"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/slack"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/version"
+)
+
+// Action IDs of the App Home quick action buttons
+const (
+	actionSilence = "silence_alert"
+	actionExpire  = "expire_silence"
+)
+
+// Limits keeping the view under Slack's 100 block limit
+const (
+	maxAlerts   = 40
+	maxSilences = 20
+)
+
+// AlertSource lists the alerts that are currently firing
+type AlertSource interface {
+	OpenAlerts() []notify.PodAlert
+}
+
+// Publisher publishes App Home views
+type Publisher interface {
+	PublishHomeView(userID string, view slack.View) error
+}
+
+// Home publishes the App Home tab with the firing alerts of the viewing user's teams and the
+// active silences, and runs its quick actions
+type Home struct {
+	Alerts    AlertSource
+	Publisher Publisher
+	Store     store.Store
+	Config    config.AppHomeConfig
+}
+
+// runAction silences an alert or expires a silence on behalf of a Slack user
+func (h *Home) runAction(ctx context.Context, userID, username, actionID, value string) error {
+	now := time.Now()
+
+	switch actionID {
+	case actionSilence:
+		parts := strings.SplitN(value, "/", 3)
+		if len(parts) != 3 {
+			return fmt.Errorf("invalid alert reference %q", value)
+		}
+		createdBy := "slack:" + userID
+		if username != "" {
+			createdBy = "slack:" + username
+		}
+		silence := store.Silence{
+			ID:        fmt.Sprintf("apphome-%s-%d", userID, now.UnixNano()),
+			Namespace: parts[0],
+			Pod:       parts[1],
+			Reason:    parts[2],
+			StartsAt:  now,
+			EndsAt:    now.Add(h.Config.SilenceDuration.Duration),
+			CreatedBy: createdBy,
+			Comment:   "Silenced from the Slack App Home tab",
+		}
+		if err := h.Store.PutSilence(ctx, silence); err != nil {
+			return err
+		}
+		logf.FromContext(ctx).Info("Silenced alert from App Home",
+			"namespace", silence.Namespace,
+			"pod", silence.Pod,
+			"reason", silence.Reason,
+			"until", silence.EndsAt,
+			"user", createdBy,
+		)
+	case actionExpire:
+		if err := h.Store.DeleteSilence(ctx, value); err != nil {
+			return err
+		}
+		logf.FromContext(ctx).Info("Expired silence from App Home", "silence", value, "user", userID)
+	}
+	return nil
+}
+
+// publish builds and publishes the home tab of a user. It runs after Slack's request was
+// answered, since Slack expects a response within three seconds.
+func (h *Home) publish(parent context.Context, userID string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(parent), asyncTimeout)
+	defer cancel()
+	logger := logf.FromContext(ctx)
+
+	silences, err := h.Store.Silences(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to list silences for App Home", "user", userID)
+	}
+
+	view := h.buildView(h.teamsOf(userID), h.Alerts.OpenAlerts(), silences, time.Now())
+	if err := h.Publisher.PublishHomeView(userID, view); err != nil {
+		logger.Error(err, "Failed to publish App Home", "user", userID)
+	}
+}
+
+// teamsOf returns the teams a Slack user is a member of
+func (h *Home) teamsOf(userID string) []string {
+	var teams []string
+	for team, members := range h.Config.Teams {
+		if slices.Contains(members, userID) {
+			teams = append(teams, team)
+		}
+	}
+	sort.Strings(teams)
+	return teams
+}
+
+// buildView renders the firing alerts of the user's teams and the active silences
+func (h *Home) buildView(teams []string, alerts []notify.PodAlert, silences []store.Silence, now time.Time) slack.View {
+	var visible []notify.PodAlert
+	others := 0
+	for _, alert := range alerts {
+		if len(teams) == 0 || slices.Contains(teams, alert.Team) {
+			visible = append(visible, alert)
+		} else {
+			others++
+		}
+	}
+	sort.Slice(visible, func(i, j int) bool {
+		si, sj := visible[i].AlertSeverity(), visible[j].AlertSeverity()
+		if si != sj {
+			return si.AtLeast(sj)
+		}
+		return visible[i].Timestamp.After(visible[j].Timestamp)
+	})
+
+	var active []store.Silence
+	for _, silence := range silences {
+		if silence.Active(now) {
+			active = append(active, silence)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].EndsAt.Before(active[j].EndsAt) })
+
+	scope := "all teams"
+	if len(teams) > 0 {
+		scope = strings.Join(teams, ", ")
+	}
+
+	blocks := []slack.Block{
+		{Type: "header", Text: &slack.BlockText{Type: "plain_text", Text: "Cluster failure overview"}},
+		{Type: "context", Elements: []slack.BlockElement{slack.BlockText{Type: "mrkdwn", Text: fmt.Sprintf(
+			"%d firing for %s · %d active silence(s) · updated <!date^%d^{date_short_pretty} {time}|%s> · %s",
+			len(visible), scope, len(active), now.Unix(), now.Format(time.RFC1123), version.Footer())}}},
+		{Type: "divider"},
+		section("*Firing alerts*"),
+	}
+
+	if len(visible) == 0 {
+		blocks = append(blocks, section("_Nothing is failing_ :white_check_mark:"))
+	}
+	for i, alert := range visible {
+		if i == maxAlerts {
+			blocks = append(blocks, section(fmt.Sprintf("_…and %d more_", len(visible)-maxAlerts)))
+			break
+		}
+		text := fmt.Sprintf("%s *%s* `%s/%s`", notify.EmojiForSeverity(alert.AlertSeverity()),
+			alert.Reason, alert.Namespace, alert.PodName)
+		if alert.ContainerName != "" {
+			text += fmt.Sprintf(" container `%s`", alert.ContainerName)
+		}
+		if alert.Team != "" {
+			text += " · " + alert.Team
+		}
+		text += fmt.Sprintf("\nsince <!date^%d^{date_short_pretty} {time}|%s>", alert.Timestamp.Unix(), alert.Timestamp.Format(time.RFC1123))
+		block := section(text)
+		block.Accessory = slack.NewButton("Silence "+h.Config.SilenceDuration.Duration.String(), actionSilence,
+			alert.Namespace+"/"+alert.PodName+"/"+alert.Reason, "")
+		blocks = append(blocks, block)
+	}
+	if others > 0 {
+		blocks = append(blocks, slack.Block{Type: "context", Elements: []slack.BlockElement{slack.BlockText{Type: "mrkdwn",
+			Text: fmt.Sprintf("%d more alert(s) firing for other teams", others)}}})
+	}
+
+	blocks = append(blocks, slack.Block{Type: "divider"}, section("*Active silences*"))
+	if len(active) == 0 {
+		blocks = append(blocks, section("_No active silences_"))
+	}
+	for i, silence := range active {
+		if i == maxSilences {
+			blocks = append(blocks, section(fmt.Sprintf("_…and %d more_", len(active)-maxSilences)))
+			break
+		}
+		block := section(describeSilence(silence))
+		block.Accessory = slack.NewButton("Expire", actionExpire, silence.ID, "danger")
+		blocks = append(blocks, block)
+	}
+
+	return slack.View{Type: "home", Blocks: blocks}
+}
+
+// describeSilence renders the matchers, expiry and author of a silence
+func describeSilence(silence store.Silence) string {
+	matchers := []string{}
+	for _, m := range []struct{ name, value string }{
+		{"namespace", silence.Namespace},
+		{"pod", silence.Pod},
+		{"reason", silence.Reason},
+		{"selector", silence.Selector},
+	} {
+		if m.value != "" {
+			matchers = append(matchers, fmt.Sprintf("%s=`%s`", m.name, m.value))
+		}
+	}
+	if len(matchers) == 0 {
+		matchers = append(matchers, "_everything_")
+	}
+
+	text := fmt.Sprintf(":mute: %s\nuntil <!date^%d^{date_short_pretty} {time}|%s>",
+		strings.Join(matchers, " "), silence.EndsAt.Unix(), silence.EndsAt.Format(time.RFC1123))
+	if silence.CreatedBy != "" {
+		text += " · by " + silence.CreatedBy
+	}
+	if silence.Comment != "" {
+		text += " · " + silence.Comment
+	}
+	return text
+}
+
+// section creates a section block with markdown text
+func section(text string) slack.Block {
+	return slack.Block{Type: "section", Text: &slack.BlockText{Type: "mrkdwn", Text: text}}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slackapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/github"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/slack"
+)
+
+// IssueCreator opens an issue for a failure unless an open one already tracks it, and
+// reports whether it opened one
+type IssueCreator interface {
+	Create(ctx context.Context, req github.NewIssue) (*notify.Issue, bool, error)
+}
+
+// ThreadReplier posts replies into the thread of an alert
+type ThreadReplier interface {
+	PostThreadReply(channel, threadTS, text string, blocks []slack.Block) error
+}
+
+// Issues opens an issue when someone clicks the Create issue button of an alert
+type Issues struct {
+	Creator IssueCreator
+	Slack   ThreadReplier
+}
+
+// handleButton opens the issue in the background and replies with its link in the alert's thread
+func (i *Issues) handleButton(ctx context.Context, userID, username, value, channel, threadTS string) {
+	var ref slack.AlertRef
+	if err := json.Unmarshal([]byte(value), &ref); err != nil || ref.Fingerprint == "" {
+		logf.FromContext(ctx).V(1).Info("Ignoring Create issue click with invalid alert reference", "value", value)
+		return
+	}
+	openedBy := "@" + username
+	if username == "" {
+		openedBy = "Slack user " + userID
+	}
+	go i.create(ctx, ref, openedBy, channel, threadTS)
+}
+
+// create opens the issue and posts its link
+func (i *Issues) create(parent context.Context, ref slack.AlertRef, openedBy, channel, threadTS string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(parent), asyncTimeout)
+	defer cancel()
+	logger := logf.FromContext(ctx).WithValues("pod", ref.Pod, "namespace", ref.Namespace)
+
+	issue, created, err := i.Creator.Create(ctx, github.NewIssue{
+		Fingerprint: ref.Fingerprint,
+		Namespace:   ref.Namespace,
+		Pod:         ref.Pod,
+		Container:   ref.Container,
+		Reason:      ref.Reason,
+		OpenedBy:    openedBy,
+	})
+
+	var text string
+	switch {
+	case err != nil:
+		logger.Error(err, "Failed to create GitHub issue")
+		text = fmt.Sprintf(":warning: Failed to create an issue: %s", err)
+	case created:
+		logger.Info("Created GitHub issue for alert", "issue", issue.URL, "user", openedBy)
+		text = fmt.Sprintf(":memo: %s opened <%s|#%d %s>", openedBy, issue.URL, issue.Number, issue.Title)
+	default:
+		text = fmt.Sprintf(":memo: Already tracked in <%s|#%d %s>", issue.URL, issue.Number, issue.Title)
+	}
+	if err := i.Slack.PostThreadReply(channel, threadTS, text, []slack.Block{section(text)}); err != nil {
+		logger.Error(err, "Failed to post GitHub issue link")
+	}
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package slackapp serves the Slack app endpoints of the operator: the Events API,
+// interactivity and slash command request URLs behind the App Home tab, the details of compact
+// alerts and the /silence command.
+package slackapp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/slack"
+)
+
+// Paths of the Slack Events API, interactivity and slash command request URLs
+const (
+	eventsPath       = "/slack/events"
+	interactionsPath = "/slack/interactions"
+	commandsPath     = "/slack/commands"
+)
+
+// maxBodyBytes bounds the size of requests from Slack
+const maxBodyBytes = 1 << 20
+
+// asyncTimeout bounds work done after Slack's request was answered. Slack expects a response
+// within three seconds, so anything calling back into Slack runs in the background.
+const asyncTimeout = 10 * time.Second
+
+// Server answers Slack Events API and interactivity requests. Firing alerts are only known to
+// the leader, so the server runs on the leader only.
+type Server struct {
+	BindAddress   string
+	SigningSecret string
+
+	// Home serves the App Home tab; nil disables it
+	Home *Home
+	// Details expands compact alerts; nil disables it
+	Details *Details
+	// Commands answers the /silence slash command; nil disables it
+	Commands *Commands
+	// Issues opens GitHub issues from alerts; nil disables it
+	Issues *Issues
+}
+
+// NeedLeaderElection runs the server on the leader, which tracks the firing alerts
+func (s *Server) NeedLeaderElection() bool {
+	return true
+}
+
+// Start serves Slack requests until the context is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(eventsPath, s.handleEvents)
+	mux.HandleFunc(interactionsPath, s.handleInteractions)
+	mux.HandleFunc(commandsPath, s.handleCommands)
+
+	srv := &http.Server{
+		Addr:              s.BindAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	logf.FromContext(ctx).Info("Serving Slack app requests", "address", s.BindAddress)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// readVerified reads the request body and checks its Slack signature
+func (s *Server) readVerified(w http.ResponseWriter, req *http.Request) ([]byte, bool) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return nil, false
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxBodyBytes))
+	if err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return nil, false
+	}
+	if err := slack.VerifyRequest(s.SigningSecret, req.Header, body, time.Now()); err != nil {
+		logf.FromContext(req.Context()).V(1).Info("Rejected Slack request", "reason", err.Error())
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return nil, false
+	}
+	return body, true
+}
+
+// eventEnvelope is the subset of an Events API request we care about
+type eventEnvelope struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Event     struct {
+		Type     string `json:"type"`
+		User     string `json:"user"`
+		Tab      string `json:"tab"`
+		Reaction string `json:"reaction"`
+		Item     struct {
+			Type    string `json:"type"`
+			Channel string `json:"channel"`
+			TS      string `json:"ts"`
+		} `json:"item"`
+	} `json:"event"`
+}
+
+// handleEvents answers the URL verification handshake and hands events to the enabled features
+func (s *Server) handleEvents(w http.ResponseWriter, req *http.Request) {
+	body, ok := s.readVerified(w, req)
+	if !ok {
+		return
+	}
+
+	var envelope eventEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		http.Error(w, "invalid event", http.StatusBadRequest)
+		return
+	}
+
+	switch envelope.Type {
+	case "url_verification":
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, envelope.Challenge)
+		return
+	case "event_callback":
+		event := envelope.Event
+		switch {
+		case event.Type == "app_home_opened" && event.Tab == "home" && s.Home != nil:
+			go s.Home.publish(req.Context(), event.User)
+		case event.Type == "reaction_added" && event.Item.Type == "message" && s.Details != nil:
+			s.Details.handleReaction(req.Context(), event.Reaction, event.Item.Channel, event.Item.TS)
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// interactionPayload is the subset of a block_actions payload we care about
+type interactionPayload struct {
+	Type string `json:"type"`
+	User struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	Container struct {
+		Type      string `json:"type"`
+		ChannelID string `json:"channel_id"`
+		MessageTS string `json:"message_ts"`
+		ThreadTS  string `json:"thread_ts"`
+	} `json:"container"`
+	Actions []struct {
+		ActionID string `json:"action_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+}
+
+// handleInteractions hands button clicks to the feature that owns the action
+func (s *Server) handleInteractions(w http.ResponseWriter, req *http.Request) {
+	body, ok := s.readVerified(w, req)
+	if !ok {
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	var payload interactionPayload
+	if err := json.Unmarshal([]byte(form.Get("payload")), &payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if payload.Type != "block_actions" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	ctx := req.Context()
+	logger := logf.FromContext(ctx)
+	republishHome := false
+	for _, action := range payload.Actions {
+		switch action.ActionID {
+		case actionSilence, actionExpire:
+			if s.Home == nil {
+				continue
+			}
+			if err := s.Home.runAction(ctx, payload.User.ID, payload.User.Username, action.ActionID, action.Value); err != nil {
+				logger.Error(err, "Failed to run App Home action", "action", action.ActionID, "user", payload.User.ID)
+			}
+			republishHome = true
+		case slack.ActionShowDetails:
+			if s.Details == nil {
+				continue
+			}
+			threadTS := payload.Container.ThreadTS
+			if threadTS == "" {
+				threadTS = payload.Container.MessageTS
+			}
+			s.Details.handleButton(ctx, action.Value, payload.Container.ChannelID, payload.Container.MessageTS, threadTS)
+		case slack.ActionCreateIssue:
+			if s.Issues == nil {
+				continue
+			}
+			threadTS := payload.Container.ThreadTS
+			if threadTS == "" {
+				threadTS = payload.Container.MessageTS
+			}
+			s.Issues.handleButton(ctx, payload.User.ID, payload.User.Username, action.Value, payload.Container.ChannelID, threadTS)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if republishHome {
+		go s.Home.publish(ctx, payload.User.ID)
+	}
+}
+
+// commandResponse is the ephemeral reply to a slash command
+type commandResponse struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
+
+// handleCommands answers slash commands with a reply only the invoking user sees
+func (s *Server) handleCommands(w http.ResponseWriter, req *http.Request) {
+	body, ok := s.readVerified(w, req)
+	if !ok {
+		return
+	}
+	if s.Commands == nil {
+		http.Error(w, "slash commands are disabled", http.StatusNotFound)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "invalid command", http.StatusBadRequest)
+		return
+	}
+	reply := s.Commands.run(req.Context(), form.Get("user_id"), form.Get("user_name"), form.Get("text"))
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(commandResponse{ResponseType: "ephemeral", Text: reply})
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stateapi serves the alerting state of the operator as read-only JSON, for
+// dashboards and chatops tools.
+package stateapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/store"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// Paths of the API, also used in RBAC rules granting access to it
+const (
+	AlertsPath    = "/api/v1/alerts"
+	IncidentsPath = "/api/v1/incidents"
+	SilencesPath  = "/api/v1/silences"
+	DebouncePath  = "/api/v1/debounce"
+)
+
+// Limits on the number of history entries returned
+const (
+	defaultLimit = 100
+	maxLimit     = 500
+)
+
+// AlertSource is the in-memory alerting state of the pod reconciler
+type AlertSource interface {
+	// OpenAlerts returns the alerts that were sent and have not recovered yet
+	OpenAlerts() []notify.PodAlert
+	// DebounceEntries returns when the alerts in the debounce cache were last sent
+	DebounceEntries() map[string]time.Time
+}
+
+// API serves alert history, open incidents, silences and the debounce cache. It has no
+// authentication of its own: its handlers are served by the metrics server, which
+// authenticates and authorizes requests like those for /metrics.
+type API struct {
+	Store  store.Store
+	Alerts AlertSource
+}
+
+// incident is an alert that has not recovered yet
+type incident struct {
+	Key       string    `json:"key"`
+	ID        string    `json:"id,omitempty"`
+	Cluster   string    `json:"cluster,omitempty"`
+	Namespace string    `json:"namespace,omitempty"`
+	Pod       string    `json:"pod,omitempty"`
+	Container string    `json:"container,omitempty"`
+	Workload  string    `json:"workload,omitempty"`
+	Reason    string    `json:"reason"`
+	Severity  string    `json:"severity"`
+	Channel   string    `json:"channel,omitempty"`
+	Team      string    `json:"team,omitempty"`
+	Since     time.Time `json:"since"`
+}
+
+// debounceEntry is an alert whose repeats are being suppressed
+type debounceEntry struct {
+	Key      string    `json:"key"`
+	LastSent time.Time `json:"lastSent"`
+}
+
+// Handlers returns the handlers of the API by path
+func (a *API) Handlers() map[string]http.Handler {
+	return map[string]http.Handler{
+		AlertsPath:    readOnly(a.alerts),
+		IncidentsPath: readOnly(a.incidents),
+		SilencesPath:  readOnly(a.silences),
+		DebouncePath:  readOnly(a.debounce),
+	}
+}
+
+// alerts lists recently sent alerts, newest first. Query parameters: namespace, reason and
+// limit (default 100, at most 500).
+func (a *API) alerts(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	limit := defaultLimit
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = min(parsed, maxLimit)
+	}
+
+	// Filter the whole history, so a filter does not come back short when recent alerts
+	// are for other namespaces
+	history, err := a.Store.History(req.Context(), maxLimit)
+	if err != nil {
+		logf.FromContext(req.Context()).Error(err, "Failed to read alert history")
+		writeError(w, http.StatusInternalServerError, "failed to read alert history")
+		return
+	}
+	alerts := []store.HistoryEntry{}
+	for _, entry := range history {
+		if len(alerts) == limit {
+			break
+		}
+		if matches(query.Get("namespace"), entry.Namespace) && matches(query.Get("reason"), entry.Reason) {
+			alerts = append(alerts, entry)
+		}
+	}
+	writeJSON(w, map[string]any{"alerts": alerts})
+}
+
+// incidents lists the alerts that have not recovered, oldest first. Query parameter: namespace.
+func (a *API) incidents(w http.ResponseWriter, req *http.Request) {
+	namespace := req.URL.Query().Get("namespace")
+	incidents := []incident{}
+	for _, alert := range a.Alerts.OpenAlerts() {
+		if !matches(namespace, alert.Namespace) {
+			continue
+		}
+		incidents = append(incidents, incident{
+			Key:       alert.Key,
+			ID:        alert.ID(),
+			Cluster:   alert.Cluster,
+			Namespace: alert.Namespace,
+			Pod:       alert.PodName,
+			Container: alert.ContainerName,
+			Workload:  alert.Workload(),
+			Reason:    alert.Reason,
+			Severity:  string(alert.AlertSeverity()),
+			Channel:   alert.Channel,
+			Team:      alert.Team,
+			Since:     alert.Timestamp,
+		})
+	}
+	sort.Slice(incidents, func(i, j int) bool { return incidents[i].Since.Before(incidents[j].Since) })
+	writeJSON(w, map[string]any{"incidents": incidents})
+}
+
+// silences lists the configured silences. Query parameter: active=true lists only the
+// silences in effect.
+func (a *API) silences(w http.ResponseWriter, req *http.Request) {
+	all, err := a.Store.Silences(req.Context())
+	if err != nil {
+		logf.FromContext(req.Context()).Error(err, "Failed to read silences")
+		writeError(w, http.StatusInternalServerError, "failed to read silences")
+		return
+	}
+	activeOnly := req.URL.Query().Get("active") == "true"
+	now := time.Now()
+	silences := []store.Silence{}
+	for _, silence := range all {
+		if !activeOnly || silence.Active(now) {
+			silences = append(silences, silence)
+		}
+	}
+	writeJSON(w, map[string]any{"silences": silences})
+}
+
+// debounce lists the alerts in the debounce cache, most recently sent first
+func (a *API) debounce(w http.ResponseWriter, _ *http.Request) {
+	entries := []debounceEntry{}
+	for key, lastSent := range a.Alerts.DebounceEntries() {
+		entries = append(entries, debounceEntry{Key: key, LastSent: lastSent})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].LastSent.After(entries[j].LastSent) })
+	writeJSON(w, map[string]any{"entries": entries})
+}
+
+// matches reports whether a value matches a query filter; an empty filter matches everything
+func matches(filter, value string) bool {
+	return filter == "" || filter == value
+}
+
+// readOnly answers anything but GET with 405
+func readOnly(handler http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		handler(w, req)
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/alertkey"
+)
+
+// KeyMigrator is implemented by stores that can rewrite persisted alert keys to the current
+// format ahead of an upgrade, rather than on the first load by the new version
+type KeyMigrator interface {
+	// MigrateKeys loads the persisted state, converts its keys and saves it
+	MigrateKeys(ctx context.Context) (MigrationResult, error)
+}
+
+// MigrationResult counts the keys converted by a migration
+type MigrationResult struct {
+	From, To int
+	// Migrated and Dropped count debounce entries. Dropped entries could not be converted;
+	// each may cause one repeat alert.
+	Migrated, Dropped int
+}
+
+// MigrateKeys implements KeyMigrator
+func (s *snapshotStore) MigrateKeys(ctx context.Context) (MigrationResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Load from the persister even if state is already in memory
+	s.snap = nil
+	if s.persister == nil {
+		return MigrationResult{}, fmt.Errorf("the memory state store has no persisted state to migrate")
+	}
+	snap, err := s.persister.load(ctx)
+	if err != nil {
+		return MigrationResult{}, err
+	}
+	if snap.Alerts == nil {
+		snap.Alerts = make(map[string]time.Time)
+	}
+
+	result := migrateKeys(snap, s.cluster)
+	if err := s.persister.save(ctx, snap); err != nil {
+		return result, err
+	}
+	s.snap = snap
+	return result, nil
+}
+
+// migrateKeys converts the keys of a snapshot written with an earlier key format
+func migrateKeys(snap *Snapshot, cluster string) MigrationResult {
+	result := MigrationResult{From: snap.KeyVersion, To: alertkey.Version}
+	if snap.KeyVersion >= alertkey.Version {
+		return result
+	}
+
+	// Version 0 is the first format, from before versions were recorded. Its keys embed the
+	// reason after a dash, which names can contain too, so reasons come from the history.
+	keyReasons := make(map[string]string)
+	reasonSet := make(map[string]bool)
+	for _, entry := range snap.History {
+		keyReasons[entry.Key] = entry.Reason
+		reasonSet[entry.Reason] = true
+	}
+	// Longer reasons first, so InitContainer-CrashLoopBackOff wins over CrashLoopBackOff
+	reasons := make([]string, 0, len(reasonSet))
+	for reason := range reasonSet {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool { return len(reasons[i]) > len(reasons[j]) })
+
+	convert := func(old string) (string, bool) {
+		if reason, ok := keyReasons[old]; ok {
+			if key, ok := alertkey.FromV1(old, cluster, reason); ok {
+				return key.String(), true
+			}
+		}
+		for _, reason := range reasons {
+			if key, ok := alertkey.FromV1(old, cluster, reason); ok {
+				return key.String(), true
+			}
+		}
+		return "", false
+	}
+
+	alerts := make(map[string]time.Time, len(snap.Alerts))
+	for old, at := range snap.Alerts {
+		if alertkey.IsRecord(old) {
+			alerts[old] = at
+			continue
+		}
+		key, ok := convert(old)
+		if !ok {
+			result.Dropped++
+			continue
+		}
+		if previous, exists := alerts[key]; !exists || at.After(previous) {
+			alerts[key] = at
+		}
+		result.Migrated++
+	}
+	snap.Alerts = alerts
+
+	// History and dead letters are informational; keys that cannot be converted are kept
+	for i := range snap.History {
+		if key, ok := convert(snap.History[i].Key); ok {
+			snap.History[i].Key = key
+		}
+	}
+	for i := range snap.DeadLetters {
+		if key, ok := convert(snap.DeadLetters[i].Key); ok {
+			snap.DeadLetters[i].Key = key
+		}
+	}
+
+	snap.KeyVersion = alertkey.Version
+	return result
+}
+
+// logMigration reports a migration done while loading state
+func logMigration(ctx context.Context, result MigrationResult) {
+	if result.From == result.To {
+		return
+	}
+	logf.FromContext(ctx).Info("Migrated persisted alert keys",
+		"from", result.From,
+		"to", result.To,
+		"migrated", result.Migrated,
+		"dropped", result.Dropped,
+	)
+}
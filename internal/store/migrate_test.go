@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/alertkey"
+)
+
+// TestMigrateKeys checks the conversion of persisted state from the first key format
+func TestMigrateKeys(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		snap        Snapshot
+		wantAlerts  map[string]time.Time
+		wantHistory []string
+		wantResult  MigrationResult
+	}{
+		{
+			name: "pod, workload and spot keys",
+			snap: Snapshot{
+				Alerts: map[string]time.Time{
+					"payments/api-7d9f-x2x-CrashLoopBackOff": t0,
+					"payments/Deployment/api-OOMKilled":      t1,
+					"spot/node/ip-10-0-0-1-SpotInterruption": t0,
+				},
+				History: []HistoryEntry{
+					{Key: "payments/api-7d9f-x2x-CrashLoopBackOff", Reason: "CrashLoopBackOff"},
+					{Key: "payments/Deployment/api-OOMKilled", Reason: "OOMKilled"},
+					{Key: "spot/node/ip-10-0-0-1-SpotInterruption", Reason: "SpotInterruption"},
+				},
+			},
+			wantAlerts: map[string]time.Time{
+				"v2/prod/payments/Pod/api-7d9f-x2x/CrashLoopBackOff": t0,
+				"v2/prod/payments/Deployment/api/OOMKilled":          t1,
+				"v2/prod//Node/ip-10-0-0-1/SpotInterruption":         t0,
+			},
+			wantHistory: []string{
+				"v2/prod/payments/Pod/api-7d9f-x2x/CrashLoopBackOff",
+				"v2/prod/payments/Deployment/api/OOMKilled",
+				"v2/prod//Node/ip-10-0-0-1/SpotInterruption",
+			},
+			wantResult: MigrationResult{From: 0, To: alertkey.Version, Migrated: 3},
+		},
+		{
+			name: "names containing reasons",
+			snap: Snapshot{
+				Alerts: map[string]time.Time{
+					// The history names the reason of this key
+					"payments/worker-Error-Error": t0,
+					// Without a history entry, the longest matching reason wins
+					"payments/api-0-InitContainer-CrashLoopBackOff": t1,
+				},
+				History: []HistoryEntry{
+					{Key: "payments/worker-Error-Error", Reason: "Error"},
+					{Key: "payments/web-CrashLoopBackOff", Reason: "CrashLoopBackOff"},
+					{Key: "payments/db-InitContainer-CrashLoopBackOff", Reason: "InitContainer-CrashLoopBackOff"},
+				},
+			},
+			wantAlerts: map[string]time.Time{
+				"v2/prod/payments/Pod/worker-Error/Error":                   t0,
+				"v2/prod/payments/Pod/api-0/InitContainer-CrashLoopBackOff": t1,
+			},
+			wantHistory: []string{
+				"v2/prod/payments/Pod/worker-Error/Error",
+				"v2/prod/payments/Pod/web/CrashLoopBackOff",
+				"v2/prod/payments/Pod/db/InitContainer-CrashLoopBackOff",
+			},
+			wantResult: MigrationResult{From: 0, To: alertkey.Version, Migrated: 2},
+		},
+		{
+			name: "records and unknown keys",
+			snap: Snapshot{
+				Alerts: map[string]time.Time{
+					alertkey.QuotaReport:     t0,
+					alertkey.HistoryExport:   t1,
+					"payments/api-0-Unknown": t0,
+				},
+				History: []HistoryEntry{
+					{Key: "payments/web-OOMKilled", Reason: "OOMKilled"},
+					{Key: "not-a-key", Reason: "Error"},
+				},
+			},
+			wantAlerts: map[string]time.Time{
+				alertkey.QuotaReport:   t0,
+				alertkey.HistoryExport: t1,
+			},
+			wantHistory: []string{"v2/prod/payments/Pod/web/OOMKilled", "not-a-key"},
+			wantResult:  MigrationResult{From: 0, To: alertkey.Version, Dropped: 1},
+		},
+		{
+			name: "current format",
+			snap: Snapshot{
+				KeyVersion: alertkey.Version,
+				Alerts: map[string]time.Time{
+					"v2/prod/payments/Pod/api-0/CrashLoopBackOff": t0,
+					alertkey.QuotaReport:                          t1,
+				},
+				History: []HistoryEntry{{Key: "v2/prod/payments/Pod/api-0/CrashLoopBackOff", Reason: "CrashLoopBackOff"}},
+			},
+			wantAlerts: map[string]time.Time{
+				"v2/prod/payments/Pod/api-0/CrashLoopBackOff": t0,
+				alertkey.QuotaReport:                          t1,
+			},
+			wantHistory: []string{"v2/prod/payments/Pod/api-0/CrashLoopBackOff"},
+			wantResult:  MigrationResult{From: alertkey.Version, To: alertkey.Version},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snap := tt.snap
+			result := migrateKeys(&snap, "prod")
+			if result != tt.wantResult {
+				t.Errorf("result = %+v, want %+v", result, tt.wantResult)
+			}
+			checkMigrated(t, &snap, tt.wantAlerts, tt.wantHistory)
+
+			// Migrated state is not migrated again
+			again := migrateKeys(&snap, "prod")
+			if want := (MigrationResult{From: alertkey.Version, To: alertkey.Version}); again != want {
+				t.Errorf("second migration result = %+v, want %+v", again, want)
+			}
+			checkMigrated(t, &snap, tt.wantAlerts, tt.wantHistory)
+		})
+	}
+}
+
+// checkMigrated compares the keys of a migrated snapshot
+func checkMigrated(t *testing.T, snap *Snapshot, wantAlerts map[string]time.Time, wantHistory []string) {
+	t.Helper()
+
+	if snap.KeyVersion != alertkey.Version {
+		t.Errorf("KeyVersion = %d, want %d", snap.KeyVersion, alertkey.Version)
+	}
+	if !maps.Equal(snap.Alerts, wantAlerts) {
+		t.Errorf("Alerts = %v, want %v", snap.Alerts, wantAlerts)
+	}
+	if len(snap.History) != len(wantHistory) {
+		t.Fatalf("History has %d entries, want %d", len(snap.History), len(wantHistory))
+	}
+	for i, entry := range snap.History {
+		if entry.Key != wantHistory[i] {
+			t.Errorf("History[%d].Key = %q, want %q", i, entry.Key, wantHistory[i])
+		}
+	}
+}
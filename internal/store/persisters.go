@@ -0,0 +1,441 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	geniev1alpha1 "github.com/ahmadrazalab/kube-slackgenie-operator/api/v1alpha1"
+)
+
+// configMapStateKey is the ConfigMap data key holding the JSON encoded snapshot
+const configMapStateKey = "state.json"
+
+// configMapPersister stores the snapshot as JSON in a ConfigMap
+type configMapPersister struct {
+	client client.Client
+	reader client.Reader
+	key    types.NamespacedName
+}
+
+func (p *configMapPersister) load(ctx context.Context) (*Snapshot, error) {
+	var cm corev1.ConfigMap
+	if err := p.reader.Get(ctx, p.key, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return &Snapshot{}, nil
+		}
+		return nil, fmt.Errorf("failed to read state ConfigMap: %w", err)
+	}
+
+	snap := &Snapshot{}
+	if data := cm.Data[configMapStateKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), snap); err != nil {
+			return nil, fmt.Errorf("failed to decode state ConfigMap: %w", err)
+		}
+	}
+	return snap, nil
+}
+
+func (p *configMapPersister) save(ctx context.Context, snap *Snapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	var cm corev1.ConfigMap
+	if err := p.reader.Get(ctx, p.key, &cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to read state ConfigMap: %w", err)
+		}
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: p.key.Namespace, Name: p.key.Name},
+			Data:       map[string]string{configMapStateKey: string(data)},
+		}
+		return p.client.Create(ctx, &cm)
+	}
+
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[configMapStateKey] = string(data)
+	return p.client.Update(ctx, &cm)
+}
+
+// +kubebuilder:rbac:groups=genie.slackgenie.io,resources=geniestates,verbs=get;list;watch;create;update
+
+// crdPersister stores the snapshot in the spec of a GenieState custom resource
+type crdPersister struct {
+	client client.Client
+	reader client.Reader
+	key    types.NamespacedName
+}
+
+func (p *crdPersister) load(ctx context.Context) (*Snapshot, error) {
+	var state geniev1alpha1.GenieState
+	if err := p.reader.Get(ctx, p.key, &state); err != nil {
+		if apierrors.IsNotFound(err) {
+			return &Snapshot{}, nil
+		}
+		return nil, fmt.Errorf("failed to read GenieState: %w", err)
+	}
+
+	return snapshotFromSpec(&state.Spec), nil
+}
+
+func (p *crdPersister) save(ctx context.Context, snap *Snapshot) error {
+	var state geniev1alpha1.GenieState
+	if err := p.reader.Get(ctx, p.key, &state); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to read GenieState: %w", err)
+		}
+		state = geniev1alpha1.GenieState{
+			ObjectMeta: metav1.ObjectMeta{Namespace: p.key.Namespace, Name: p.key.Name},
+			Spec:       specFromSnapshot(snap),
+		}
+		return p.client.Create(ctx, &state)
+	}
+
+	state.Spec = specFromSnapshot(snap)
+	return p.client.Update(ctx, &state)
+}
+
+func snapshotFromSpec(spec *geniev1alpha1.GenieStateSpec) *Snapshot {
+	snap := &Snapshot{Alerts: make(map[string]time.Time, len(spec.Alerts)), KeyVersion: spec.KeyVersion}
+	for key, at := range spec.Alerts {
+		snap.Alerts[key] = at.Time
+	}
+	for _, h := range spec.History {
+		snap.History = append(snap.History, HistoryEntry{
+			Key:       h.Key,
+			Namespace: h.Namespace,
+			Pod:       h.Pod,
+			Reason:    h.Reason,
+			Channel:   h.Channel,
+			Timestamp: h.Timestamp.Time,
+		})
+	}
+	for _, s := range spec.Silences {
+		silence := Silence{
+			ID:        s.ID,
+			Namespace: s.Namespace,
+			Pod:       s.Pod,
+			Reason:    s.Reason,
+			Selector:  s.Selector,
+			StartsAt:  s.StartsAt.Time,
+			EndsAt:    s.EndsAt.Time,
+			CreatedBy: s.CreatedBy,
+			Comment:   s.Comment,
+		}
+		if s.CreatedAt != nil {
+			silence.CreatedAt = s.CreatedAt.Time
+		}
+		snap.Silences = append(snap.Silences, silence)
+	}
+	for _, d := range spec.DeadLetters {
+		snap.DeadLetters = append(snap.DeadLetters, DeadLetter{
+			Kind:      d.Kind,
+			Key:       d.Key,
+			Namespace: d.Namespace,
+			Pod:       d.Pod,
+			Reason:    d.Reason,
+			Title:     d.Title,
+			Error:     d.Error,
+			FailedAt:  d.FailedAt.Time,
+		})
+	}
+	for _, a := range spec.OpenAlerts {
+		alert := OpenAlert{
+			Key:          a.Key,
+			Cluster:      a.Cluster,
+			Namespace:    a.Namespace,
+			Pod:          a.Pod,
+			Container:    a.Container,
+			WorkloadKind: a.WorkloadKind,
+			WorkloadName: a.WorkloadName,
+			Reason:       a.Reason,
+			Severity:     a.Severity,
+			Channel:      a.Channel,
+			Team:         a.Team,
+			Timestamp:    a.Timestamp.Time,
+			Escalated:    a.Escalated,
+		}
+		if a.OpenedAt != nil {
+			alert.OpenedAt = a.OpenedAt.Time
+		}
+		snap.OpenAlerts = append(snap.OpenAlerts, alert)
+	}
+	return snap
+}
+
+func specFromSnapshot(snap *Snapshot) geniev1alpha1.GenieStateSpec {
+	spec := geniev1alpha1.GenieStateSpec{Alerts: make(map[string]metav1.Time, len(snap.Alerts)), KeyVersion: snap.KeyVersion}
+	for key, at := range snap.Alerts {
+		spec.Alerts[key] = metav1.NewTime(at)
+	}
+	for _, h := range snap.History {
+		spec.History = append(spec.History, geniev1alpha1.AlertHistoryEntry{
+			Key:       h.Key,
+			Namespace: h.Namespace,
+			Pod:       h.Pod,
+			Reason:    h.Reason,
+			Channel:   h.Channel,
+			Timestamp: metav1.NewTime(h.Timestamp),
+		})
+	}
+	for _, s := range snap.Silences {
+		silence := geniev1alpha1.Silence{
+			ID:        s.ID,
+			Namespace: s.Namespace,
+			Pod:       s.Pod,
+			Reason:    s.Reason,
+			Selector:  s.Selector,
+			StartsAt:  metav1.NewTime(s.StartsAt),
+			EndsAt:    metav1.NewTime(s.EndsAt),
+			CreatedBy: s.CreatedBy,
+			Comment:   s.Comment,
+		}
+		if !s.CreatedAt.IsZero() {
+			createdAt := metav1.NewTime(s.CreatedAt)
+			silence.CreatedAt = &createdAt
+		}
+		spec.Silences = append(spec.Silences, silence)
+	}
+	for _, d := range snap.DeadLetters {
+		spec.DeadLetters = append(spec.DeadLetters, geniev1alpha1.DeadLetter{
+			Kind:      d.Kind,
+			Key:       d.Key,
+			Namespace: d.Namespace,
+			Pod:       d.Pod,
+			Reason:    d.Reason,
+			Title:     d.Title,
+			Error:     d.Error,
+			FailedAt:  metav1.NewTime(d.FailedAt),
+		})
+	}
+	for _, a := range snap.OpenAlerts {
+		alert := geniev1alpha1.OpenAlert{
+			Key:          a.Key,
+			Cluster:      a.Cluster,
+			Namespace:    a.Namespace,
+			Pod:          a.Pod,
+			Container:    a.Container,
+			WorkloadKind: a.WorkloadKind,
+			WorkloadName: a.WorkloadName,
+			Reason:       a.Reason,
+			Severity:     a.Severity,
+			Channel:      a.Channel,
+			Team:         a.Team,
+			Timestamp:    metav1.NewTime(a.Timestamp),
+			Escalated:    a.Escalated,
+		}
+		if !a.OpenedAt.IsZero() {
+			openedAt := metav1.NewTime(a.OpenedAt)
+			alert.OpenedAt = &openedAt
+		}
+		spec.OpenAlerts = append(spec.OpenAlerts, alert)
+	}
+	return spec
+}
+
+// filePersister stores the snapshot as a JSON file, e.g. on a mounted PersistentVolume
+type filePersister struct {
+	path string
+}
+
+func (p *filePersister) load(_ context.Context) (*Snapshot, error) {
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &Snapshot{}, nil
+		}
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	snap := &Snapshot{}
+	if err := json.Unmarshal(data, snap); err != nil {
+		return nil, fmt.Errorf("failed to decode state file: %w", err)
+	}
+	return snap, nil
+}
+
+func (p *filePersister) save(_ context.Context, snap *Snapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	// Write to a temporary file and rename so a crash never leaves a truncated state file
+	tmp, err := os.CreateTemp(filepath.Dir(p.path), ".state-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return os.Rename(tmp.Name(), p.path)
+}
+
+// Buckets of the bbolt database
+var (
+	boltAlertsBucket = []byte("alerts")
+	boltStateBucket  = []byte("state")
+)
+
+// boltStateField is a snapshot field stored as a JSON value in the state bucket
+type boltStateField struct {
+	key   []byte
+	value any
+}
+
+// boltStateFields returns pointers to the snapshot fields kept in the state bucket
+func boltStateFields(snap *Snapshot) []boltStateField {
+	return []boltStateField{
+		{key: []byte("history"), value: &snap.History},
+		{key: []byte("silences"), value: &snap.Silences},
+		{key: []byte("deadLetters"), value: &snap.DeadLetters},
+		{key: []byte("openAlerts"), value: &snap.OpenAlerts},
+		{key: []byte("keyVersion"), value: &snap.KeyVersion},
+	}
+}
+
+// boltPersister stores the snapshot in a bbolt database, e.g. on a mounted PersistentVolume.
+// Alert times are stored one key each, so a save only writes the alerts that changed; the
+// bounded lists are stored as JSON values. Every save is a single transaction.
+type boltPersister struct {
+	db *bolt.DB
+}
+
+// openBoltPersister opens or creates the database at path. bbolt locks the file, so a second
+// process using the same file fails here instead of corrupting it.
+func openBoltPersister(path string) (*boltPersister, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state database: %w", err)
+	}
+	return &boltPersister{db: db}, nil
+}
+
+func (p *boltPersister) load(_ context.Context) (*Snapshot, error) {
+	snap := &Snapshot{Alerts: make(map[string]time.Time)}
+	err := p.db.View(func(tx *bolt.Tx) error {
+		if alerts := tx.Bucket(boltAlertsBucket); alerts != nil {
+			err := alerts.ForEach(func(key, value []byte) error {
+				var at time.Time
+				if err := at.UnmarshalBinary(value); err != nil {
+					return fmt.Errorf("alert %q: %w", key, err)
+				}
+				snap.Alerts[string(key)] = at
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		state := tx.Bucket(boltStateBucket)
+		if state == nil {
+			return nil
+		}
+		for _, field := range boltStateFields(snap) {
+			if value := state.Get(field.key); value != nil {
+				if err := json.Unmarshal(value, field.value); err != nil {
+					return fmt.Errorf("%s: %w", field.key, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state database: %w", err)
+	}
+	return snap, nil
+}
+
+func (p *boltPersister) save(_ context.Context, snap *Snapshot) error {
+	err := p.db.Update(func(tx *bolt.Tx) error {
+		alerts, err := tx.CreateBucketIfNotExists(boltAlertsBucket)
+		if err != nil {
+			return err
+		}
+		// Deleting while iterating a cursor can skip keys, so collect them first
+		var removed [][]byte
+		err = alerts.ForEach(func(key, _ []byte) error {
+			if _, ok := snap.Alerts[string(key)]; !ok {
+				removed = append(removed, key)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range removed {
+			if err := alerts.Delete(key); err != nil {
+				return err
+			}
+		}
+		for key, at := range snap.Alerts {
+			value, err := at.MarshalBinary()
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(alerts.Get([]byte(key)), value) {
+				if err := alerts.Put([]byte(key), value); err != nil {
+					return err
+				}
+			}
+		}
+
+		state, err := tx.CreateBucketIfNotExists(boltStateBucket)
+		if err != nil {
+			return err
+		}
+		for _, field := range boltStateFields(snap) {
+			data, err := json.Marshal(field.value)
+			if err != nil {
+				return err
+			}
+			if err := state.Put(field.key, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write state database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,348 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Snapshot is the complete persisted state of a store
+type Snapshot struct {
+	Alerts      map[string]time.Time `json:"alerts,omitempty"`
+	History     []HistoryEntry       `json:"history,omitempty"`
+	Silences    []Silence            `json:"silences,omitempty"`
+	DeadLetters []DeadLetter         `json:"deadLetters,omitempty"`
+	OpenAlerts  []OpenAlert          `json:"openAlerts,omitempty"`
+	// KeyVersion is the alert key format of Alerts, History and DeadLetters
+	KeyVersion int `json:"keyVersion,omitempty"`
+}
+
+// persister loads and saves a whole Snapshot to a backing medium
+type persister interface {
+	// load returns the persisted snapshot, or an empty snapshot if none exists yet
+	load(ctx context.Context) (*Snapshot, error)
+	save(ctx context.Context, snap *Snapshot) error
+}
+
+// silencesTTL is how long silences edited out-of-band may take to apply
+const silencesTTL = 15 * time.Second
+
+// snapshotStore keeps the state in memory and writes it through to an optional persister
+// after every mutation. With a nil persister it is a pure in-memory store.
+type snapshotStore struct {
+	mu   sync.Mutex
+	snap *Snapshot
+	// loadedAt is when snap was read from the persister
+	loadedAt time.Time
+	// unsaved is set while snap holds changes the persister failed to save; such a snapshot is
+	// never dropped for a reload, and the next mutation saves it again
+	unsaved    bool
+	persister  persister
+	maxHistory int
+	// cluster is the cluster name in alert keys, used when migrating keys of earlier formats
+	cluster string
+}
+
+func newSnapshotStore(p persister, maxHistory int, cluster string) *snapshotStore {
+	return &snapshotStore{
+		persister:  p,
+		maxHistory: maxHistory,
+		cluster:    cluster,
+	}
+}
+
+// ensureLoaded lazily loads the persisted snapshot. Callers must hold s.mu.
+func (s *snapshotStore) ensureLoaded(ctx context.Context) error {
+	if s.snap != nil {
+		return nil
+	}
+
+	snap := &Snapshot{}
+	if s.persister != nil {
+		loaded, err := s.persister.load(ctx)
+		if err != nil {
+			return err
+		}
+		snap = loaded
+	}
+	if snap.Alerts == nil {
+		snap.Alerts = make(map[string]time.Time)
+	}
+	// The migrated keys are persisted with the next mutation
+	logMigration(ctx, migrateKeys(snap, s.cluster))
+
+	s.snap = snap
+	s.loadedAt = time.Now()
+	return nil
+}
+
+// mutate applies fn to the loaded snapshot and persists the result. Callers must not hold s.mu.
+func (s *snapshotStore) mutate(ctx context.Context, fn func(*Snapshot)) error {
+	return s.mutateIf(ctx, func(snap *Snapshot) bool {
+		fn(snap)
+		return true
+	})
+}
+
+// mutateIf applies fn to the loaded snapshot and persists the result if fn reports a change,
+// so no-op mutations cost no write to the backend. Callers must not hold s.mu.
+func (s *snapshotStore) mutateIf(ctx context.Context, fn func(*Snapshot) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureLoaded(ctx); err != nil {
+		return err
+	}
+
+	if !fn(s.snap) || s.persister == nil {
+		return nil
+	}
+	return s.saveLocked(ctx)
+}
+
+// saveLocked persists the snapshot, remembering whether it holds unsaved changes. Callers must
+// hold s.mu.
+func (s *snapshotStore) saveLocked(ctx context.Context) error {
+	if err := s.persister.save(ctx, s.snap); err != nil {
+		s.unsaved = true
+		return err
+	}
+	s.unsaved = false
+	return nil
+}
+
+// dropLocked discards the snapshot so the next access reloads it, unless it holds changes that
+// were not saved. Callers must hold s.mu.
+func (s *snapshotStore) dropLocked() {
+	if s.persister != nil && !s.unsaved {
+		s.snap = nil
+	}
+}
+
+// Reload implements Reloader. A pure in-memory store has nothing to reload.
+func (s *snapshotStore) Reload() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dropLocked()
+}
+
+func (s *snapshotStore) LastAlert(ctx context.Context, key string) (time.Time, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureLoaded(ctx); err != nil {
+		return time.Time{}, false, err
+	}
+
+	at, exists := s.snap.Alerts[key]
+	return at, exists, nil
+}
+
+func (s *snapshotStore) RecordAlert(ctx context.Context, key string, at time.Time) error {
+	return s.mutate(ctx, func(snap *Snapshot) {
+		snap.Alerts[key] = at
+	})
+}
+
+func (s *snapshotStore) RecordAlerts(ctx context.Context, alerts map[string]time.Time) error {
+	return s.mutateIf(ctx, func(snap *Snapshot) bool {
+		for key, at := range alerts {
+			snap.Alerts[key] = at
+		}
+		return len(alerts) > 0
+	})
+}
+
+func (s *snapshotStore) ForgetAlerts(ctx context.Context, prefix string) error {
+	// Most deleted pods never alerted, so their deletion must not rewrite the backend
+	return s.mutateIf(ctx, func(snap *Snapshot) bool {
+		removed := false
+		for key := range snap.Alerts {
+			if strings.HasPrefix(key, prefix) {
+				delete(snap.Alerts, key)
+				removed = true
+			}
+		}
+		return removed
+	})
+}
+
+func (s *snapshotStore) ExpireAlerts(ctx context.Context, expired func(key string, at time.Time) bool) (int, error) {
+	removed := 0
+	err := s.mutateIf(ctx, func(snap *Snapshot) bool {
+		for key, at := range snap.Alerts {
+			if expired(key, at) {
+				delete(snap.Alerts, key)
+				removed++
+			}
+		}
+		return removed > 0
+	})
+	return removed, err
+}
+
+func (s *snapshotStore) AppendHistory(ctx context.Context, entry HistoryEntry) error {
+	return s.mutate(ctx, func(snap *Snapshot) {
+		snap.History = append(snap.History, entry)
+		if len(snap.History) > s.maxHistory {
+			snap.History = snap.History[len(snap.History)-s.maxHistory:]
+		}
+	})
+}
+
+func (s *snapshotStore) History(ctx context.Context, limit int) ([]HistoryEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+
+	n := len(s.snap.History)
+	if limit <= 0 || limit > n {
+		limit = n
+	}
+
+	entries := make([]HistoryEntry, 0, limit)
+	for i := n - 1; i >= n-limit; i-- {
+		entries = append(entries, s.snap.History[i])
+	}
+	return entries, nil
+}
+
+func (s *snapshotStore) Silences(ctx context.Context) ([]Silence, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Silences may be edited out-of-band (kubectl edit), so persisted state is re-read once
+	// it is older than silencesTTL. Silences are checked on every reconcile of a failing pod,
+	// which must not cost an API request each.
+	if s.snap != nil && time.Since(s.loadedAt) >= silencesTTL {
+		s.dropLocked()
+	}
+	if err := s.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+
+	return append([]Silence(nil), s.snap.Silences...), nil
+}
+
+func (s *snapshotStore) PutSilence(ctx context.Context, silence Silence) error {
+	return s.mutate(ctx, func(snap *Snapshot) {
+		for i := range snap.Silences {
+			if snap.Silences[i].ID == silence.ID {
+				snap.Silences[i] = silence
+				return
+			}
+		}
+		snap.Silences = append(snap.Silences, silence)
+	})
+}
+
+func (s *snapshotStore) DeleteSilence(ctx context.Context, id string) error {
+	return s.mutate(ctx, func(snap *Snapshot) {
+		for i := range snap.Silences {
+			if snap.Silences[i].ID == id {
+				snap.Silences = append(snap.Silences[:i], snap.Silences[i+1:]...)
+				return
+			}
+		}
+	})
+}
+
+func (s *snapshotStore) OpenAlerts(ctx context.Context) ([]OpenAlert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+
+	return append([]OpenAlert(nil), s.snap.OpenAlerts...), nil
+}
+
+func (s *snapshotStore) PutOpenAlert(ctx context.Context, alert OpenAlert) error {
+	return s.mutate(ctx, func(snap *Snapshot) {
+		for i := range snap.OpenAlerts {
+			if snap.OpenAlerts[i].Key == alert.Key {
+				alert.OpenedAt, alert.Escalated = snap.OpenAlerts[i].OpenedAt, snap.OpenAlerts[i].Escalated
+				snap.OpenAlerts[i] = alert
+				return
+			}
+		}
+		if alert.OpenedAt.IsZero() {
+			alert.OpenedAt = alert.Timestamp
+		}
+		snap.OpenAlerts = append(snap.OpenAlerts, alert)
+	})
+}
+
+func (s *snapshotStore) MarkEscalated(ctx context.Context, key string, rule int) error {
+	return s.mutateIf(ctx, func(snap *Snapshot) bool {
+		for i := range snap.OpenAlerts {
+			if snap.OpenAlerts[i].Key == key && !slices.Contains(snap.OpenAlerts[i].Escalated, rule) {
+				snap.OpenAlerts[i].Escalated = append(snap.OpenAlerts[i].Escalated, rule)
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (s *snapshotStore) DeleteOpenAlerts(ctx context.Context, keys []string) error {
+	return s.mutateIf(ctx, func(snap *Snapshot) bool {
+		n := len(snap.OpenAlerts)
+		snap.OpenAlerts = slices.DeleteFunc(snap.OpenAlerts, func(alert OpenAlert) bool {
+			return slices.Contains(keys, alert.Key)
+		})
+		return len(snap.OpenAlerts) < n
+	})
+}
+
+func (s *snapshotStore) AppendDeadLetter(ctx context.Context, letter DeadLetter) error {
+	return s.mutate(ctx, func(snap *Snapshot) {
+		snap.DeadLetters = append(snap.DeadLetters, letter)
+		if len(snap.DeadLetters) > s.maxHistory {
+			snap.DeadLetters = snap.DeadLetters[len(snap.DeadLetters)-s.maxHistory:]
+		}
+	})
+}
+
+func (s *snapshotStore) DeadLetters(ctx context.Context, limit int) ([]DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureLoaded(ctx); err != nil {
+		return nil, err
+	}
+
+	n := len(s.snap.DeadLetters)
+	if limit <= 0 || limit > n {
+		limit = n
+	}
+
+	letters := make([]DeadLetter, 0, limit)
+	for i := n - 1; i >= n-limit; i-- {
+		letters = append(letters, s.snap.DeadLetters[i])
+	}
+	return letters, nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package store provides pluggable persistence for alert state, history and silences.
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Supported backends
+const (
+	BackendMemory    = "memory"
+	BackendConfigMap = "configmap"
+	BackendCRD       = "crd"
+	BackendFile      = "file"
+	BackendBolt      = "bbolt"
+)
+
+// defaultMaxHistory bounds the number of history entries kept by a store
+const defaultMaxHistory = 500
+
+// HistoryEntry records a single alert that was sent
+type HistoryEntry struct {
+	Key       string    `json:"key"`
+	Namespace string    `json:"namespace,omitempty"`
+	Pod       string    `json:"pod,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	Channel   string    `json:"channel,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// DeadLetter records a delivery that still failed after all retries
+type DeadLetter struct {
+	Kind      string    `json:"kind"`
+	Key       string    `json:"key,omitempty"`
+	Namespace string    `json:"namespace,omitempty"`
+	Pod       string    `json:"pod,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Error     string    `json:"error"`
+	FailedAt  time.Time `json:"failedAt"`
+}
+
+// OpenAlert records a sent alert that has not been resolved yet, with the fields notifiers route
+// and resolve it by, so a new leader can still resolve it. The message is not kept, as
+// enrichment can make it several kilobytes.
+type OpenAlert struct {
+	Key          string    `json:"key"`
+	Cluster      string    `json:"cluster,omitempty"`
+	Namespace    string    `json:"namespace,omitempty"`
+	Pod          string    `json:"pod,omitempty"`
+	Container    string    `json:"container,omitempty"`
+	WorkloadKind string    `json:"workloadKind,omitempty"`
+	WorkloadName string    `json:"workloadName,omitempty"`
+	Reason       string    `json:"reason,omitempty"`
+	Severity     string    `json:"severity,omitempty"`
+	Channel      string    `json:"channel,omitempty"`
+	Team         string    `json:"team,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+	// OpenedAt is when the first alert of the incident was sent; Timestamp moves on with
+	// repeat alerts and reminders
+	OpenedAt time.Time `json:"openedAt,omitzero"`
+	// Escalated holds the indexes of the escalation rules that already fired
+	Escalated []int `json:"escalated,omitempty"`
+}
+
+// Silence suppresses alerts matching its matchers between StartsAt and EndsAt.
+// Empty matchers match everything.
+type Silence struct {
+	ID        string `json:"id"`
+	Namespace string `json:"namespace,omitempty"`
+	Pod       string `json:"pod,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	// Selector is a label selector on the pod's labels
+	Selector  string    `json:"selector,omitempty"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitzero"`
+	Comment   string    `json:"comment,omitempty"`
+}
+
+// Active reports whether the silence is in effect at the given time
+func (s Silence) Active(now time.Time) bool {
+	return !now.Before(s.StartsAt) && now.Before(s.EndsAt)
+}
+
+// Matches reports whether the silence applies to the given pod and reason. A silence with an
+// invalid selector matches nothing.
+func (s Silence) Matches(namespace, pod, reason string, podLabels map[string]string) bool {
+	if (s.Namespace != "" && s.Namespace != namespace) ||
+		(s.Pod != "" && s.Pod != pod) ||
+		(s.Reason != "" && s.Reason != reason) {
+		return false
+	}
+	if s.Selector == "" {
+		return true
+	}
+	selector, err := labels.Parse(s.Selector)
+	return err == nil && selector.Matches(labels.Set(podLabels))
+}
+
+// Store persists alert state, history and silences. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// LastAlert returns when an alert with the given key was last sent
+	LastAlert(ctx context.Context, key string) (time.Time, bool, error)
+	// RecordAlert records that an alert with the given key was sent at the given time
+	RecordAlert(ctx context.Context, key string, at time.Time) error
+	// RecordAlerts records the times of several alerts in a single write
+	RecordAlerts(ctx context.Context, alerts map[string]time.Time) error
+	// ForgetAlerts removes alert state for all keys starting with the given prefix
+	ForgetAlerts(ctx context.Context, prefix string) error
+	// ExpireAlerts removes alert state for which expired returns true and reports how many
+	// keys it removed
+	ExpireAlerts(ctx context.Context, expired func(key string, at time.Time) bool) (int, error)
+
+	// AppendHistory adds an entry to the alert history
+	AppendHistory(ctx context.Context, entry HistoryEntry) error
+	// History returns up to limit of the most recent history entries, newest first
+	History(ctx context.Context, limit int) ([]HistoryEntry, error)
+
+	// Silences returns all configured silences
+	Silences(ctx context.Context) ([]Silence, error)
+	// PutSilence creates or replaces the silence with the same ID
+	PutSilence(ctx context.Context, silence Silence) error
+	// DeleteSilence removes the silence with the given ID
+	DeleteSilence(ctx context.Context, id string) error
+
+	// OpenAlerts returns the alerts that were sent and have not been resolved
+	OpenAlerts(ctx context.Context) ([]OpenAlert, error)
+	// PutOpenAlert records an unresolved alert, replacing the one with the same key but keeping
+	// when its incident opened and which escalations fired
+	PutOpenAlert(ctx context.Context, alert OpenAlert) error
+	// MarkEscalated records that the escalation rule with the given index fired for the open
+	// alert with the given key
+	MarkEscalated(ctx context.Context, key string, rule int) error
+	// DeleteOpenAlerts removes the open alerts with the given keys
+	DeleteOpenAlerts(ctx context.Context, keys []string) error
+
+	// AppendDeadLetter records an undeliverable alert
+	AppendDeadLetter(ctx context.Context, letter DeadLetter) error
+	// DeadLetters returns up to limit of the most recent dead letters, newest first
+	DeadLetters(ctx context.Context, limit int) ([]DeadLetter, error)
+}
+
+// Reloader is implemented by stores that cache persisted state, so a replica that becomes the
+// leader can drop what it loaded as a standby and read the state the previous leader wrote
+type Reloader interface {
+	// Reload discards cached state; the next access loads the persisted state
+	Reload()
+}
+
+// Options selects and configures a Store backend
+type Options struct {
+	// Backend is one of memory, configmap, crd, file or bbolt
+	Backend string
+	// Namespace and Name identify the ConfigMap or GenieState object for those backends
+	Namespace string
+	Name      string
+	// Path is the state file for the file backend, or the database for the bbolt backend
+	Path string
+	// MaxHistory bounds the number of retained history entries and dead letters
+	MaxHistory int
+	// Cluster is the cluster name in alert keys, used when migrating keys of earlier formats
+	Cluster string
+}
+
+// New creates the Store backend selected by opts. The reader is used to load persisted
+// state directly from the API server, bypassing the informer cache.
+func New(c client.Client, reader client.Reader, opts Options) (Store, error) {
+	maxHistory := opts.MaxHistory
+	if maxHistory <= 0 {
+		maxHistory = defaultMaxHistory
+	}
+
+	key := types.NamespacedName{Namespace: opts.Namespace, Name: opts.Name}
+
+	switch opts.Backend {
+	case "", BackendMemory:
+		return newSnapshotStore(nil, maxHistory, opts.Cluster), nil
+	case BackendConfigMap:
+		if key.Namespace == "" || key.Name == "" {
+			return nil, fmt.Errorf("configmap state store requires a namespace and name")
+		}
+		return newSnapshotStore(&configMapPersister{client: c, reader: reader, key: key}, maxHistory, opts.Cluster), nil
+	case BackendCRD:
+		if key.Namespace == "" || key.Name == "" {
+			return nil, fmt.Errorf("crd state store requires a namespace and name")
+		}
+		return newSnapshotStore(&crdPersister{client: c, reader: reader, key: key}, maxHistory, opts.Cluster), nil
+	case BackendFile:
+		if opts.Path == "" {
+			return nil, fmt.Errorf("file state store requires a path")
+		}
+		return newSnapshotStore(&filePersister{path: opts.Path}, maxHistory, opts.Cluster), nil
+	case BackendBolt:
+		if opts.Path == "" {
+			return nil, fmt.Errorf("bbolt state store requires a path")
+		}
+		p, err := openBoltPersister(opts.Path)
+		if err != nil {
+			return nil, err
+		}
+		return newSnapshotStore(p, maxHistory, opts.Cluster), nil
+	default:
+		return nil, fmt.Errorf("unknown state store backend %q", opts.Backend)
+	}
+}
@@ -0,0 +1,240 @@
+// Package cloudevents sends alerts as CloudEvents v1.0 over HTTP, e.g. to a Knative broker or an
+// Argo Events webhook, so pod failures can trigger event-driven automation.
+package cloudevents
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/filesink"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// Event types, one per record kind
+const (
+	TypePodAlert    = "io.slackgenie.pod.alert"
+	TypePodResolved = "io.slackgenie.pod.resolved"
+	TypeMetaAlert   = "io.slackgenie.meta.alert"
+)
+
+// Content modes of the HTTP protocol binding
+const (
+	// ModeBinary carries the attributes in ce- headers and the record as the body
+	ModeBinary = "binary"
+	// ModeStructured carries the whole event as an application/cloudevents+json body
+	ModeStructured = "structured"
+)
+
+// specVersion is the CloudEvents specification version of the events
+const specVersion = "1.0"
+
+// Options configures the CloudEvents notifier
+type Options struct {
+	// URL is the sink events are posted to
+	URL string
+	// Source is the event source attribute
+	Source string
+	// Mode is binary (default) or structured
+	Mode string
+	// Headers are added to every request. Values are expanded with environment variables.
+	Headers map[string]string
+}
+
+// Event is a CloudEvent in structured mode. In binary mode the same attributes are sent as
+// headers.
+type Event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Severity        string          `json:"severity,omitempty"`
+	Reason          string          `json:"reason,omitempty"`
+	Data            filesink.Record `json:"data"`
+}
+
+// Notifier posts a CloudEvent per alert, with a filesink.Record as its data
+type Notifier struct {
+	url        string
+	source     string
+	mode       string
+	headers    http.Header
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+// NewNotifier creates a new CloudEvents notifier
+func NewNotifier(logger logr.Logger, opts Options) (*Notifier, error) {
+	endpoint, err := url.Parse(opts.URL)
+	if err != nil || (endpoint.Scheme != "https" && endpoint.Scheme != "http") || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid CloudEvents sink URL %q", opts.URL)
+	}
+	mode := opts.Mode
+	if mode == "" {
+		mode = ModeBinary
+	}
+	if mode != ModeBinary && mode != ModeStructured {
+		return nil, fmt.Errorf("unsupported CloudEvents mode %q", opts.Mode)
+	}
+
+	headers := make(http.Header, len(opts.Headers))
+	for name, value := range opts.Headers {
+		headers.Set(name, os.ExpandEnv(value))
+	}
+
+	return &Notifier{
+		url:     opts.URL,
+		source:  opts.Source,
+		mode:    mode,
+		headers: headers,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		logger: logger,
+	}, nil
+}
+
+// SendPodAlert sends a pod alert event, with the pod as its subject
+func (n *Notifier) SendPodAlert(alert notify.PodAlert) error {
+	if err := n.send(n.podEvent(TypePodAlert, filesink.PodRecord(filesink.KindPodAlert, alert))); err != nil {
+		return err
+	}
+	n.logger.V(1).Info("CloudEvent sent",
+		"pod", alert.PodName,
+		"namespace", alert.Namespace,
+		"reason", alert.Reason,
+	)
+	return nil
+}
+
+// ResolvePodAlert sends an event marking the alert's incident as recovered
+func (n *Notifier) ResolvePodAlert(alert notify.PodAlert) error {
+	record := filesink.PodRecord(filesink.KindPodResolved, alert)
+	record.Timestamp = time.Now()
+	return n.send(n.podEvent(TypePodResolved, record))
+}
+
+// SendMetaAlert sends an operator message event
+func (n *Notifier) SendMetaAlert(alert notify.MetaAlert) error {
+	return n.send(n.event(TypeMetaAlert, filesink.Record{
+		Kind:      filesink.KindMetaAlert,
+		Timestamp: alert.Timestamp,
+		Severity:  alert.Severity,
+		Channel:   alert.Channel,
+		Title:     alert.Title,
+		Text:      alert.Text,
+	}))
+}
+
+// podEvent wraps a pod record, adding the attributes triggers usually filter on
+func (n *Notifier) podEvent(eventType string, record filesink.Record) Event {
+	event := n.event(eventType, record)
+	event.Subject = record.Namespace + "/" + record.Pod
+	event.Reason = record.Reason
+	return event
+}
+
+// event wraps a record with a new event ID
+func (n *Notifier) event(eventType string, record filesink.Record) Event {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	at := record.Timestamp
+	if at.IsZero() {
+		at = time.Now()
+	}
+	return Event{
+		SpecVersion:     specVersion,
+		ID:              hex.EncodeToString(id),
+		Source:          n.source,
+		Type:            eventType,
+		Time:            at.UTC(),
+		DataContentType: "application/json",
+		Severity:        string(record.Severity),
+		Data:            record,
+	}
+}
+
+// send posts the event in the configured content mode
+func (n *Notifier) send(event Event) error {
+	var body []byte
+	var err error
+	if n.mode == ModeStructured {
+		body, err = json.Marshal(event)
+	} else {
+		body, err = json.Marshal(event.Data)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode CloudEvent: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create CloudEvents request: %w", err)
+	}
+	req.Header = n.headers.Clone()
+	if n.mode == ModeStructured {
+		req.Header.Set("Content-Type", "application/cloudevents+json; charset=utf-8")
+	} else {
+		req.Header.Set("Content-Type", event.DataContentType)
+		setAttributeHeaders(req.Header, event)
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send CloudEvent: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return notify.StatusError("CloudEvents sink", resp)
+	}
+	return nil
+}
+
+// setAttributeHeaders sets the ce- headers of the binary content mode
+func setAttributeHeaders(header http.Header, event Event) {
+	attributes := map[string]string{
+		"specversion": event.SpecVersion,
+		"id":          event.ID,
+		"source":      event.Source,
+		"type":        event.Type,
+		"subject":     event.Subject,
+		"time":        event.Time.Format(time.RFC3339Nano),
+		"severity":    event.Severity,
+		"reason":      event.Reason,
+	}
+	for name, value := range attributes {
+		if value != "" {
+			header.Set("Ce-"+name, encodeHeaderValue(value))
+		}
+	}
+}
+
+// encodeHeaderValue percent-encodes the characters the HTTP binding requires to be encoded
+func encodeHeaderValue(value string) string {
+	var b strings.Builder
+	for _, c := range []byte(value) {
+		if c < 0x20 || c > 0x7e || c == '%' || c == '"' {
+			fmt.Fprintf(&b, "%%%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// SetTransport implements notify.TransportSetter
+func (n *Notifier) SetTransport(transport http.RoundTripper) {
+	n.httpClient.Transport = transport
+}
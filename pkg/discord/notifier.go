@@ -0,0 +1,193 @@
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/version"
+)
+
+// Embed colors by severity
+const (
+	colorCritical = 0xE01E5A
+	colorWarning  = 0xECB22E
+	colorInfo     = 0x36C5F0
+)
+
+// WebhookMessage represents the structure of a Discord webhook message
+type WebhookMessage struct {
+	Username string  `json:"username,omitempty"`
+	Content  string  `json:"content,omitempty"`
+	Embeds   []Embed `json:"embeds"`
+}
+
+// Embed represents a Discord rich embed
+type Embed struct {
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	Color       int          `json:"color"`
+	Fields      []EmbedField `json:"fields,omitempty"`
+	Footer      *EmbedFooter `json:"footer,omitempty"`
+	Timestamp   string       `json:"timestamp,omitempty"`
+}
+
+// EmbedFooter is the small text shown at the bottom of an embed
+type EmbedFooter struct {
+	Text string `json:"text"`
+}
+
+// EmbedField is a name/value pair shown in an embed
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// Notifier handles Discord notifications
+type Notifier struct {
+	webhookURL string
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+// NewNotifier creates a new Discord notifier instance from the DISCORD_WEBHOOK_URL environment variable
+func NewNotifier(logger logr.Logger) (*Notifier, error) {
+	webhookURL := os.Getenv("DISCORD_WEBHOOK_URL")
+	if webhookURL == "" {
+		return nil, fmt.Errorf("DISCORD_WEBHOOK_URL environment variable not set")
+	}
+
+	return &Notifier{
+		webhookURL: webhookURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		logger: logger,
+	}, nil
+}
+
+// SendPodAlert sends an embed alert to the Discord webhook
+func (n *Notifier) SendPodAlert(alert notify.PodAlert) error {
+	if err := n.post(n.buildMessage(alert)); err != nil {
+		return err
+	}
+
+	n.logger.Info("Discord alert sent successfully",
+		"pod", alert.PodName,
+		"namespace", alert.Namespace,
+		"reason", alert.Reason,
+		"restarts", alert.RestartCount,
+	)
+
+	return nil
+}
+
+// SendMetaAlert sends an operator-generated message to the Discord webhook
+func (n *Notifier) SendMetaAlert(alert notify.MetaAlert) error {
+	if err := n.post(WebhookMessage{
+		Username: "Kube-SlackGenie",
+		Embeds: []Embed{
+			{
+				Title:       alert.Title,
+				Description: alert.Text,
+				Color:       colorForSeverity(alert.Severity),
+				Footer:      &EmbedFooter{Text: version.Footer()},
+				Timestamp:   alert.Timestamp.Format(time.RFC3339),
+			},
+		},
+	}); err != nil {
+		return err
+	}
+
+	n.logger.Info("Discord meta-alert sent successfully", "title", alert.Title)
+	return nil
+}
+
+// post delivers a message to the Discord webhook
+func (n *Notifier) post(msg WebhookMessage) error {
+	jsonData, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Discord message: %w", err)
+	}
+
+	resp, err := n.httpClient.Post(n.webhookURL, "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to send Discord notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Discord answers 204 No Content unless ?wait=true is set on the webhook URL
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("discord webhook returned status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// buildMessage maps the pod alert onto embed fields
+func (n *Notifier) buildMessage(alert notify.PodAlert) WebhookMessage {
+	fields := []EmbedField{
+		{Name: "Pod", Value: alert.PodName, Inline: true},
+		{Name: "Namespace", Value: alert.Namespace, Inline: true},
+		{Name: "Container", Value: orDash(alert.ContainerName), Inline: true},
+		{Name: "Image", Value: orDash(alert.Image)},
+		{Name: "Reason", Value: orDash(alert.Reason), Inline: true},
+		{Name: "Restarts", Value: fmt.Sprintf("%d", alert.RestartCount), Inline: true},
+	}
+	if workload := alert.Workload(); workload != "" {
+		fields = append([]EmbedField{{Name: "Workload", Value: workload}}, fields...)
+	}
+	if exit := alert.ExitSummary(); exit != "" {
+		fields = append(fields, EmbedField{Name: "Exit code", Value: exit})
+	}
+	if node := alert.NodeSummary(); node != "" {
+		fields = append(fields, EmbedField{Name: "Node", Value: node, Inline: true})
+	}
+	if alert.Team != "" {
+		fields = append(fields, EmbedField{Name: "Owner", Value: alert.Team, Inline: true})
+	}
+
+	return WebhookMessage{
+		Username: "Kube-SlackGenie",
+		Embeds: []Embed{
+			{
+				Title:       fmt.Sprintf("%s: %s/%s", alert.Reason, alert.Namespace, alert.PodName),
+				Description: alert.Message,
+				Color:       colorForSeverity(alert.AlertSeverity()),
+				Fields:      fields,
+				Timestamp:   alert.Timestamp.Format(time.RFC3339),
+			},
+		},
+	}
+}
+
+// colorForSeverity returns the embed color for a severity
+func colorForSeverity(severity notify.Severity) int {
+	switch severity {
+	case notify.SeverityCritical:
+		return colorCritical
+	case notify.SeverityWarning:
+		return colorWarning
+	default:
+		return colorInfo
+	}
+}
+
+// orDash substitutes a dash for empty values, since Discord rejects empty embed fields
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// SetTransport implements notify.TransportSetter
+func (n *Notifier) SetTransport(transport http.RoundTripper) {
+	n.httpClient.Transport = transport
+}
@@ -0,0 +1,229 @@
+package email
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/version"
+)
+
+// TLS modes
+const (
+	// TLSStartTLS upgrades a plain connection with STARTTLS, typically on port 587
+	TLSStartTLS = "starttls"
+	// TLSImplicit connects over TLS from the start, typically on port 465
+	TLSImplicit = "tls"
+	// TLSNone sends in clear text and is only meant for local relays
+	TLSNone = "none"
+)
+
+// dialTimeout bounds connecting to the SMTP server
+const dialTimeout = 30 * time.Second
+
+// Options configures the email notifier
+type Options struct {
+	// Host and Port of the SMTP server
+	Host string
+	Port int
+	// TLS is one of starttls (default), tls or none
+	TLS string
+	// From is the sender address
+	From string
+	// To are the default recipients
+	To []string
+	// NamespaceRecipients replaces the default recipients for alerts from specific namespaces
+	NamespaceRecipients map[string][]string
+}
+
+// Notifier sends alerts by email. SMTP credentials are read from the SMTP_USERNAME and
+// SMTP_PASSWORD environment variables; without them no authentication is attempted.
+type Notifier struct {
+	opts     Options
+	username string
+	password string
+	logger   logr.Logger
+}
+
+// NewNotifier creates a new email notifier
+func NewNotifier(logger logr.Logger, opts Options) (*Notifier, error) {
+	if opts.Host == "" || opts.From == "" {
+		return nil, fmt.Errorf("email host and from address are required")
+	}
+	if opts.TLS == "" {
+		opts.TLS = TLSStartTLS
+	}
+	if opts.Port == 0 {
+		opts.Port = 587
+		if opts.TLS == TLSImplicit {
+			opts.Port = 465
+		}
+	}
+
+	return &Notifier{
+		opts:     opts,
+		username: os.Getenv("SMTP_USERNAME"),
+		password: os.Getenv("SMTP_PASSWORD"),
+		logger:   logger,
+	}, nil
+}
+
+// SendPodAlert emails the alert to the recipients of its namespace
+func (n *Notifier) SendPodAlert(alert notify.PodAlert) error {
+	recipients := n.recipientsFor(alert.Namespace)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	subject := fmt.Sprintf("[%s] %s: %s/%s", alert.AlertSeverity(), alert.Reason, alert.Namespace, alert.PodName)
+
+	var body strings.Builder
+	if workload := alert.Workload(); workload != "" {
+		fmt.Fprintf(&body, "Workload:  %s\n", workload)
+	}
+	fmt.Fprintf(&body, "Pod:       %s\n", alert.PodName)
+	fmt.Fprintf(&body, "Namespace: %s\n", alert.Namespace)
+	fmt.Fprintf(&body, "Container: %s\n", alert.ContainerName)
+	fmt.Fprintf(&body, "Image:     %s\n", alert.Image)
+	fmt.Fprintf(&body, "Reason:    %s\n", alert.Reason)
+	fmt.Fprintf(&body, "Restarts:  %d\n", alert.RestartCount)
+	fmt.Fprintf(&body, "Time:      %s\n", alert.Timestamp.Format(time.RFC3339))
+	if exit := alert.ExitSummary(); exit != "" {
+		fmt.Fprintf(&body, "Exit code: %s\n", exit)
+	}
+	if node := alert.NodeSummary(); node != "" {
+		fmt.Fprintf(&body, "Node:      %s\n", node)
+	}
+	if alert.Team != "" {
+		fmt.Fprintf(&body, "Owner:     %s\n", alert.Team)
+	}
+	if alert.LogURL != "" {
+		fmt.Fprintf(&body, "Logs:      %s\n", alert.LogURL)
+	}
+	fmt.Fprintf(&body, "\n%s\n", alert.Message)
+
+	if err := n.send(recipients, subject, body.String()); err != nil {
+		return err
+	}
+
+	n.logger.Info("Email alert sent successfully",
+		"pod", alert.PodName,
+		"namespace", alert.Namespace,
+		"reason", alert.Reason,
+		"recipients", len(recipients),
+	)
+
+	return nil
+}
+
+// SendMetaAlert emails an operator-generated message to the default recipients
+func (n *Notifier) SendMetaAlert(alert notify.MetaAlert) error {
+	if len(n.opts.To) == 0 {
+		return nil
+	}
+
+	if err := n.send(n.opts.To, alert.Title, alert.Text+"\n\n-- \n"+version.Footer()+"\n"); err != nil {
+		return err
+	}
+
+	n.logger.Info("Email meta-alert sent successfully", "title", alert.Title)
+	return nil
+}
+
+// recipientsFor returns the recipients for alerts from a namespace
+func (n *Notifier) recipientsFor(namespace string) []string {
+	if recipients, ok := n.opts.NamespaceRecipients[namespace]; ok {
+		return recipients
+	}
+	return n.opts.To
+}
+
+// send delivers a plain text message over SMTP
+func (n *Notifier) send(to []string, subject, body string) error {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.opts.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	client, err := n.dial()
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	defer client.Close()
+
+	if n.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.username, n.password, n.opts.Host)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(n.opts.From); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM failed: %w", err)
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("SMTP RCPT TO %s failed: %w", recipient, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA failed: %w", err)
+	}
+	if _, err := w.Write(msg.Bytes()); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// dial connects to the SMTP server using the configured TLS mode
+func (n *Notifier) dial() (*smtp.Client, error) {
+	addr := net.JoinHostPort(n.opts.Host, strconv.Itoa(n.opts.Port))
+	tlsConfig := &tls.Config{ServerName: n.opts.Host, MinVersion: tls.VersionTLS12}
+	dialer := &net.Dialer{Timeout: dialTimeout}
+
+	if n.opts.TLS == TLSImplicit {
+		conn, err := tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		return smtp.NewClient(conn, n.opts.Host)
+	}
+
+	conn, err := dialer.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	client, err := smtp.NewClient(conn, n.opts.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if n.opts.TLS == TLSStartTLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("STARTTLS failed: %w", err)
+		}
+	}
+	return client, nil
+}
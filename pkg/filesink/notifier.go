@@ -0,0 +1,159 @@
+// Package filesink writes alerts as JSON lines to stdout or a file, so the operator can feed
+// log pipelines such as Loki or Splunk without any chat integration.
+package filesink
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// Kinds of records written by the sink
+const (
+	KindPodAlert    = "pod_alert"
+	KindPodResolved = "pod_resolved"
+	KindMetaAlert   = "meta_alert"
+)
+
+// Record is one JSON line written by the sink
+type Record struct {
+	Kind      string          `json:"kind"`
+	Timestamp time.Time       `json:"timestamp"`
+	Severity  notify.Severity `json:"severity"`
+
+	// Pod alerts and resolutions
+	Namespace      string                 `json:"namespace,omitempty"`
+	Pod            string                 `json:"pod,omitempty"`
+	WorkloadKind   string                 `json:"workloadKind,omitempty"`
+	WorkloadName   string                 `json:"workloadName,omitempty"`
+	Container      string                 `json:"container,omitempty"`
+	Image          string                 `json:"image,omitempty"`
+	Reason         string                 `json:"reason,omitempty"`
+	Message        string                 `json:"message,omitempty"`
+	RestartCount   int32                  `json:"restartCount,omitempty"`
+	Key            string                 `json:"key,omitempty"`
+	Team           string                 `json:"team,omitempty"`
+	Channel        string                 `json:"channel,omitempty"`
+	Fingerprint    string                 `json:"fingerprint,omitempty"`
+	KnownIssue     *notify.Issue          `json:"knownIssue,omitempty"`
+	LogURL         string                 `json:"logURL,omitempty"`
+	Links          []notify.Link          `json:"links,omitempty"`
+	Trace          []string               `json:"trace,omitempty"`
+	Node           string                 `json:"node,omitempty"`
+	NodeConditions []notify.NodeCondition `json:"nodeConditions,omitempty"`
+	ExitCode       *int32                 `json:"exitCode,omitempty"`
+	Signal         int32                  `json:"signal,omitempty"`
+
+	// Meta alerts
+	Title string `json:"title,omitempty"`
+	Text  string `json:"text,omitempty"`
+}
+
+// Notifier appends a JSON record per alert to its output
+type Notifier struct {
+	mu     sync.Mutex
+	out    io.Writer
+	path   string
+	logger logr.Logger
+}
+
+// NewNotifier creates a sink writing to the file at path, which is created if needed and
+// appended to. An empty path or "-" writes to stdout.
+func NewNotifier(logger logr.Logger, path string) (*Notifier, error) {
+	if path == "" || path == "-" {
+		return &Notifier{out: os.Stdout, path: "stdout", logger: logger}, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open alert file: %w", err)
+	}
+	return &Notifier{out: file, path: path, logger: logger}, nil
+}
+
+// SendPodAlert writes a pod alert record
+func (n *Notifier) SendPodAlert(alert notify.PodAlert) error {
+	if err := n.write(PodRecord(KindPodAlert, alert)); err != nil {
+		return err
+	}
+	n.logger.V(1).Info("Alert written to file sink",
+		"pod", alert.PodName,
+		"namespace", alert.Namespace,
+		"reason", alert.Reason,
+		"output", n.path,
+	)
+	return nil
+}
+
+// ResolvePodAlert writes a record marking the alert's incident as recovered
+func (n *Notifier) ResolvePodAlert(alert notify.PodAlert) error {
+	record := PodRecord(KindPodResolved, alert)
+	record.Timestamp = time.Now()
+	return n.write(record)
+}
+
+// SendMetaAlert writes a meta alert record
+func (n *Notifier) SendMetaAlert(alert notify.MetaAlert) error {
+	return n.write(Record{
+		Kind:      KindMetaAlert,
+		Timestamp: alert.Timestamp,
+		Severity:  alert.Severity,
+		Channel:   alert.Channel,
+		Title:     alert.Title,
+		Text:      alert.Text,
+	})
+}
+
+// write appends a record as a single line, so concurrent deliveries never interleave
+func (n *Notifier) write(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode alert record: %w", err)
+	}
+	line = append(line, '\n')
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, err := n.out.Write(line); err != nil {
+		return fmt.Errorf("failed to write alert record to %s: %w", n.path, err)
+	}
+	return nil
+}
+
+// PodRecord converts a pod alert into a record of the given kind. The Kafka sink publishes
+// the same records.
+func PodRecord(kind string, alert notify.PodAlert) Record {
+	return Record{
+		Kind:           kind,
+		Timestamp:      alert.Timestamp,
+		Severity:       alert.AlertSeverity(),
+		Namespace:      alert.Namespace,
+		Pod:            alert.PodName,
+		WorkloadKind:   alert.WorkloadKind,
+		WorkloadName:   alert.WorkloadName,
+		Container:      alert.ContainerName,
+		Image:          alert.Image,
+		Reason:         alert.Reason,
+		Message:        alert.Message,
+		RestartCount:   alert.RestartCount,
+		Key:            alert.Key,
+		Team:           alert.Team,
+		Channel:        alert.Channel,
+		Fingerprint:    alert.Fingerprint,
+		KnownIssue:     alert.KnownIssue,
+		LogURL:         alert.LogURL,
+		Links:          alert.Links,
+		Trace:          alert.Trace,
+		Node:           alert.Node,
+		NodeConditions: alert.NodeConditions,
+		ExitCode:       alert.ExitCode,
+		Signal:         alert.Signal,
+	}
+}
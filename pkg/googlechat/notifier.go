@@ -0,0 +1,306 @@
+package googlechat
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/version"
+)
+
+// Severity label colors
+const (
+	colorCritical = "#E01E5A"
+	colorWarning  = "#ECB22E"
+	colorInfo     = "#36C5F0"
+)
+
+// WebhookMessage represents a Google Chat message with cards v2
+type WebhookMessage struct {
+	// Text is shown in notifications, which do not render cards
+	Text    string   `json:"text,omitempty"`
+	CardsV2 []CardV2 `json:"cardsV2,omitempty"`
+	Thread  *Thread  `json:"thread,omitempty"`
+}
+
+// Thread groups messages with the same key into one thread of the space
+type Thread struct {
+	ThreadKey string `json:"threadKey"`
+}
+
+// CardV2 wraps a card with its ID
+type CardV2 struct {
+	CardID string `json:"cardId"`
+	Card   Card   `json:"card"`
+}
+
+// Card is a Google Chat card
+type Card struct {
+	Header   *CardHeader `json:"header,omitempty"`
+	Sections []Section   `json:"sections"`
+}
+
+// CardHeader is the title of a card
+type CardHeader struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+// Section is a group of widgets
+type Section struct {
+	Header                    string   `json:"header,omitempty"`
+	Collapsible               bool     `json:"collapsible,omitempty"`
+	UncollapsibleWidgetsCount int      `json:"uncollapsibleWidgetsCount,omitempty"`
+	Widgets                   []Widget `json:"widgets"`
+}
+
+// Widget is one element of a section; exactly one field is set
+type Widget struct {
+	DecoratedText *DecoratedText `json:"decoratedText,omitempty"`
+	TextParagraph *TextParagraph `json:"textParagraph,omitempty"`
+	ButtonList    *ButtonList    `json:"buttonList,omitempty"`
+}
+
+// DecoratedText is a labelled value
+type DecoratedText struct {
+	TopLabel string `json:"topLabel"`
+	Text     string `json:"text"`
+}
+
+// TextParagraph is a block of text, formatted with a small subset of HTML
+type TextParagraph struct {
+	Text string `json:"text"`
+}
+
+// ButtonList is a row of buttons
+type ButtonList struct {
+	Buttons []Button `json:"buttons"`
+}
+
+// Button opens a link
+type Button struct {
+	Text    string  `json:"text"`
+	OnClick OnClick `json:"onClick"`
+}
+
+// OnClick is the action of a button
+type OnClick struct {
+	OpenLink OpenLink `json:"openLink"`
+}
+
+// OpenLink opens the URL
+type OpenLink struct {
+	URL string `json:"url"`
+}
+
+// Notifier handles Google Chat notifications
+type Notifier struct {
+	webhookURL string
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+// NewNotifier creates a new Google Chat notifier instance from the GOOGLE_CHAT_WEBHOOK_URL
+// environment variable
+func NewNotifier(logger logr.Logger) (*Notifier, error) {
+	webhookURL := os.Getenv("GOOGLE_CHAT_WEBHOOK_URL")
+	if webhookURL == "" {
+		return nil, fmt.Errorf("GOOGLE_CHAT_WEBHOOK_URL environment variable not set")
+	}
+
+	// Reply in the incident's thread, starting one for its first alert
+	endpoint, err := url.Parse(webhookURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GOOGLE_CHAT_WEBHOOK_URL: %w", err)
+	}
+	query := endpoint.Query()
+	query.Set("messageReplyOption", "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD")
+	endpoint.RawQuery = query.Encode()
+
+	return &Notifier{
+		webhookURL: endpoint.String(),
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		logger: logger,
+	}, nil
+}
+
+// SendPodAlert sends a card alert to the Google Chat webhook, in the thread of its incident
+func (n *Notifier) SendPodAlert(alert notify.PodAlert) error {
+	if err := n.post(n.buildMessage(alert)); err != nil {
+		return err
+	}
+
+	n.logger.Info("Google Chat alert sent successfully",
+		"pod", alert.PodName,
+		"namespace", alert.Namespace,
+		"reason", alert.Reason,
+		"restarts", alert.RestartCount,
+	)
+
+	return nil
+}
+
+// ResolvePodAlert replies in the incident's thread that the pod recovered
+func (n *Notifier) ResolvePodAlert(alert notify.PodAlert) error {
+	return n.post(WebhookMessage{
+		Text:   fmt.Sprintf("✅ Resolved: %s %s/%s", alert.Reason, alert.Namespace, alert.PodName),
+		Thread: threadFor(alert),
+	})
+}
+
+// SendMetaAlert sends an operator-generated message to the Google Chat webhook
+func (n *Notifier) SendMetaAlert(alert notify.MetaAlert) error {
+	if err := n.post(WebhookMessage{
+		Text: alert.Title,
+		CardsV2: []CardV2{{
+			CardID: "meta",
+			Card: Card{
+				Header: &CardHeader{Title: alert.Title, Subtitle: version.Footer()},
+				Sections: []Section{{Widgets: []Widget{
+					{DecoratedText: &DecoratedText{TopLabel: "Severity", Text: severityLabel(alert.Severity)}},
+					{TextParagraph: &TextParagraph{Text: html.EscapeString(alert.Text)}},
+				}}},
+			},
+		}},
+	}); err != nil {
+		return err
+	}
+
+	n.logger.Info("Google Chat meta-alert sent successfully", "title", alert.Title)
+	return nil
+}
+
+// post delivers a message to the Google Chat webhook
+func (n *Notifier) post(msg WebhookMessage) error {
+	jsonData, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Google Chat message: %w", err)
+	}
+
+	resp, err := n.httpClient.Post(n.webhookURL, "application/json; charset=UTF-8", bytes.NewBuffer(jsonData))
+	if err != nil {
+		// The URL carries the webhook's key and token, so leave it out of the error
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to send Google Chat notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%w: %s", notify.StatusError("Google Chat webhook", resp), body)
+	}
+	return nil
+}
+
+// buildMessage maps the pod alert onto a card
+func (n *Notifier) buildMessage(alert notify.PodAlert) WebhookMessage {
+	title := fmt.Sprintf("%s: %s/%s", alert.Reason, alert.Namespace, alert.PodName)
+
+	details := []Widget{
+		{DecoratedText: &DecoratedText{TopLabel: "Severity", Text: severityLabel(alert.AlertSeverity())}},
+	}
+	if workload := alert.Workload(); workload != "" {
+		details = append(details, labelled("Workload", workload))
+	}
+	details = append(details,
+		labelled("Container", alert.ContainerName),
+		labelled("Image", alert.Image),
+		labelled("Restarts", fmt.Sprintf("%d", alert.RestartCount)),
+	)
+	if exit := alert.ExitSummary(); exit != "" {
+		details = append(details, labelled("Exit code", exit))
+	}
+	if node := alert.NodeSummary(); node != "" {
+		details = append(details, labelled("Node", node))
+	}
+	if alert.Team != "" {
+		details = append(details, labelled("Owner", alert.Team))
+	}
+
+	sections := []Section{{Widgets: details}}
+	if alert.Message != "" {
+		sections = append(sections, Section{
+			Header:  "Message",
+			Widgets: []Widget{{TextParagraph: &TextParagraph{Text: html.EscapeString(alert.Message)}}},
+		})
+	}
+
+	var buttons []Button
+	if alert.LogURL != "" {
+		buttons = append(buttons, linkButton("View recent logs", alert.LogURL))
+	}
+	for _, link := range alert.Links {
+		buttons = append(buttons, linkButton(link.Name, link.URL))
+	}
+	if alert.KnownIssue != nil {
+		buttons = append(buttons, linkButton(fmt.Sprintf("Known issue #%d", alert.KnownIssue.Number), alert.KnownIssue.URL))
+	}
+	if len(buttons) > 0 {
+		sections = append(sections, Section{Widgets: []Widget{{ButtonList: &ButtonList{Buttons: buttons}}}})
+	}
+
+	return WebhookMessage{
+		Text: title,
+		CardsV2: []CardV2{{
+			CardID: "alert",
+			Card: Card{
+				Header:   &CardHeader{Title: title, Subtitle: alert.Timestamp.Format(time.RFC3339)},
+				Sections: sections,
+			},
+		}},
+		Thread: threadFor(alert),
+	}
+}
+
+// threadFor returns the thread of the alert's incident, if it has a key
+func threadFor(alert notify.PodAlert) *Thread {
+	if id := alert.ID(); id != "" {
+		return &Thread{ThreadKey: id}
+	}
+	return nil
+}
+
+// labelled returns a decorated text widget, escaping the value
+func labelled(label, value string) Widget {
+	if value == "" {
+		value = "-"
+	}
+	return Widget{DecoratedText: &DecoratedText{TopLabel: label, Text: html.EscapeString(value)}}
+}
+
+// linkButton returns a button opening the URL
+func linkButton(text, link string) Button {
+	return Button{Text: text, OnClick: OnClick{OpenLink: OpenLink{URL: link}}}
+}
+
+// severityLabel renders the severity in its color
+func severityLabel(severity notify.Severity) string {
+	color := colorInfo
+	switch severity {
+	case notify.SeverityCritical:
+		color = colorCritical
+	case notify.SeverityWarning:
+		color = colorWarning
+	}
+	return fmt.Sprintf(`<font color="%s"><b>%s</b></font>`, color, html.EscapeString(string(severity)))
+}
+
+// SetTransport implements notify.TransportSetter
+func (n *Notifier) SetTransport(transport http.RoundTripper) {
+	n.httpClient.Transport = transport
+}
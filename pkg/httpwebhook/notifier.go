@@ -0,0 +1,163 @@
+package httpwebhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/slack"
+)
+
+// Options configures the generic webhook notifier
+type Options struct {
+	// URL is the endpoint alerts are sent to
+	URL string
+	// Method is the HTTP method, POST by default
+	Method string
+	// Headers are added to every request. Values are expanded with environment variables,
+	// so credentials can be injected from a Secret, e.g. "Bearer ${INCIDENT_API_TOKEN}".
+	Headers map[string]string
+	// Template renders the request body for pod alerts
+	Template string
+	// MetaTemplate renders the request body for operator-generated messages. Empty skips them.
+	MetaTemplate string
+}
+
+// Notifier sends alerts to an arbitrary HTTP endpoint with a user-supplied payload template
+type Notifier struct {
+	url          string
+	method       string
+	headers      http.Header
+	template     *template.Template
+	metaTemplate *template.Template
+	httpClient   *http.Client
+	logger       logr.Logger
+}
+
+// NewNotifier creates a new generic webhook notifier
+func NewNotifier(logger logr.Logger, opts Options) (*Notifier, error) {
+	endpoint, err := url.Parse(opts.URL)
+	if err != nil || (endpoint.Scheme != "https" && endpoint.Scheme != "http") || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid webhook URL %q", opts.URL)
+	}
+
+	method := opts.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	headers := make(http.Header, len(opts.Headers)+1)
+	headers.Set("Content-Type", "application/json")
+	for name, value := range opts.Headers {
+		headers.Set(name, os.ExpandEnv(value))
+	}
+
+	tmpl, err := parseTemplate("template", opts.Template)
+	if err != nil {
+		return nil, err
+	}
+	var metaTmpl *template.Template
+	if opts.MetaTemplate != "" {
+		if metaTmpl, err = parseTemplate("metaTemplate", opts.MetaTemplate); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Notifier{
+		url:          opts.URL,
+		method:       method,
+		headers:      headers,
+		template:     tmpl,
+		metaTemplate: metaTmpl,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		logger: logger,
+	}, nil
+}
+
+// parseTemplate parses a payload template with the alert template functions and a json
+// function for safely embedding strings in JSON payloads
+func parseTemplate(name, text string) (*template.Template, error) {
+	funcs := slack.TemplateFuncs()
+	funcs["json"] = func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	}
+
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse webhook %s: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// SendPodAlert renders the template with the alert and sends it to the endpoint
+func (n *Notifier) SendPodAlert(alert notify.PodAlert) error {
+	if err := n.send(n.template, alert); err != nil {
+		return err
+	}
+
+	n.logger.Info("Webhook alert sent successfully",
+		"pod", alert.PodName,
+		"namespace", alert.Namespace,
+		"reason", alert.Reason,
+		"restarts", alert.RestartCount,
+	)
+
+	return nil
+}
+
+// SendMetaAlert renders the meta template with the message, if one is configured
+func (n *Notifier) SendMetaAlert(alert notify.MetaAlert) error {
+	if n.metaTemplate == nil {
+		return nil
+	}
+
+	if err := n.send(n.metaTemplate, alert); err != nil {
+		return err
+	}
+
+	n.logger.Info("Webhook meta-alert sent successfully", "title", alert.Title)
+	return nil
+}
+
+// send renders a payload and delivers it to the endpoint
+func (n *Notifier) send(tmpl *template.Template, data any) error {
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		return fmt.Errorf("failed to render webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequest(n.method, n.url, &body)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header = n.headers.Clone()
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned status code %d: %s", resp.StatusCode, respBody)
+	}
+	return nil
+}
+
+// SetTransport implements notify.TransportSetter
+func (n *Notifier) SetTransport(transport http.RoundTripper) {
+	n.httpClient.Transport = transport
+}
@@ -0,0 +1,147 @@
+// Package kafka publishes alerts as JSON to a Kafka topic, so they can feed analytics and
+// incident correlation pipelines alongside chat notifications.
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/filesink"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// Supported SASL mechanisms
+const (
+	SASLPlain       = "plain"
+	SASLSCRAMSHA256 = "scram-sha-256"
+	SASLSCRAMSHA512 = "scram-sha-512"
+)
+
+// writeTimeout bounds a single publish, so an unreachable cluster fails the delivery and the
+// dispatcher retries it
+const writeTimeout = 10 * time.Second
+
+// Options configure the Kafka notifier
+type Options struct {
+	// Brokers are the bootstrap brokers, host:port
+	Brokers []string
+	// Topic receives the alerts
+	Topic string
+	// SASL is the SASL mechanism, empty to connect without authentication. The credentials are
+	// read from the KAFKA_USERNAME and KAFKA_PASSWORD environment variables.
+	SASL string
+	// TLS connects to the brokers over TLS when set
+	TLS *tls.Config
+}
+
+// Notifier publishes a filesink.Record per alert, keyed by the alert key so the records of an
+// incident stay in order on one partition
+type Notifier struct {
+	writer *kafkago.Writer
+	topic  string
+	logger logr.Logger
+}
+
+// NewNotifier creates a Kafka notifier. Brokers are not contacted until the first alert.
+func NewNotifier(logger logr.Logger, opts Options) (*Notifier, error) {
+	if len(opts.Brokers) == 0 || opts.Topic == "" {
+		return nil, fmt.Errorf("kafka brokers and topic must be set")
+	}
+
+	var mechanism sasl.Mechanism
+	if opts.SASL != "" {
+		username, password := os.Getenv("KAFKA_USERNAME"), os.Getenv("KAFKA_PASSWORD")
+		if username == "" || password == "" {
+			return nil, fmt.Errorf("KAFKA_USERNAME and KAFKA_PASSWORD environment variables must be set for SASL")
+		}
+		var err error
+		switch opts.SASL {
+		case SASLPlain:
+			mechanism = plain.Mechanism{Username: username, Password: password}
+		case SASLSCRAMSHA256:
+			mechanism, err = scram.Mechanism(scram.SHA256, username, password)
+		case SASLSCRAMSHA512:
+			mechanism, err = scram.Mechanism(scram.SHA512, username, password)
+		default:
+			err = fmt.Errorf("unsupported SASL mechanism %q", opts.SASL)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Notifier{
+		writer: &kafkago.Writer{
+			Addr:         kafkago.TCP(opts.Brokers...),
+			Topic:        opts.Topic,
+			Balancer:     &kafkago.Hash{},
+			RequiredAcks: kafkago.RequireAll,
+			// Alerts are sent one at a time, so waiting to fill a batch only delays them
+			BatchTimeout: 10 * time.Millisecond,
+			Transport: &kafkago.Transport{
+				TLS:  opts.TLS,
+				SASL: mechanism,
+			},
+		},
+		topic:  opts.Topic,
+		logger: logger,
+	}, nil
+}
+
+// SendPodAlert publishes a pod alert record
+func (n *Notifier) SendPodAlert(alert notify.PodAlert) error {
+	if err := n.publish(alert.Key, filesink.PodRecord(filesink.KindPodAlert, alert)); err != nil {
+		return err
+	}
+	n.logger.V(1).Info("Alert published to Kafka",
+		"pod", alert.PodName,
+		"namespace", alert.Namespace,
+		"reason", alert.Reason,
+		"topic", n.topic,
+	)
+	return nil
+}
+
+// ResolvePodAlert publishes a record marking the alert's incident as recovered
+func (n *Notifier) ResolvePodAlert(alert notify.PodAlert) error {
+	record := filesink.PodRecord(filesink.KindPodResolved, alert)
+	record.Timestamp = time.Now()
+	return n.publish(alert.Key, record)
+}
+
+// SendMetaAlert publishes a meta alert record
+func (n *Notifier) SendMetaAlert(alert notify.MetaAlert) error {
+	return n.publish(alert.Title, filesink.Record{
+		Kind:      filesink.KindMetaAlert,
+		Timestamp: alert.Timestamp,
+		Severity:  alert.Severity,
+		Channel:   alert.Channel,
+		Title:     alert.Title,
+		Text:      alert.Text,
+	})
+}
+
+// publish writes the record and waits for all in-sync replicas to acknowledge it
+func (n *Notifier) publish(key string, record filesink.Record) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode alert record: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+	if err := n.writer.WriteMessages(ctx, kafkago.Message{Key: []byte(key), Value: value}); err != nil {
+		return fmt.Errorf("failed to publish alert to Kafka topic %s: %w", n.topic, err)
+	}
+	return nil
+}
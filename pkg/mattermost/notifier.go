@@ -0,0 +1,186 @@
+package mattermost
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/version"
+)
+
+// Attachment colors by severity
+const (
+	colorCritical = "#E01E5A"
+	colorWarning  = "#ECB22E"
+	colorInfo     = "#36C5F0"
+)
+
+// WebhookMessage represents the structure of a Mattermost incoming webhook message
+type WebhookMessage struct {
+	Username    string       `json:"username,omitempty"`
+	Text        string       `json:"text,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// Attachment is a Mattermost message attachment. Unlike Slack, Mattermost renders the
+// attachment title and fields but ignores Block Kit, so alerts are built from attachments.
+type Attachment struct {
+	Fallback string  `json:"fallback"`
+	Color    string  `json:"color,omitempty"`
+	Title    string  `json:"title,omitempty"`
+	Text     string  `json:"text,omitempty"`
+	Fields   []Field `json:"fields,omitempty"`
+	Footer   string  `json:"footer,omitempty"`
+}
+
+// Field is a title/value pair shown in an attachment
+type Field struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// Notifier handles Mattermost notifications
+type Notifier struct {
+	webhookURL string
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+// NewNotifier creates a new Mattermost notifier instance from the MATTERMOST_WEBHOOK_URL environment variable
+func NewNotifier(logger logr.Logger) (*Notifier, error) {
+	webhookURL := os.Getenv("MATTERMOST_WEBHOOK_URL")
+	if webhookURL == "" {
+		return nil, fmt.Errorf("MATTERMOST_WEBHOOK_URL environment variable not set")
+	}
+
+	return &Notifier{
+		webhookURL: webhookURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		logger: logger,
+	}, nil
+}
+
+// SendPodAlert sends an attachment alert to the Mattermost webhook
+func (n *Notifier) SendPodAlert(alert notify.PodAlert) error {
+	if err := n.post(n.buildMessage(alert)); err != nil {
+		return err
+	}
+
+	n.logger.Info("Mattermost alert sent successfully",
+		"pod", alert.PodName,
+		"namespace", alert.Namespace,
+		"reason", alert.Reason,
+		"restarts", alert.RestartCount,
+	)
+
+	return nil
+}
+
+// SendMetaAlert sends an operator-generated message to the Mattermost webhook
+func (n *Notifier) SendMetaAlert(alert notify.MetaAlert) error {
+	if err := n.post(WebhookMessage{
+		Username: "Kube-SlackGenie",
+		Attachments: []Attachment{
+			{
+				Fallback: alert.Title,
+				Color:    colorForSeverity(alert.Severity),
+				Title:    alert.Title,
+				Text:     alert.Text,
+				Footer:   version.Footer() + " | " + alert.Timestamp.Format(time.RFC3339),
+			},
+		},
+	}); err != nil {
+		return err
+	}
+
+	n.logger.Info("Mattermost meta-alert sent successfully", "title", alert.Title)
+	return nil
+}
+
+// post delivers a message to the Mattermost webhook
+func (n *Notifier) post(msg WebhookMessage) error {
+	jsonData, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Mattermost message: %w", err)
+	}
+
+	resp, err := n.httpClient.Post(n.webhookURL, "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to send Mattermost notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("mattermost webhook returned status code %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
+
+// buildMessage maps the pod alert onto attachment fields
+func (n *Notifier) buildMessage(alert notify.PodAlert) WebhookMessage {
+	title := fmt.Sprintf("%s: %s/%s", alert.Reason, alert.Namespace, alert.PodName)
+	fields := []Field{
+		{Title: "Pod", Value: alert.PodName, Short: true},
+		{Title: "Namespace", Value: alert.Namespace, Short: true},
+		{Title: "Container", Value: alert.ContainerName, Short: true},
+		{Title: "Restarts", Value: fmt.Sprintf("%d", alert.RestartCount), Short: true},
+		{Title: "Image", Value: alert.Image},
+	}
+	if workload := alert.Workload(); workload != "" {
+		fields = append([]Field{{Title: "Workload", Value: workload}}, fields...)
+	}
+	if exit := alert.ExitSummary(); exit != "" {
+		fields = append(fields, Field{Title: "Exit code", Value: exit})
+	}
+	if node := alert.NodeSummary(); node != "" {
+		fields = append(fields, Field{Title: "Node", Value: node, Short: true})
+	}
+	if alert.Team != "" {
+		fields = append(fields, Field{Title: "Owner", Value: alert.Team, Short: true})
+	}
+	if alert.LogURL != "" {
+		fields = append(fields, Field{Title: "Logs", Value: fmt.Sprintf("[View recent logs](%s)", alert.LogURL), Short: true})
+	}
+
+	return WebhookMessage{
+		Username: "Kube-SlackGenie",
+		Attachments: []Attachment{
+			{
+				Fallback: title,
+				Color:    colorForSeverity(alert.AlertSeverity()),
+				Title:    title,
+				Text:     alert.Message,
+				Fields:   fields,
+				Footer:   alert.Timestamp.Format(time.RFC3339),
+			},
+		},
+	}
+}
+
+// colorForSeverity returns the attachment color for a severity
+func colorForSeverity(severity notify.Severity) string {
+	switch severity {
+	case notify.SeverityCritical:
+		return colorCritical
+	case notify.SeverityWarning:
+		return colorWarning
+	default:
+		return colorInfo
+	}
+}
+
+// SetTransport implements notify.TransportSetter
+func (n *Notifier) SetTransport(transport http.RoundTripper) {
+	n.httpClient.Transport = transport
+}
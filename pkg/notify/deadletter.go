@@ -0,0 +1,35 @@
+package notify
+
+import "time"
+
+// Kinds of dead-lettered deliveries
+const (
+	DeadLetterPod     = "pod"
+	DeadLetterMeta    = "meta"
+	DeadLetterResolve = "resolve"
+)
+
+// DeadLetter is a delivery that still failed after all retries. Either PodAlert or MetaAlert
+// is set, depending on the kind.
+type DeadLetter struct {
+	Kind      string
+	PodAlert  *PodAlert
+	MetaAlert *MetaAlert
+	Err       error
+	FailedAt  time.Time
+}
+
+// DeadLetterHandler keeps deliveries that could not be made, so they can be audited rather
+// than being lost with a log line
+type DeadLetterHandler interface {
+	HandleDeadLetter(letter DeadLetter)
+}
+
+// RecordDeadLetter counts a failed delivery and hands it to handler, if set. Notifiers that
+// deliver in the background, after the queue has moved on, record their failures here too.
+func RecordDeadLetter(handler DeadLetterHandler, letter DeadLetter) {
+	deadLettersTotal.WithLabelValues(letter.Kind).Inc()
+	if handler != nil {
+		handler.HandleDeadLetter(letter)
+	}
+}
@@ -0,0 +1,327 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// maxRetryAfter is the longest rate limit delay honored inline; longer ones fail the delivery
+const maxRetryAfter = time.Minute
+
+// partialDeliveryTTL bounds how long the dispatcher remembers which sinks already received
+// an alert that failed on other sinks
+const partialDeliveryTTL = 30 * time.Minute
+
+// Route restricts which alerts a sink receives. Empty fields match everything.
+type Route struct {
+	// MinSeverity drops alerts below this severity
+	MinSeverity Severity
+	// Namespaces limits pod alerts to these namespaces
+	Namespaces []string
+	// Reasons limits pod alerts to these failure reasons
+	Reasons []string
+}
+
+// Matches reports whether a pod alert should be sent through the route
+func (r Route) Matches(alert PodAlert) bool {
+	if !alert.AlertSeverity().AtLeast(r.MinSeverity) {
+		return false
+	}
+	if len(r.Namespaces) > 0 && !slices.Contains(r.Namespaces, alert.Namespace) {
+		return false
+	}
+	if len(r.Reasons) > 0 && !slices.Contains(r.Reasons, alert.Reason) {
+		return false
+	}
+	return true
+}
+
+// MatchesMeta reports whether a meta-alert should be sent through the route. Only the
+// severity filter applies, since meta-alerts are not tied to a namespace or reason.
+func (r Route) MatchesMeta(alert MetaAlert) bool {
+	return alert.Severity.AtLeast(r.MinSeverity)
+}
+
+// Retry controls how often delivery to a sink is attempted before giving up
+type Retry struct {
+	// Attempts is the total number of attempts; values below 1 mean a single attempt
+	Attempts int
+	// Backoff is the delay before the first retry, doubled after each further attempt
+	Backoff time.Duration
+}
+
+// Sink is a notifier together with the alerts it should receive and its retry policy
+type Sink struct {
+	Name     string
+	Notifier Notifier
+	Route    Route
+	Retry    Retry
+}
+
+// Delivery is the outcome of sending a pod alert to one sink
+type Delivery struct {
+	Sink string
+	// Err is the last error if delivery failed after all retries
+	Err error
+	At  time.Time
+}
+
+// DeliveryObserver is told how each pod alert was delivered, e.g. to keep an audit trail
+type DeliveryObserver interface {
+	ObserveDelivery(alert PodAlert, deliveries []Delivery)
+}
+
+// Dispatcher fans alerts out to several sinks. Each sink is filtered by its own route and
+// retried independently, so a failing sink neither blocks nor duplicates delivery to the others.
+type Dispatcher struct {
+	sinks     []Sink
+	logger    logr.Logger
+	observers []DeliveryObserver
+	decisions logr.Logger
+	// sleep waits between retries; tests replace it to retry without waiting
+	sleep func(time.Duration)
+
+	mu sync.Mutex
+	// delivered tracks, per alert key, the sinks that already received an alert whose
+	// delivery failed elsewhere, so a requeued alert is only retried where it failed
+	delivered map[string]partialDelivery
+}
+
+// partialDelivery records the sinks that succeeded for a partially delivered alert
+type partialDelivery struct {
+	sinks map[string]bool
+	at    time.Time
+}
+
+// NewDispatcher creates a dispatcher for the given sinks
+func NewDispatcher(logger logr.Logger, sinks ...Sink) *Dispatcher {
+	return &Dispatcher{
+		sinks:     sinks,
+		logger:    logger,
+		sleep:     time.Sleep,
+		delivered: make(map[string]partialDelivery),
+	}
+}
+
+// ObserveDeliveries adds an observer told the outcome of every pod alert. Observers must be
+// added before alerts are sent.
+func (d *Dispatcher) ObserveDeliveries(observer DeliveryObserver) {
+	d.observers = append(d.observers, observer)
+}
+
+// LogDecisions logs every pod alert a sink's route filters out to the logger
+func (d *Dispatcher) LogDecisions(logger logr.Logger) {
+	d.decisions = logger
+}
+
+// SendPodAlert sends the alert to every sink whose route matches. The returned error joins
+// the failures of all sinks that did not accept the alert after their retries.
+func (d *Dispatcher) SendPodAlert(alert PodAlert) error {
+	skip := d.alreadyDelivered(alert.Key)
+
+	var targets []Sink
+	for _, sink := range d.sinks {
+		switch {
+		case !sink.Route.Matches(alert):
+			RecordSuppressed(SuppressedFilter, alert.Reason)
+			d.decisions.Info("Alert decision",
+				"pod", alert.PodName,
+				"namespace", alert.Namespace,
+				"reason", alert.Reason,
+				"verdict", "filtered",
+				"key", alert.Key,
+				"sink", sink.Name,
+				"severity", string(alert.AlertSeverity()),
+				"minSeverity", string(sink.Route.MinSeverity),
+				"namespaces", sink.Route.Namespaces,
+				"reasons", sink.Route.Reasons,
+			)
+		case !skip[sink.Name]:
+			targets = append(targets, sink)
+		}
+	}
+
+	errs := d.fanOut(targets, func(n Notifier) error { return n.SendPodAlert(alert) })
+	succeeded := succeededSinks(targets, errs)
+	for _, name := range succeeded {
+		alertsSentTotal.WithLabelValues(alert.Reason, alert.Namespace, name).Inc()
+	}
+	err := errors.Join(errs...)
+	d.recordDelivery(alert.Key, succeeded, err)
+
+	if len(d.observers) > 0 && len(targets) > 0 {
+		now := time.Now()
+		deliveries := make([]Delivery, len(targets))
+		for i, sink := range targets {
+			deliveries[i] = Delivery{Sink: sink.Name, Err: errs[i], At: now}
+		}
+		for _, observer := range d.observers {
+			observer.ObserveDelivery(alert, deliveries)
+		}
+	}
+	return err
+}
+
+// SendMetaAlert sends the meta-alert to every sink whose route matches
+func (d *Dispatcher) SendMetaAlert(alert MetaAlert) error {
+	var targets []Sink
+	for _, sink := range d.sinks {
+		if sink.Route.MatchesMeta(alert) {
+			targets = append(targets, sink)
+		}
+	}
+
+	return errors.Join(d.fanOut(targets, func(n Notifier) error { return n.SendMetaAlert(alert) })...)
+}
+
+// ForgetThreads forwards to every sink that keeps thread state
+func (d *Dispatcher) ForgetThreads(prefix string) {
+	for _, sink := range d.sinks {
+		if forgetter, ok := sink.Notifier.(ThreadForgetter); ok {
+			forgetter.ForgetThreads(prefix)
+		}
+	}
+}
+
+// ResolvePodAlert forwards to every sink that tracks incidents and would have received the alert
+func (d *Dispatcher) ResolvePodAlert(alert PodAlert) error {
+	var targets []Sink
+	for _, sink := range d.sinks {
+		if _, ok := sink.Notifier.(AlertResolver); ok && sink.Route.Matches(alert) {
+			targets = append(targets, sink)
+		}
+	}
+
+	return errors.Join(d.fanOut(targets, func(n Notifier) error { return n.(AlertResolver).ResolvePodAlert(alert) })...)
+}
+
+// fanOut runs send against every sink concurrently, retrying each one according to its own
+// policy. It returns the failure of each sink, nil where delivery succeeded.
+func (d *Dispatcher) fanOut(sinks []Sink, send func(Notifier) error) []error {
+	errs := make([]error, len(sinks))
+
+	var wg sync.WaitGroup
+	for i, sink := range sinks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = d.sendWithRetry(sink, send)
+		}()
+	}
+	wg.Wait()
+
+	for i, sink := range sinks {
+		if errs[i] != nil {
+			sendFailuresTotal.WithLabelValues(sink.Name).Inc()
+			errs[i] = fmt.Errorf("%s: %w", sink.Name, errs[i])
+		}
+	}
+	return errs
+}
+
+// succeededSinks returns the names of the sinks fanOut delivered to
+func succeededSinks(sinks []Sink, errs []error) []string {
+	var succeeded []string
+	for i, sink := range sinks {
+		if errs[i] == nil {
+			succeeded = append(succeeded, sink.Name)
+		}
+	}
+	return succeeded
+}
+
+// sendWithRetry attempts delivery to a single sink with jittered exponential backoff. Permanent
+// failures are not retried, and rate limits wait for at least the delay the backend asked for.
+func (d *Dispatcher) sendWithRetry(sink Sink, send func(Notifier) error) error {
+	attempts := max(sink.Retry.Attempts, 1)
+	backoff := sink.Retry.Backoff
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		start := time.Now()
+		err = send(sink.Notifier)
+		sendLatencySeconds.WithLabelValues(sink.Name).Observe(time.Since(start).Seconds())
+		if err == nil || IsPermanent(err) || attempt == attempts {
+			return err
+		}
+
+		delay := jitter(backoff)
+		var rateLimited *RetryAfterError
+		if errors.As(err, &rateLimited) {
+			if rateLimited.After > maxRetryAfter {
+				return err
+			}
+			delay = max(delay, rateLimited.After)
+		}
+
+		d.logger.V(1).Info("Notifier failed, retrying",
+			"sink", sink.Name,
+			"attempt", attempt,
+			"delay", delay,
+			"error", err.Error(),
+		)
+		d.sleep(delay)
+		backoff *= 2
+	}
+	return err
+}
+
+// jitter spreads a backoff delay over its upper half, so sinks recovering from an outage are
+// not hit by every retry at once
+func jitter(backoff time.Duration) time.Duration {
+	if backoff <= 0 {
+		return 0
+	}
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// alreadyDelivered returns the sinks that already received a partially delivered alert
+func (d *Dispatcher) alreadyDelivered(key string) map[string]bool {
+	if key == "" {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	for k, delivery := range d.delivered {
+		if now.Sub(delivery.at) > partialDeliveryTTL {
+			delete(d.delivered, k)
+		}
+	}
+	return maps.Clone(d.delivered[key].sinks)
+}
+
+// recordDelivery remembers the sinks that succeeded when others failed, and clears the
+// record once every sink has accepted the alert
+func (d *Dispatcher) recordDelivery(key string, succeeded []string, err error) {
+	if key == "" {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err == nil {
+		delete(d.delivered, key)
+		return
+	}
+
+	delivery, exists := d.delivered[key]
+	if !exists {
+		delivery = partialDelivery{sinks: make(map[string]bool)}
+	}
+	for _, name := range succeeded {
+		delivery.sinks[name] = true
+	}
+	delivery.at = time.Now()
+	d.delivered[key] = delivery
+}
@@ -0,0 +1,129 @@
+package notify
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// failingNotifier fails pod alerts with the next error of errs, then succeeds
+type failingNotifier struct {
+	errs  []error
+	calls int
+}
+
+func (n *failingNotifier) SendPodAlert(PodAlert) error {
+	n.calls++
+	if n.calls <= len(n.errs) {
+		return n.errs[n.calls-1]
+	}
+	return nil
+}
+
+func (n *failingNotifier) SendMetaAlert(MetaAlert) error { return nil }
+
+// TestSendWithRetry checks how often a sink is attempted and how long the dispatcher waits
+// between attempts
+func TestSendWithRetry(t *testing.T) {
+	errUnavailable := errors.New("503 service unavailable")
+	rateLimited := func(after time.Duration) error {
+		return &RetryAfterError{Err: errors.New("429 too many requests"), After: after}
+	}
+
+	tests := []struct {
+		name         string
+		retry        Retry
+		errs         []error
+		wantCalls    int
+		wantErr      bool
+		wantMinSleep []time.Duration
+		wantMaxSleep []time.Duration
+	}{
+		{
+			name:      "success needs no retry",
+			retry:     Retry{Attempts: 3, Backoff: time.Second},
+			wantCalls: 1,
+		},
+		{
+			name:         "recovers before the last attempt",
+			retry:        Retry{Attempts: 3, Backoff: time.Second},
+			errs:         []error{errUnavailable, errUnavailable},
+			wantCalls:    3,
+			wantMinSleep: []time.Duration{time.Second / 2, time.Second},
+			wantMaxSleep: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:         "gives up after all attempts",
+			retry:        Retry{Attempts: 3, Backoff: time.Second},
+			errs:         []error{errUnavailable, errUnavailable, errUnavailable, errUnavailable},
+			wantCalls:    3,
+			wantErr:      true,
+			wantMinSleep: []time.Duration{time.Second / 2, time.Second},
+			wantMaxSleep: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:      "no attempts means a single attempt",
+			errs:      []error{errUnavailable},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "permanent failure is not retried",
+			retry:     Retry{Attempts: 3, Backoff: time.Second},
+			errs:      []error{Permanent(errors.New("400 invalid_payload"))},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:         "honors Retry-After beyond the backoff",
+			retry:        Retry{Attempts: 2, Backoff: time.Second},
+			errs:         []error{rateLimited(30 * time.Second)},
+			wantCalls:    2,
+			wantMinSleep: []time.Duration{30 * time.Second},
+			wantMaxSleep: []time.Duration{30 * time.Second},
+		},
+		{
+			name:         "backoff beyond Retry-After wins",
+			retry:        Retry{Attempts: 2, Backoff: 10 * time.Second},
+			errs:         []error{rateLimited(time.Second)},
+			wantCalls:    2,
+			wantMinSleep: []time.Duration{5 * time.Second},
+			wantMaxSleep: []time.Duration{10 * time.Second},
+		},
+		{
+			name:      "Retry-After beyond the cap fails without waiting",
+			retry:     Retry{Attempts: 3, Backoff: time.Second},
+			errs:      []error{rateLimited(maxRetryAfter + time.Second)},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &failingNotifier{errs: tt.errs}
+			d := NewDispatcher(logr.Discard())
+			var slept []time.Duration
+			d.sleep = func(delay time.Duration) { slept = append(slept, delay) }
+
+			sink := Sink{Name: "test", Notifier: notifier, Retry: tt.retry}
+			err := d.sendWithRetry(sink, func(n Notifier) error { return n.SendPodAlert(PodAlert{}) })
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if notifier.calls != tt.wantCalls {
+				t.Errorf("attempted %d times, want %d", notifier.calls, tt.wantCalls)
+			}
+			if len(slept) != len(tt.wantMinSleep) {
+				t.Fatalf("slept %v, want %d waits", slept, len(tt.wantMinSleep))
+			}
+			for i, delay := range slept {
+				if delay < tt.wantMinSleep[i] || delay > tt.wantMaxSleep[i] {
+					t.Errorf("wait %d was %s, want between %s and %s", i+1, delay, tt.wantMinSleep[i], tt.wantMaxSleep[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+// dryRunResponse answers dry-run requests the way Slack's Web API reports success; other
+// backends only check the status code
+const dryRunResponse = `{"ok":true,"ts":"dry-run"}`
+
+// DryRunTransport logs requests instead of sending them and answers each with success. The
+// messages are logged exactly as rendered for the backend; only the destination host is
+// logged, since webhook URLs carry credentials in their path.
+type DryRunTransport struct {
+	Logger logr.Logger
+	// Sink names the notifier whose requests are logged
+	Sink string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *DryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+
+	var message any = string(body)
+	if json.Valid(body) {
+		message = json.RawMessage(body)
+	}
+	t.Logger.Info("Dry run, not sending message",
+		"sink", t.Sink,
+		"method", req.Method,
+		"host", req.URL.Host,
+		"api", dryRunAPIMethod(req),
+		"message", message,
+	)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewBufferString(dryRunResponse)),
+		Request:    req,
+	}, nil
+}
+
+// dryRunAPIMethod returns the Slack Web API method a request calls, e.g. chat.postMessage,
+// or an empty string for other backends, whose paths may hold credentials
+func dryRunAPIMethod(req *http.Request) string {
+	if method, ok := strings.CutPrefix(req.URL.Path, "/api/"); ok && req.URL.Host == "slack.com" {
+		return method
+	}
+	return ""
+}
+
+// DryRunNotifier logs alerts instead of delivering them, for notifiers that do not deliver
+// over HTTP
+type DryRunNotifier struct {
+	Logger logr.Logger
+	// Sink names the notifier whose alerts are logged
+	Sink string
+}
+
+// SendPodAlert logs the alert
+func (n *DryRunNotifier) SendPodAlert(alert PodAlert) error {
+	n.Logger.Info("Dry run, not sending pod alert", "sink", n.Sink, "alert", alert)
+	return nil
+}
+
+// SendMetaAlert logs the alert
+func (n *DryRunNotifier) SendMetaAlert(alert MetaAlert) error {
+	n.Logger.Info("Dry run, not sending meta-alert", "sink", n.Sink, "alert", alert)
+	return nil
+}
@@ -0,0 +1,89 @@
+package notify
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Causes for which an alert is not delivered
+const (
+	SuppressedDebounce   = "debounce"
+	SuppressedSilence    = "silence"
+	SuppressedScaleDown  = "scale_down"
+	SuppressedChaos      = "chaos"
+	SuppressedFilter     = "filter"
+	SuppressedKnownIssue = "known_issue"
+	SuppressedSpot       = "spot"
+	SuppressedDrain      = "drain"
+	SuppressedJobRetry   = "job_retry"
+	SuppressedExpected   = "expected"
+	SuppressedStartup    = "startup"
+)
+
+var (
+	alertsSentTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "slackgenie_alerts_sent_total",
+			Help: "Number of pod alerts delivered, per failure reason, namespace and sink",
+		},
+		[]string{"reason", "namespace", "sink"},
+	)
+
+	alertsSuppressedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "slackgenie_alerts_suppressed_total",
+			Help: "Number of pod alerts not delivered, per cause and failure reason. Filtered alerts are counted per sink.",
+		},
+		[]string{"cause", "reason"},
+	)
+
+	sendFailuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "slackgenie_send_failures_total",
+			Help: "Number of deliveries that failed after all retries, per sink",
+		},
+		[]string{"sink"},
+	)
+
+	sendLatencySeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "slackgenie_send_latency_seconds",
+			Help:    "Duration of a single delivery attempt, per sink",
+			Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"sink"},
+	)
+
+	queueDepth = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "slackgenie_send_queue_depth",
+			Help: "Number of deliveries waiting in the send queue",
+		},
+	)
+
+	queueDroppedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "slackgenie_send_queue_dropped_total",
+			Help: "Number of deliveries rejected or discarded because the send queue was full, per overflow policy",
+		},
+		[]string{"policy"},
+	)
+
+	deadLettersTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "slackgenie_dead_letters_total",
+			Help: "Number of deliveries given up after all retries, per kind (pod, meta, resolve)",
+		},
+		[]string{"kind"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(alertsSentTotal, alertsSuppressedTotal, sendFailuresTotal, sendLatencySeconds,
+		queueDepth, queueDroppedTotal, deadLettersTotal)
+}
+
+// RecordSuppressed counts a pod alert that was dropped before reaching the notifiers
+func RecordSuppressed(cause, reason string) {
+	alertsSuppressedTotal.WithLabelValues(cause, reason).Inc()
+}
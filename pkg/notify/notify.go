@@ -0,0 +1,325 @@
+// Package notify defines the alerts the operator emits and the interface notification
+// backends implement, along with a dispatcher that fans alerts out to several backends.
+package notify
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodAlert contains information about a pod failure
+type PodAlert struct {
+	PodName       string
+	Namespace     string
+	ContainerName string
+	Image         string
+	Reason        string
+	Message       string
+	RestartCount  int32
+	Timestamp     time.Time
+	// Channel overrides the default destination channel when set
+	Channel string
+	// Key identifies the incident this alert belongs to, used for threading
+	Key string
+	// Cluster names the cluster the pod runs in, empty for a single unnamed cluster
+	Cluster string
+	// Team is the team the alert is attributed to by ownership rules, if any
+	Team string
+	// Mentions are Slack mentions of the users or user groups to notify, e.g. <!subteam^S0123ABCD>
+	Mentions []string
+	// LogURL links to recent logs of the failing container, if log links are enabled
+	LogURL string
+	// Links are dashboard links rendered from the configured URL templates
+	Links []Link
+	// Trace lists the checks and routing decisions that led to the alert, if tracing is enabled
+	Trace []string
+	// Fingerprint identifies the failure across pod restarts, used to match tracker issues
+	Fingerprint string
+	// KnownIssue is an open issue already tracking the failure, if any
+	KnownIssue *Issue
+	// OfferIssue asks notifiers to offer creating an issue for the failure
+	OfferIssue bool
+	// WorkloadKind and WorkloadName identify the top-level workload owning the pod, e.g.
+	// Deployment payments-api. Both are empty for pods without a controller.
+	WorkloadKind string
+	WorkloadName string
+	// Node is the node the pod runs on, empty if it is not scheduled
+	Node string
+	// NodeConditions are the node's readiness and pressure conditions
+	NodeConditions []NodeCondition
+	// ExitCode is the exit code of the container's last termination, nil if it has not
+	// terminated
+	ExitCode *int32
+	// Signal is the signal that terminated the container, as reported by the runtime or
+	// derived from an exit code above 128; zero if unknown
+	Signal int32
+	// Severity overrides the severity of the reason when set, e.g. for alerts forwarded from
+	// Alertmanager, whose reasons are alert names
+	Severity Severity
+}
+
+// AlertSeverity returns the alert's severity: its override if set, or that of its reason
+func (a PodAlert) AlertSeverity() Severity {
+	if a.Severity != "" {
+		return a.Severity
+	}
+	return SeverityForReason(a.Reason)
+}
+
+// Workload names the workload owning the alert's pod, e.g. "Deployment payments-api", or
+// returns an empty string for pods without a controller
+func (a PodAlert) Workload() string {
+	if a.WorkloadName == "" {
+		return ""
+	}
+	return a.WorkloadKind + " " + a.WorkloadName
+}
+
+// shortLimit bounds the length of short alerts, so they fit a single SMS with room for the
+// pager's own prefix
+const shortLimit = 160
+
+// ID returns a short identifier of the alert's incident, derived from its key, for quoting in
+// pages and replies. It is empty for alerts without a key.
+func (a PodAlert) ID() string {
+	if a.Key == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(a.Key))
+	return hex.EncodeToString(sum[:4])
+}
+
+// Short renders the alert in under 200 characters for SMS and paging backends, with only the
+// severity, reason, workload, cluster and alert ID, e.g.
+// "CRITICAL CrashLoopBackOff Deployment shop/payments-api @prod-eu #3fa9c2d1". Unlike a
+// truncated message it carries no markup, and the names are shortened rather than cut off.
+func (a PodAlert) Short() string {
+	kind, name := a.WorkloadKind, a.WorkloadName
+	if name == "" {
+		kind, name = "pod", a.PodName
+	}
+
+	var suffix string
+	if a.Cluster != "" {
+		suffix += " @" + a.Cluster
+	}
+	if id := a.ID(); id != "" {
+		suffix += " #" + id
+	}
+	prefix := fmt.Sprintf("%s %s %s ", strings.ToUpper(string(a.AlertSeverity())), a.Reason, kind)
+
+	target := a.Namespace + "/" + name
+	if room := shortLimit - len(prefix) - len(suffix); len(target) > room {
+		target = target[:max(room-3, 0)] + "..."
+	}
+	return prefix + target + suffix
+}
+
+// NodeCondition is a health condition of the node an alert's pod runs on
+type NodeCondition struct {
+	Type   string `json:"type"`
+	Status string `json:"status"`
+}
+
+// NodeSummary describes the alert's node and whether it is healthy, e.g.
+// "node-1 (healthy)" or "node-1 (⚠️ NotReady, MemoryPressure)", so recipients can tell a
+// pod-specific failure from a node-wide one. It is empty for unscheduled pods.
+func (a PodAlert) NodeSummary() string {
+	if a.Node == "" {
+		return ""
+	}
+	if len(a.NodeConditions) == 0 {
+		return a.Node
+	}
+
+	var problems []string
+	for _, condition := range a.NodeConditions {
+		switch {
+		case condition.Type == "Ready" && condition.Status == "False":
+			problems = append(problems, "NotReady")
+		case condition.Type == "Ready" && condition.Status != "True":
+			problems = append(problems, "Ready="+condition.Status)
+		case condition.Type != "Ready" && condition.Status == "True":
+			problems = append(problems, condition.Type)
+		}
+	}
+	if len(problems) == 0 {
+		return a.Node + " (healthy)"
+	}
+	return fmt.Sprintf("%s (⚠️ %s)", a.Node, strings.Join(problems, ", "))
+}
+
+// signalNames explains the signals containers are commonly terminated with
+var signalNames = map[int32]string{
+	1:  "SIGHUP: hangup",
+	2:  "SIGINT: interrupted",
+	6:  "SIGABRT: aborted, e.g. by a failed assertion",
+	9:  "SIGKILL: killed, usually by the OOM killer, a failed liveness probe or after the termination grace period",
+	11: "SIGSEGV: segmentation fault",
+	15: "SIGTERM: asked to stop, e.g. by a rollout, eviction or scale-down",
+}
+
+// ExitSummary explains the exit code of the alert's container, e.g. "137 (SIGKILL: killed,
+// usually by the OOM killer, ...)", or returns an empty string if it has not terminated
+func (a PodAlert) ExitSummary() string {
+	if a.ExitCode == nil {
+		return ""
+	}
+	code := *a.ExitCode
+
+	var explanation string
+	switch {
+	case a.Signal != 0:
+		explanation = signalNames[a.Signal]
+		if explanation == "" {
+			explanation = fmt.Sprintf("terminated by signal %d", a.Signal)
+		}
+	case code == 0:
+		explanation = "exited successfully, then restarted by the pod's restartPolicy"
+	case code == 1:
+		explanation = "application error"
+	case code == 126:
+		explanation = "command cannot be executed, e.g. missing execute permission"
+	case code == 127:
+		explanation = "command not found, check the image's entrypoint and the container's command"
+	case code == 255:
+		explanation = "exit status out of range, e.g. exit(-1)"
+	}
+	if explanation == "" {
+		return fmt.Sprintf("%d", code)
+	}
+	return fmt.Sprintf("%d (%s)", code, explanation)
+}
+
+// terminationDetails returns the exit code and signal of a container's termination
+func terminationDetails(terminated *corev1.ContainerStateTerminated) (*int32, int32) {
+	code := terminated.ExitCode
+	signal := terminated.Signal
+	if signal == 0 && code > 128 && code < 160 {
+		signal = code - 128
+	}
+	return &code, signal
+}
+
+// Link is a labelled link to an external page about the alert, such as a dashboard
+type Link struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// Issue is an issue in a tracker such as GitHub
+type Issue struct {
+	Number int
+	Title  string
+	URL    string
+}
+
+// MetaAlert is an operator-generated message that is not tied to a single pod failure,
+// such as capacity forecasts, reports and summaries
+type MetaAlert struct {
+	Title     string
+	Text      string
+	Severity  Severity
+	Channel   string
+	Timestamp time.Time
+}
+
+// Notifier delivers alerts to a notification backend such as Slack, PagerDuty or a webhook
+type Notifier interface {
+	SendPodAlert(alert PodAlert) error
+	SendMetaAlert(alert MetaAlert) error
+}
+
+// ThreadForgetter is implemented by notifiers that keep per-incident thread state
+type ThreadForgetter interface {
+	ForgetThreads(prefix string)
+}
+
+// AlertResolver is implemented by notifiers that track incidents and close them once the
+// failing pod recovers
+type AlertResolver interface {
+	ResolvePodAlert(alert PodAlert) error
+}
+
+// Acknowledger is implemented by notifiers that can tell whether a responder acknowledged the
+// message of an incident
+type Acknowledger interface {
+	Acknowledged(key string) (bool, error)
+}
+
+// CreatePodAlertFromPod extracts alert information from a Pod resource
+func CreatePodAlertFromPod(pod *corev1.Pod) *PodAlert {
+	if pod == nil {
+		return nil
+	}
+
+	// Find the first container with issues
+	var containerName, image, reason, message string
+	var restartCount int32
+	var exitCode *int32
+	var signal int32
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.State.Waiting != nil {
+			containerName = containerStatus.Name
+			image = containerStatus.Image
+			reason = containerStatus.State.Waiting.Reason
+			message = containerStatus.State.Waiting.Message
+			restartCount = containerStatus.RestartCount
+			// A crash loop waits between restarts; the last termination explains it
+			if last := containerStatus.LastTerminationState.Terminated; last != nil {
+				exitCode, signal = terminationDetails(last)
+			}
+			break
+		}
+		if containerStatus.State.Terminated != nil && containerStatus.State.Terminated.ExitCode != 0 {
+			containerName = containerStatus.Name
+			image = containerStatus.Image
+			reason = containerStatus.State.Terminated.Reason
+			message = containerStatus.State.Terminated.Message
+			restartCount = containerStatus.RestartCount
+			exitCode, signal = terminationDetails(containerStatus.State.Terminated)
+			break
+		}
+	}
+
+	// If no container status found, check init containers
+	if containerName == "" {
+		for _, containerStatus := range pod.Status.InitContainerStatuses {
+			if containerStatus.State.Waiting != nil {
+				containerName = containerStatus.Name
+				image = containerStatus.Image
+				reason = containerStatus.State.Waiting.Reason
+				message = containerStatus.State.Waiting.Message
+				restartCount = containerStatus.RestartCount
+				break
+			}
+		}
+	}
+
+	// Fallback to pod-level information
+	if containerName == "" && len(pod.Spec.Containers) > 0 {
+		containerName = pod.Spec.Containers[0].Name
+		image = pod.Spec.Containers[0].Image
+		reason = string(pod.Status.Phase)
+		message = pod.Status.Message
+	}
+
+	return &PodAlert{
+		PodName:       pod.Name,
+		Namespace:     pod.Namespace,
+		ContainerName: containerName,
+		Image:         image,
+		Reason:        reason,
+		Message:       message,
+		RestartCount:  restartCount,
+		Timestamp:     time.Now(),
+		ExitCode:      exitCode,
+		Signal:        signal,
+	}
+}
@@ -0,0 +1,201 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Overflow policies for a full send queue
+const (
+	// OverflowReject fails the send, so the caller retries later
+	OverflowReject = "reject"
+	// OverflowDropOldest discards the oldest queued send to make room
+	OverflowDropOldest = "drop-oldest"
+	// OverflowBlock waits until there is room
+	OverflowBlock = "block"
+)
+
+// ErrQueueFull is returned by a queue with the reject policy when it has no room left
+var ErrQueueFull = errors.New("send queue is full")
+
+// QueueOptions configures a Queue
+type QueueOptions struct {
+	// Size is the total capacity, split evenly across workers
+	Size int
+	// Workers is the number of goroutines delivering alerts
+	Workers int
+	// Overflow is the policy applied when a worker's queue is full
+	Overflow string
+	// DeadLetters receives deliveries that still failed after all retries
+	DeadLetters DeadLetterHandler
+}
+
+// queueItem is a pending call on the wrapped notifier
+type queueItem struct {
+	letter DeadLetter
+	run    func() error
+}
+
+// Queue delivers alerts asynchronously, so callers such as the reconciler return immediately
+// instead of waiting on slow backends. Sends are sharded across workers by alert key, which
+// keeps the updates and resolution of one incident in order.
+type Queue struct {
+	notifier    Notifier
+	logger      logr.Logger
+	overflow    string
+	deadLetters DeadLetterHandler
+	shards      []chan queueItem
+}
+
+// NewQueue creates a queue in front of a notifier. It delivers nothing until started.
+func NewQueue(logger logr.Logger, notifier Notifier, opts QueueOptions) *Queue {
+	workers := max(opts.Workers, 1)
+	perShard := max(opts.Size/workers, 1)
+
+	q := &Queue{
+		notifier:    notifier,
+		logger:      logger,
+		overflow:    opts.Overflow,
+		deadLetters: opts.DeadLetters,
+		shards:      make([]chan queueItem, workers),
+	}
+	for i := range q.shards {
+		q.shards[i] = make(chan queueItem, perShard)
+	}
+	return q
+}
+
+// NeedLeaderElection runs the workers on every replica, since meta-alerts and pod alerts are
+// enqueued by whichever replica produces them
+func (q *Queue) NeedLeaderElection() bool {
+	return false
+}
+
+// Start runs the workers until the context is cancelled, then delivers what is still queued
+func (q *Queue) Start(ctx context.Context) error {
+	done := make(chan struct{}, len(q.shards))
+	for _, shard := range q.shards {
+		go func() {
+			q.work(ctx, shard)
+			done <- struct{}{}
+		}()
+	}
+	for range q.shards {
+		<-done
+	}
+	return nil
+}
+
+// work delivers the items of one shard
+func (q *Queue) work(ctx context.Context, shard chan queueItem) {
+	for {
+		select {
+		case item := <-shard:
+			q.deliver(item)
+		case <-ctx.Done():
+			for {
+				select {
+				case item := <-shard:
+					q.deliver(item)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// deliver runs a queued call. The caller has already moved on, so a failure is final and is
+// handed to the dead-letter handler.
+func (q *Queue) deliver(item queueItem) {
+	queueDepth.Dec()
+	err := item.run()
+	if err == nil {
+		return
+	}
+
+	q.logger.Error(err, "Queued delivery failed", "kind", item.letter.Kind)
+	letter := item.letter
+	letter.Err = err
+	letter.FailedAt = time.Now()
+	RecordDeadLetter(q.deadLetters, letter)
+}
+
+// enqueue adds a call to the shard of the given key, applying the overflow policy when full
+func (q *Queue) enqueue(key string, item queueItem) error {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	shard := q.shards[h.Sum32()%uint32(len(q.shards))]
+
+	// Count the item before it becomes visible to the worker, so the gauge never goes negative
+	queueDepth.Inc()
+	switch q.overflow {
+	case OverflowBlock:
+		shard <- item
+		return nil
+	case OverflowDropOldest:
+		for {
+			select {
+			case shard <- item:
+				return nil
+			default:
+			}
+			select {
+			case dropped := <-shard:
+				queueDepth.Dec()
+				queueDroppedTotal.WithLabelValues(OverflowDropOldest).Inc()
+				q.logger.Info("Send queue full, dropped oldest queued delivery", "kind", dropped.letter.Kind)
+			default:
+			}
+		}
+	default:
+		select {
+		case shard <- item:
+			return nil
+		default:
+			queueDepth.Dec()
+			queueDroppedTotal.WithLabelValues(OverflowReject).Inc()
+			return ErrQueueFull
+		}
+	}
+}
+
+// SendPodAlert queues the alert for delivery
+func (q *Queue) SendPodAlert(alert PodAlert) error {
+	return q.enqueue(alert.Key, queueItem{
+		letter: DeadLetter{Kind: DeadLetterPod, PodAlert: &alert},
+		run:    func() error { return q.notifier.SendPodAlert(alert) },
+	})
+}
+
+// SendMetaAlert queues the meta-alert for delivery
+func (q *Queue) SendMetaAlert(alert MetaAlert) error {
+	return q.enqueue(alert.Title, queueItem{
+		letter: DeadLetter{Kind: DeadLetterMeta, MetaAlert: &alert},
+		run:    func() error { return q.notifier.SendMetaAlert(alert) },
+	})
+}
+
+// ResolvePodAlert queues the resolution behind any pending updates of the same incident
+func (q *Queue) ResolvePodAlert(alert PodAlert) error {
+	resolver, ok := q.notifier.(AlertResolver)
+	if !ok {
+		return nil
+	}
+	return q.enqueue(alert.Key, queueItem{
+		letter: DeadLetter{Kind: DeadLetterResolve, PodAlert: &alert},
+		run:    func() error { return resolver.ResolvePodAlert(alert) },
+	})
+}
+
+// ForgetThreads forwards to the wrapped notifier. Pending sends may still start new threads,
+// which are dropped the next time the pod's state is forgotten.
+func (q *Queue) ForgetThreads(prefix string) {
+	if forgetter, ok := q.notifier.(ThreadForgetter); ok {
+		forgetter.ForgetThreads(prefix)
+	}
+}
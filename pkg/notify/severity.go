@@ -0,0 +1,56 @@
+package notify
+
+// Severity classifies how urgently an alert needs attention
+type Severity string
+
+// Alert severities
+const (
+	SeverityCritical Severity = "critical"
+	SeverityWarning  Severity = "warning"
+	SeverityInfo     Severity = "info"
+)
+
+// SeverityForReason maps a failure reason to a severity
+func SeverityForReason(reason string) Severity {
+	switch reason {
+	case "CrashLoopBackOff", "OOMKilled", "Failed", "ContainerCannotRun", "DeadlineExceeded", "Error",
+		"LivenessProbeFailed":
+		return SeverityCritical
+	case "ImagePullBackOff", "ErrImagePull", "InvalidImageName", "ImageInspectError", "FailedScheduling",
+		"CreateContainerConfigError", "CreateContainerError",
+		"FailedPostStartHook", "FailedPreStopHook", "GracefulShutdownExceeded", "CPUThrottling",
+		"PendingTimeout", "StuckTerminating", "Evicted", "ReadinessProbeFailed":
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
+
+// EmojiForSeverity returns an emoji representing a severity
+func EmojiForSeverity(severity Severity) string {
+	switch severity {
+	case SeverityCritical:
+		return "🚨"
+	case SeverityWarning:
+		return "⚠️"
+	default:
+		return "ℹ️"
+	}
+}
+
+// rank orders severities so routes can filter by a minimum severity
+func (s Severity) rank() int {
+	switch s {
+	case SeverityCritical:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AtLeast reports whether the severity is at or above min. An empty min matches everything.
+func (s Severity) AtLeast(min Severity) bool {
+	return min == "" || s.rank() >= min.rank()
+}
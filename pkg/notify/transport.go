@@ -0,0 +1,85 @@
+package notify
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// TLSOptions configure the TLS connections of a notifier
+type TLSOptions struct {
+	// CAFile is a PEM bundle of certificate authorities trusted in addition to the system ones
+	CAFile string
+	// MinVersion is the minimum TLS version, e.g. tls.VersionTLS13; zero means TLS 1.2
+	MinVersion uint16
+	// CertFile and KeyFile are a PEM client certificate and key, read on each new connection
+	CertFile string
+	KeyFile  string
+}
+
+// TransportSetter is implemented by notifiers delivering over HTTP. Replacing their transport
+// applies a sink's proxy and TLS options, built with NewTransport, or logs their requests
+// instead of sending them with a DryRunTransport. Notifiers speaking other protocols take
+// their TLS options at construction instead.
+type TransportSetter interface {
+	SetTransport(transport http.RoundTripper)
+}
+
+// NewTransport returns a transport like http.DefaultTransport with the given TLS options.
+// Requests go through proxy if it is set, otherwise through the proxy of the environment.
+func NewTransport(opts TLSOptions, proxy *url.URL) (*http.Transport, error) {
+	tlsConfig, err := NewTLSConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	return transport, nil
+}
+
+// NewTLSConfig returns the TLS configuration for the options, for notifiers that do not send
+// over HTTP
+func NewTLSConfig(opts TLSOptions) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if opts.MinVersion != 0 {
+		tlsConfig.MinVersion = opts.MinVersion
+	}
+
+	if opts.CAFile != "" {
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA bundle %s contains no PEM certificates", opts.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if opts.CertFile != "" {
+		// Fail at startup rather than on the first alert if the pair cannot be loaded
+		if _, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile); err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("loading client certificate: %w", err)
+			}
+			return &cert, nil
+		}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,181 @@
+package pagerduty
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// eventsAPIURL is the PagerDuty Events API v2 endpoint
+const eventsAPIURL = "https://events.pagerduty.com/v2/enqueue"
+
+// Event actions
+const (
+	actionTrigger = "trigger"
+	actionResolve = "resolve"
+)
+
+// Event is a PagerDuty Events API v2 event
+type Event struct {
+	RoutingKey  string   `json:"routing_key"`
+	EventAction string   `json:"event_action"`
+	DedupKey    string   `json:"dedup_key,omitempty"`
+	Payload     *Payload `json:"payload,omitempty"`
+}
+
+// Payload describes the incident of a trigger event
+type Payload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Timestamp     string            `json:"timestamp,omitempty"`
+	Component     string            `json:"component,omitempty"`
+	Group         string            `json:"group,omitempty"`
+	Class         string            `json:"class,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+// Notifier opens PagerDuty incidents for critical pod failures and resolves them when the
+// pod recovers. Alerts below critical severity are left to the chat notifiers.
+type Notifier struct {
+	routingKey string
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+// NewNotifier creates a new PagerDuty notifier from the PAGERDUTY_ROUTING_KEY environment variable
+func NewNotifier(logger logr.Logger) (*Notifier, error) {
+	routingKey := os.Getenv("PAGERDUTY_ROUTING_KEY")
+	if routingKey == "" {
+		return nil, fmt.Errorf("PAGERDUTY_ROUTING_KEY environment variable not set")
+	}
+
+	return &Notifier{
+		routingKey: routingKey,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		logger: logger,
+	}, nil
+}
+
+// SendPodAlert triggers an incident for critical alerts. The alert key is used as dedup_key,
+// so repeat alerts for the same pod and reason update a single incident.
+func (n *Notifier) SendPodAlert(alert notify.PodAlert) error {
+	if alert.AlertSeverity() != notify.SeverityCritical {
+		return nil
+	}
+
+	details := map[string]string{
+		"pod":       alert.PodName,
+		"namespace": alert.Namespace,
+		"container": alert.ContainerName,
+		"image":     alert.Image,
+		"restarts":  fmt.Sprintf("%d", alert.RestartCount),
+		"message":   alert.Message,
+	}
+	if alert.Team != "" {
+		details["owner"] = alert.Team
+	}
+	if exit := alert.ExitSummary(); exit != "" {
+		details["exit_code"] = exit
+	}
+	if workload := alert.Workload(); workload != "" {
+		details["workload"] = workload
+	}
+	if alert.Cluster != "" {
+		details["cluster"] = alert.Cluster
+	}
+	if id := alert.ID(); id != "" {
+		details["alert_id"] = id
+	}
+
+	if err := n.post(Event{
+		RoutingKey:  n.routingKey,
+		EventAction: actionTrigger,
+		DedupKey:    alert.Key,
+		Payload: &Payload{
+			// The summary is what SMS and phone notifications read out
+			Summary:       alert.Short(),
+			Source:        fmt.Sprintf("%s/%s", alert.Namespace, alert.PodName),
+			Severity:      string(notify.SeverityCritical),
+			Timestamp:     alert.Timestamp.Format(time.RFC3339),
+			Component:     alert.ContainerName,
+			Group:         alert.Namespace,
+			Class:         alert.Reason,
+			CustomDetails: details,
+		},
+	}); err != nil {
+		return err
+	}
+
+	n.logger.Info("PagerDuty incident triggered",
+		"pod", alert.PodName,
+		"namespace", alert.Namespace,
+		"reason", alert.Reason,
+		"dedupKey", alert.Key,
+	)
+
+	return nil
+}
+
+// SendMetaAlert is a no-op: operator-generated summaries and forecasts are not paged
+func (n *Notifier) SendMetaAlert(alert notify.MetaAlert) error {
+	return nil
+}
+
+// ResolvePodAlert resolves the incident opened for an alert once the pod has recovered
+func (n *Notifier) ResolvePodAlert(alert notify.PodAlert) error {
+	if alert.AlertSeverity() != notify.SeverityCritical {
+		return nil
+	}
+
+	if err := n.post(Event{
+		RoutingKey:  n.routingKey,
+		EventAction: actionResolve,
+		DedupKey:    alert.Key,
+	}); err != nil {
+		return err
+	}
+
+	n.logger.Info("PagerDuty incident resolved",
+		"pod", alert.PodName,
+		"namespace", alert.Namespace,
+		"reason", alert.Reason,
+		"dedupKey", alert.Key,
+	)
+
+	return nil
+}
+
+// post enqueues an event with the Events API
+func (n *Notifier) post(event Event) error {
+	jsonData, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal PagerDuty event: %w", err)
+	}
+
+	resp, err := n.httpClient.Post(eventsAPIURL, "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to send PagerDuty event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The Events API answers 202 Accepted for enqueued events
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("pagerduty events API returned status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SetTransport implements notify.TransportSetter
+func (n *Notifier) SetTransport(transport http.RoundTripper) {
+	n.httpClient.Transport = transport
+}
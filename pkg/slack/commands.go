@@ -0,0 +1,78 @@
+package slack
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// workloadRollouts are the workload kinds `kubectl rollout status` supports
+var workloadRollouts = map[string]string{
+	"Deployment":  "deployment",
+	"StatefulSet": "statefulset",
+	"DaemonSet":   "daemonset",
+}
+
+// suggestedCommands returns kubectl commands for investigating an alert's failure, the most
+// useful for its reason first, pre-filled with its namespace, pod and container
+func suggestedCommands(alert notify.PodAlert) []string {
+	ns, pod := alert.Namespace, alert.PodName
+	container := ""
+	if alert.ContainerName != "" {
+		container = " -c " + alert.ContainerName
+	}
+
+	var commands []string
+	switch alert.Reason {
+	case "CrashLoopBackOff", "Error", "OOMKilled", "ContainerCannotRun", "Failed", "LivenessProbeFailed",
+		"FailedPostStartHook", "FailedPreStopHook", "GracefulShutdownExceeded":
+		// The current instance of a crashing container is waiting or has barely started
+		commands = append(commands, fmt.Sprintf("kubectl logs -n %s %s%s --previous", ns, pod, container))
+	case "ImagePullBackOff", "ErrImagePull", "InvalidImageName", "ImageInspectError":
+		commands = append(commands, fmt.Sprintf("kubectl get pod -n %s %s -o jsonpath='{.spec.containers[*].image}{\"\\n\"}{.spec.imagePullSecrets}'", ns, pod))
+	case "FailedScheduling":
+		commands = append(commands, "kubectl get nodes -o wide")
+	case "CreateContainerConfigError":
+		commands = append(commands, fmt.Sprintf("kubectl get configmaps,secrets -n %s", ns))
+	case "Evicted", "NodeDrain":
+		if alert.Node != "" {
+			commands = append(commands, fmt.Sprintf("kubectl describe node %s", alert.Node))
+		}
+	case "StuckTerminating":
+		commands = append(commands, fmt.Sprintf("kubectl get pod -n %s %s -o jsonpath='{.metadata.finalizers}'", ns, pod))
+	case "PendingTimeout":
+		// Pods waiting to start are mostly held up by their volumes
+		commands = append(commands, fmt.Sprintf("kubectl get pvc -n %s", ns))
+	default:
+		commands = append(commands, fmt.Sprintf("kubectl logs -n %s %s%s", ns, pod, container))
+	}
+	if alert.Reason == "OOMKilled" || alert.Reason == "CPUThrottling" {
+		commands = append(commands, fmt.Sprintf("kubectl top pod -n %s %s --containers", ns, pod))
+	}
+
+	commands = append(commands,
+		fmt.Sprintf("kubectl describe pod -n %s %s", ns, pod),
+		fmt.Sprintf("kubectl get events -n %s --field-selector involvedObject.name=%s --sort-by=.lastTimestamp", ns, pod),
+	)
+	if kind, ok := workloadRollouts[alert.WorkloadKind]; ok {
+		commands = append(commands, fmt.Sprintf("kubectl rollout status -n %s %s/%s", ns, kind, alert.WorkloadName))
+	}
+	describeNode := fmt.Sprintf("kubectl describe node %s", alert.Node)
+	if alert.Node != "" && strings.Contains(alert.NodeSummary(), "⚠️") && !slices.Contains(commands, describeNode) {
+		commands = append(commands, describeNode)
+	}
+	return commands
+}
+
+// commandsBlock renders the suggested commands as a context block of code lines, ready to
+// copy into a terminal
+func commandsBlock(alert notify.PodAlert) Block {
+	commands := suggestedCommands(alert)
+	lines := make([]string, len(commands))
+	for i, command := range commands {
+		lines[i] = "`" + command + "`"
+	}
+	return Block{Type: "context", Elements: []BlockElement{BlockText{Type: "mrkdwn", Text: truncate(strings.Join(lines, "\n"), maxSectionText)}}}
+}
@@ -0,0 +1,197 @@
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// ActionShowDetails is the action ID of the Details button on compact messages
+const ActionShowDetails = "show_details"
+
+// defaultDetailsEmoji is the reaction that expands a compact message when SLACK_DETAILS_EMOJI is unset
+const defaultDetailsEmoji = "mag"
+
+// Bounds of the index mapping compact messages to their alerts
+const (
+	compactIndexTTL        = 24 * time.Hour
+	compactIndexMaxEntries = 5000
+)
+
+// AlertRef identifies the pod and container an alert was sent for. It is carried in the
+// Details and Create issue buttons, so they work even after the operator restarted.
+type AlertRef struct {
+	Namespace    string `json:"ns"`
+	Pod          string `json:"pod"`
+	Container    string `json:"c,omitempty"`
+	Reason       string `json:"r,omitempty"`
+	RestartCount int32  `json:"rc,omitempty"`
+	// Fingerprint identifies the failure in issue trackers
+	Fingerprint string `json:"fp,omitempty"`
+}
+
+// compactMessage is a compact alert that was posted with the Web API
+type compactMessage struct {
+	ref      AlertRef
+	postedAt time.Time
+	expanded bool
+}
+
+// compactIndex maps posted compact messages to their alerts, so a reaction on a message can
+// be resolved to the pod it is about
+type compactIndex struct {
+	mu       sync.Mutex
+	messages map[string]*compactMessage
+	emoji    string
+}
+
+func newCompactIndex(emoji string) *compactIndex {
+	return &compactIndex{
+		messages: make(map[string]*compactMessage),
+		emoji:    emoji,
+	}
+}
+
+// record remembers a posted compact message, evicting expired and excess entries
+func (c *compactIndex) record(channel, ts string, ref AlertRef, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, msg := range c.messages {
+		if now.Sub(msg.postedAt) > compactIndexTTL || len(c.messages) >= compactIndexMaxEntries {
+			delete(c.messages, key)
+		}
+	}
+	c.messages[channel+"/"+ts] = &compactMessage{ref: ref, postedAt: now}
+}
+
+// CompactMode reports whether alerts are posted as compact messages with details on demand
+func (n *Notifier) CompactMode() bool {
+	return n.compact != nil
+}
+
+// DetailsEmoji returns the reaction name that expands a compact message
+func (n *Notifier) DetailsEmoji() string {
+	if n.compact == nil {
+		return ""
+	}
+	return n.compact.emoji
+}
+
+// AlertForMessage returns the alert a compact message was posted for
+func (n *Notifier) AlertForMessage(channel, ts string) (AlertRef, bool) {
+	if n.compact == nil {
+		return AlertRef{}, false
+	}
+	n.compact.mu.Lock()
+	defer n.compact.mu.Unlock()
+
+	msg, ok := n.compact.messages[channel+"/"+ts]
+	if !ok {
+		return AlertRef{}, false
+	}
+	return msg.ref, true
+}
+
+// ClaimExpansion marks a compact message as expanded and reports whether this caller should
+// post its details. Messages that are unknown, e.g. after a restart, can always be expanded.
+func (n *Notifier) ClaimExpansion(channel, ts string) bool {
+	if n.compact == nil {
+		return true
+	}
+	n.compact.mu.Lock()
+	defer n.compact.mu.Unlock()
+
+	msg, ok := n.compact.messages[channel+"/"+ts]
+	if !ok {
+		return true
+	}
+	if msg.expanded {
+		return false
+	}
+	msg.expanded = true
+	return true
+}
+
+// PostThreadReply posts blocks as a reply in the thread of a message. It requires a bot token.
+func (n *Notifier) PostThreadReply(channel, threadTS, text string, blocks []Block) error {
+	if n.credentials().BotToken == "" {
+		return fmt.Errorf("replying in threads requires SLACK_BOT_TOKEN")
+	}
+
+	var resp postMessageResponse
+	return n.callWebAPI(http.MethodPost, "chat.postMessage", nil, SlackMessage{
+		Channel:  channel,
+		ThreadTS: threadTS,
+		Text:     text,
+		Blocks:   blocks,
+	}, &resp)
+}
+
+// formatCompactMessage renders a one-line summary of an alert with a Details button
+func (n *Notifier) formatCompactMessage(alert notify.PodAlert) (string, []Block) {
+	subject := fmt.Sprintf("`%s/%s`", alert.Namespace, alert.PodName)
+	if alert.WorkloadName != "" {
+		subject = fmt.Sprintf("%s `%s/%s`", alert.WorkloadKind, alert.Namespace, alert.WorkloadName)
+	}
+	summary := fmt.Sprintf("%s *%s* %s", n.getEmojiForReason(alert.Reason), alert.Reason, subject)
+	if alert.ContainerName != "" {
+		summary += fmt.Sprintf(" · container `%s`", alert.ContainerName)
+	}
+	if alert.RestartCount > 0 {
+		summary += fmt.Sprintf(" · %d restarts", alert.RestartCount)
+	}
+	if alert.Team != "" {
+		summary += " · " + alert.Team
+	}
+	if alert.ExitCode != nil {
+		summary += fmt.Sprintf(" · exit %d", *alert.ExitCode)
+	}
+	if node := alert.NodeSummary(); strings.Contains(node, "⚠️") {
+		summary += " · node " + node
+	}
+	if alert.LogURL != "" {
+		summary += fmt.Sprintf(" · <%s|logs>", alert.LogURL)
+	}
+	if alert.KnownIssue != nil {
+		summary += " · " + knownIssueLink(alert.KnownIssue)
+	}
+
+	value, _ := json.Marshal(refFor(alert))
+	section := Block{
+		Type:      "section",
+		Text:      &BlockText{Type: "mrkdwn", Text: summary},
+		Accessory: NewButton("Details", ActionShowDetails, string(value), ""),
+	}
+	hint := Block{
+		Type: "context",
+		Elements: []BlockElement{BlockText{Type: "mrkdwn", Text: fmt.Sprintf(
+			"React with :%s: or click Details for logs, events and pod status", n.compact.emoji)}},
+	}
+	return summary, []Block{section, hint}
+}
+
+// recordCompact indexes a posted compact message so reactions on it can be resolved
+func (n *Notifier) recordCompact(resp *postMessageResponse, alert notify.PodAlert) {
+	if n.compact == nil || resp == nil || resp.TS == "" {
+		return
+	}
+	n.compact.record(resp.Channel, resp.TS, refFor(alert), time.Now())
+}
+
+// refFor returns the pod reference of an alert
+func refFor(alert notify.PodAlert) AlertRef {
+	return AlertRef{
+		Namespace:    alert.Namespace,
+		Pod:          alert.PodName,
+		Container:    alert.ContainerName,
+		Reason:       alert.Reason,
+		RestartCount: alert.RestartCount,
+		Fingerprint:  alert.Fingerprint,
+	}
+}
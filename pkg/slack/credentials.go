@@ -0,0 +1,54 @@
+package slack
+
+import (
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Secret keys holding Slack credentials
+const (
+	SecretKeyWebhookURL = "webhook-url"
+	SecretKeyBotToken   = "bot-token"
+	SecretKeyChannel    = "channel"
+)
+
+// Credentials holds the settings needed to deliver messages to Slack
+type Credentials struct {
+	// WebhookURL is the incoming webhook used as the default destination
+	WebhookURL string
+	// BotToken enables Web API (chat.postMessage) delivery when set
+	BotToken string
+	// Channel is the default channel for bot token mode
+	Channel string
+}
+
+// CredentialsFromEnv reads credentials from SLACK_WEBHOOK_URL, SLACK_BOT_TOKEN and SLACK_CHANNEL
+func CredentialsFromEnv() Credentials {
+	return Credentials{
+		WebhookURL: os.Getenv("SLACK_WEBHOOK_URL"),
+		BotToken:   os.Getenv("SLACK_BOT_TOKEN"),
+		Channel:    os.Getenv("SLACK_CHANNEL"),
+	}
+}
+
+// CredentialsFromSecret reads credentials from the webhook-url, bot-token and channel keys of a Secret
+func CredentialsFromSecret(secret *corev1.Secret) Credentials {
+	return Credentials{
+		WebhookURL: string(secret.Data[SecretKeyWebhookURL]),
+		BotToken:   string(secret.Data[SecretKeyBotToken]),
+		Channel:    string(secret.Data[SecretKeyChannel]),
+	}
+}
+
+// Validate checks that the credentials are sufficient to deliver messages
+func (c Credentials) Validate() error {
+	if c.BotToken == "" && c.WebhookURL == "" {
+		return fmt.Errorf("a Slack webhook URL or bot token must be configured")
+	}
+	if c.BotToken != "" && c.Channel == "" && c.WebhookURL == "" {
+		return fmt.Errorf("a default Slack channel must be configured when using a bot token")
+	}
+	return nil
+}
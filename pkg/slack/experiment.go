@@ -0,0 +1,193 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"net/url"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// Experiment variants
+const (
+	VariantControl   = "control"
+	VariantAlternate = "alternate"
+)
+
+const (
+	// ackPollInterval is how often pending messages are checked for reactions
+	ackPollInterval = time.Minute
+	// ackTimeout stops tracking messages nobody reacted to
+	ackTimeout = 24 * time.Hour
+)
+
+// pendingAck is a sent message waiting for its first reaction
+type pendingAck struct {
+	channel string
+	ts      string
+	variant string
+	sentAt  time.Time
+}
+
+// reactionsResponse is the subset of the reactions.get response we care about
+type reactionsResponse struct {
+	apiResponse
+	Message struct {
+		Reactions []struct {
+			Name  string `json:"name"`
+			Count int    `json:"count"`
+		} `json:"reactions"`
+	} `json:"message"`
+}
+
+// Experiment assigns a percentage of alerts to an alternate message template and measures
+// how quickly responders acknowledge each variant. The first emoji reaction on a message
+// counts as its acknowledgement, which requires bot token mode (reactions:read scope).
+type Experiment struct {
+	name       string
+	percentage uint32
+	template   *template.Template
+
+	mu      sync.Mutex
+	pending []pendingAck
+}
+
+// NewExperiment parses the alternate template and creates an experiment assigning the given
+// percentage of alerts to it. The template is executed with a PodAlert.
+func NewExperiment(name string, percentage int, alternateTemplate string) (*Experiment, error) {
+	if percentage < 0 || percentage > 100 {
+		return nil, fmt.Errorf("experiment percentage must be between 0 and 100, got %d", percentage)
+	}
+
+	tmpl, err := template.New(name).Funcs(TemplateFuncs()).Parse(alternateTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse experiment template: %w", err)
+	}
+
+	return &Experiment{
+		name:       name,
+		percentage: uint32(percentage),
+		template:   tmpl,
+	}, nil
+}
+
+// TemplateFuncs returns the functions available in alert message templates
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"emoji":    func(reason string) string { return (&Notifier{}).getEmojiForReason(reason) },
+		"severity": func(reason string) string { return string(notify.SeverityForReason(reason)) },
+		"rfc3339":  func(t time.Time) string { return t.Format(time.RFC3339) },
+	}
+}
+
+// SetExperiment enables a template experiment on the notifier
+func (n *Notifier) SetExperiment(experiment *Experiment) {
+	n.experiment = experiment
+}
+
+// assign deterministically buckets an alert key so repeat alerts for the same incident
+// always use the same variant
+func (e *Experiment) assign(key string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	if h.Sum32()%100 < e.percentage {
+		return VariantAlternate
+	}
+	return VariantControl
+}
+
+// render executes the alternate template for an alert
+func (e *Experiment) render(alert notify.PodAlert) (string, error) {
+	var buf bytes.Buffer
+	if err := e.template.Execute(&buf, alert); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// sent counts a delivered alert and, when the message can be tracked, waits for its acknowledgement.
+// It is a no-op on a nil experiment.
+func (e *Experiment) sent(resp *postMessageResponse, variant string) {
+	if e == nil {
+		return
+	}
+
+	experimentAlertsTotal.WithLabelValues(e.name, variant).Inc()
+
+	if resp == nil || resp.Channel == "" || resp.TS == "" {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.pending = append(e.pending, pendingAck{
+		channel: resp.Channel,
+		ts:      resp.TS,
+		variant: variant,
+		sentAt:  time.Now(),
+	})
+}
+
+// RunAckPoller periodically checks pending experiment messages for reactions until the
+// context is cancelled. It is meant to be added to the manager as a Runnable.
+func (n *Notifier) RunAckPoller(ctx context.Context) error {
+	if n.experiment == nil {
+		return nil
+	}
+
+	ticker := time.NewTicker(ackPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			n.pollAcks(now)
+		}
+	}
+}
+
+// pollAcks records acknowledgement latency for messages that received a reaction
+func (n *Notifier) pollAcks(now time.Time) {
+	e := n.experiment
+
+	e.mu.Lock()
+	pending := e.pending
+	e.pending = nil
+	e.mu.Unlock()
+
+	var remaining []pendingAck
+	for _, p := range pending {
+		if now.Sub(p.sentAt) > ackTimeout {
+			experimentUnackedTotal.WithLabelValues(e.name, p.variant).Inc()
+			continue
+		}
+
+		var result reactionsResponse
+		query := url.Values{"channel": {p.channel}, "timestamp": {p.ts}}
+		if err := n.callWebAPI(http.MethodGet, "reactions.get", query, nil, &result); err != nil {
+			n.logger.V(1).Info("Failed to check reactions for experiment message", "error", err.Error())
+			remaining = append(remaining, p)
+			continue
+		}
+
+		if len(result.Message.Reactions) == 0 {
+			remaining = append(remaining, p)
+			continue
+		}
+
+		experimentAckSeconds.WithLabelValues(e.name, p.variant).Observe(now.Sub(p.sentAt).Seconds())
+	}
+
+	e.mu.Lock()
+	e.pending = append(remaining, e.pending...)
+	e.mu.Unlock()
+}
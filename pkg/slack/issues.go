@@ -0,0 +1,26 @@
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// ActionCreateIssue is the action ID of the button that opens an issue for an alert
+const ActionCreateIssue = "create_issue"
+
+// knownIssueLink renders a link to the issue tracking an alert's failure
+func knownIssueLink(issue *notify.Issue) string {
+	return fmt.Sprintf("<%s|known issue #%d>", issue.URL, issue.Number)
+}
+
+// createIssueBlock offers to open an issue for a failure no open issue tracks yet
+func createIssueBlock(alert notify.PodAlert) Block {
+	value, _ := json.Marshal(refFor(alert))
+	return Block{
+		Type:      "section",
+		Text:      &BlockText{Type: "mrkdwn", Text: "_No open issue tracks this failure yet._"},
+		Accessory: NewButton("Create issue", ActionCreateIssue, string(value), ""),
+	}
+}
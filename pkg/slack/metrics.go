@@ -0,0 +1,51 @@
+package slack
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	experimentAlertsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "slackgenie_experiment_alerts_total",
+			Help: "Number of alerts sent per template experiment variant",
+		},
+		[]string{"experiment", "variant"},
+	)
+
+	experimentAckSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "slackgenie_experiment_ack_seconds",
+			Help:    "Time from sending an alert to its first reaction, per template experiment variant",
+			Buckets: []float64{60, 300, 900, 1800, 3600, 7200, 14400, 43200, 86400},
+		},
+		[]string{"experiment", "variant"},
+	)
+
+	experimentUnackedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "slackgenie_experiment_unacknowledged_total",
+			Help: "Number of alerts that received no reaction within 24h, per template experiment variant",
+		},
+		[]string{"experiment", "variant"},
+	)
+
+	coalescedAlertsTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "slackgenie_slack_coalesced_alerts_total",
+			Help: "Number of pod alerts held back by the per-channel rate limit and posted as part of a summary",
+		},
+	)
+
+	slackDegraded = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "slackgenie_slack_degraded",
+			Help: "Whether the last readiness check found Slack unreachable or the credentials rejected (1) or not (0)",
+		},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(experimentAlertsTotal, experimentAckSeconds, experimentUnackedTotal, coalescedAlertsTotal, slackDegraded)
+}
@@ -4,25 +4,41 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
-	corev1 "k8s.io/api/core/v1"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/version"
 )
 
 // SlackMessage represents the structure of a Slack webhook message
 type SlackMessage struct {
-	Text   string  `json:"text"`
-	Color  string  `json:"color,omitempty"`
-	Blocks []Block `json:"blocks,omitempty"`
+	Channel  string  `json:"channel,omitempty"`
+	ThreadTS string  `json:"thread_ts,omitempty"`
+	Text     string  `json:"text"`
+	Color    string  `json:"color,omitempty"`
+	Blocks   []Block `json:"blocks,omitempty"`
 }
 
 // Block represents a Slack block kit structure
 type Block struct {
-	Type string     `json:"type"`
-	Text *BlockText `json:"text,omitempty"`
+	Type      string         `json:"type"`
+	Text      *BlockText     `json:"text,omitempty"`
+	Elements  []BlockElement `json:"elements,omitempty"`
+	Accessory *Button        `json:"accessory,omitempty"`
+}
+
+// BlockElement is an element of a context or actions block: BlockText or *Button
+type BlockElement interface {
+	blockElement()
 }
 
 // BlockText represents text within a Slack block
@@ -31,90 +47,382 @@ type BlockText struct {
 	Text string `json:"text"`
 }
 
-// PodAlert contains information about a pod failure
-type PodAlert struct {
-	PodName       string
-	Namespace     string
-	ContainerName string
-	Image         string
-	Reason        string
-	Message       string
-	RestartCount  int32
-	Timestamp     time.Time
+func (BlockText) blockElement() {}
+
+// webAPIBaseURL is the Slack Web API endpoint used in bot token mode
+const webAPIBaseURL = "https://slack.com/api/"
+
+// apiResult is implemented by Slack Web API response types
+type apiResult interface {
+	err() error
+}
+
+// apiResponse holds the fields common to every Slack Web API response
+type apiResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+func (r *apiResponse) err() error {
+	if r.OK {
+		return nil
+	}
+	err := fmt.Errorf("Slack API returned error: %s", r.Error)
+	if permanentAPIErrors[r.Error] {
+		return notify.Permanent(err)
+	}
+	return err
+}
+
+// permanentAPIErrors are Slack Web API errors that retrying cannot fix
+var permanentAPIErrors = map[string]bool{
+	"channel_not_found": true,
+	"not_in_channel":    true,
+	"is_archived":       true,
+	"invalid_auth":      true,
+	"not_authed":        true,
+	"account_inactive":  true,
+	"token_revoked":     true,
+	"missing_scope":     true,
+	"invalid_blocks":    true,
+	"msg_too_long":      true,
+	"no_text":           true,
+}
+
+// postMessageResponse is the subset of the chat.postMessage response we care about
+type postMessageResponse struct {
+	apiResponse
+	Channel string `json:"channel,omitempty"`
+	TS      string `json:"ts,omitempty"`
 }
 
 // Notifier handles Slack notifications
 type Notifier struct {
-	webhookURL string
+	credsMux   sync.RWMutex
+	creds      Credentials
 	httpClient *http.Client
 	logger     logr.Logger
+	threads    *threadTracker
+	experiment *Experiment
+	compact    *compactIndex
+	limiter    *channelLimiter
+	// deadLetters receives coalesced alerts whose summary could not be posted
+	deadLetters notify.DeadLetterHandler
+	// commands appends suggested kubectl commands to alerts
+	commands bool
 }
 
-// NewNotifier creates a new Slack notifier instance
+// NewNotifier creates a new Slack notifier instance from the SLACK_* environment variables.
+// When SLACK_BOT_TOKEN is set, messages are posted via the Web API (chat.postMessage),
+// which allows posting to arbitrary channels. Otherwise SLACK_WEBHOOK_URL is required.
 func NewNotifier(logger logr.Logger) (*Notifier, error) {
-	webhookURL := os.Getenv("SLACK_WEBHOOK_URL")
-	if webhookURL == "" {
-		return nil, fmt.Errorf("SLACK_WEBHOOK_URL environment variable not set")
+	return NewNotifierWithCredentials(logger, CredentialsFromEnv())
+}
+
+// NewNotifierWithCredentials creates a new Slack notifier instance using the given credentials
+func NewNotifierWithCredentials(logger logr.Logger, creds Credentials) (*Notifier, error) {
+	if err := creds.Validate(); err != nil {
+		return nil, err
 	}
 
-	return &Notifier{
-		webhookURL: webhookURL,
+	n := &Notifier{
+		creds: creds,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		logger: logger,
-	}, nil
+		logger:   logger,
+		commands: os.Getenv("SLACK_COMMANDS") != "false",
+	}
+
+	if os.Getenv("SLACK_THREADED") == "true" {
+		budget := defaultThreadUpdateBudget
+		if v := os.Getenv("SLACK_THREAD_UPDATES_PER_HOUR"); v != "" {
+			parsed, err := strconv.Atoi(v)
+			if err != nil || parsed < 0 {
+				return nil, fmt.Errorf("invalid SLACK_THREAD_UPDATES_PER_HOUR %q", v)
+			}
+			budget = parsed
+		}
+		n.threads = newThreadTracker(budget, time.Hour)
+	}
+
+	rate, burst := defaultRateLimit, defaultRateBurst
+	if v := os.Getenv("SLACK_RATE_LIMIT"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("invalid SLACK_RATE_LIMIT %q", v)
+		}
+		rate = parsed
+	}
+	if v := os.Getenv("SLACK_RATE_BURST"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 {
+			return nil, fmt.Errorf("invalid SLACK_RATE_BURST %q", v)
+		}
+		burst = parsed
+	}
+	if rate > 0 {
+		n.limiter = newChannelLimiter(rate, burst, n.sendCoalesced)
+	}
+
+	if os.Getenv("SLACK_COMPACT") == "true" {
+		emoji := strings.Trim(os.Getenv("SLACK_DETAILS_EMOJI"), ":")
+		if emoji == "" {
+			emoji = defaultDetailsEmoji
+		}
+		n.compact = newCompactIndex(emoji)
+	}
+
+	return n, nil
+}
+
+// UpdateCredentials atomically replaces the credentials used for subsequent messages,
+// allowing webhook URLs and tokens to be rotated without restarting the operator
+func (n *Notifier) UpdateCredentials(creds Credentials) error {
+	if err := creds.Validate(); err != nil {
+		return err
+	}
+
+	n.credsMux.Lock()
+	defer n.credsMux.Unlock()
+
+	n.creds = creds
+	return nil
+}
+
+// credentials returns a snapshot of the current credentials
+func (n *Notifier) credentials() Credentials {
+	n.credsMux.RLock()
+	defer n.credsMux.RUnlock()
+
+	return n.creds
 }
 
 // SendPodAlert sends a formatted alert message to Slack
-func (n *Notifier) SendPodAlert(alert PodAlert) error {
+func (n *Notifier) SendPodAlert(alert notify.PodAlert) error {
+	if n.limiter != nil && !n.limiter.allow(alert.Channel, alert, time.Now()) {
+		coalescedAlertsTotal.Inc()
+		n.logger.V(1).Info("Slack channel over rate limit, coalescing alert",
+			"pod", alert.PodName,
+			"namespace", alert.Namespace,
+			"reason", alert.Reason,
+			"channel", alert.Channel,
+		)
+		return nil
+	}
+
 	message := n.formatAlertMessage(alert)
 
+	variant := VariantControl
+	if n.experiment != nil {
+		variant = n.experiment.assign(alert.Key)
+		if variant == VariantAlternate {
+			rendered, err := n.experiment.render(alert)
+			if err != nil {
+				// Never lose an alert to a broken experiment template
+				n.logger.Error(err, "Failed to render experiment template, using default message")
+				variant = VariantControl
+			} else {
+				message = rendered
+			}
+		}
+	}
+
+	slackMsg := SlackMessage{
+		Channel: alert.Channel,
+		Text:    message,
+		Blocks: []Block{
+			{
+				Type: "section",
+				Text: &BlockText{
+					Type: "mrkdwn",
+					Text: truncate(message, maxSectionText),
+				},
+			},
+		},
+	}
+	// Compact mode replaces the default message; experiments keep their own template
+	if n.compact != nil && variant == VariantControl {
+		slackMsg.Text, slackMsg.Blocks = n.formatCompactMessage(alert)
+	}
+	if len(alert.Mentions) > 0 {
+		// Mentions go first, so they notify from the notification preview as well
+		mentions := strings.Join(alert.Mentions, " ")
+		slackMsg.Text = mentions + " " + slackMsg.Text
+		slackMsg.Blocks = append([]Block{{Type: "section", Text: &BlockText{Type: "mrkdwn", Text: mentions}}}, slackMsg.Blocks...)
+	}
+	if alert.OfferIssue && alert.KnownIssue == nil {
+		slackMsg.Blocks = append(slackMsg.Blocks, createIssueBlock(alert))
+	}
+	if n.commands && n.compact == nil {
+		slackMsg.Blocks = append(slackMsg.Blocks, commandsBlock(alert))
+	}
+	if len(alert.Links) > 0 {
+		slackMsg.Blocks = append(slackMsg.Blocks, linksBlock(alert.Links))
+	}
+	if len(alert.Trace) > 0 {
+		slackMsg.Blocks = append(slackMsg.Blocks, traceBlock(alert.Trace))
+	}
+
+	if n.threads != nil && alert.Key != "" {
+		return n.sendThreaded(alert, slackMsg, variant)
+	}
+
+	resp, err := n.post(slackMsg)
+	if err != nil {
+		return err
+	}
+	n.experiment.sent(resp, variant)
+	n.recordCompact(resp, alert)
+
+	n.logger.Info("Slack alert sent successfully",
+		"pod", alert.PodName,
+		"namespace", alert.Namespace,
+		"reason", alert.Reason,
+		"restarts", alert.RestartCount,
+		"channel", slackMsg.Channel,
+	)
+
+	return nil
+}
+
+// SendMetaAlert sends an operator-generated message to Slack
+func (n *Notifier) SendMetaAlert(alert notify.MetaAlert) error {
+	message := fmt.Sprintf("%s *%s*\n\n%s", notify.EmojiForSeverity(alert.Severity), alert.Title, alert.Text)
+
 	slackMsg := SlackMessage{
-		Text: message,
+		Channel: alert.Channel,
+		Text:    message,
 		Blocks: []Block{
 			{
 				Type: "section",
 				Text: &BlockText{
 					Type: "mrkdwn",
-					Text: message,
+					Text: truncate(message, maxSectionText),
 				},
 			},
+			{
+				Type:     "context",
+				Elements: []BlockElement{BlockText{Type: "mrkdwn", Text: version.Footer()}},
+			},
 		},
 	}
 
-	jsonData, err := json.Marshal(slackMsg)
+	if _, err := n.post(slackMsg); err != nil {
+		return err
+	}
+
+	n.logger.Info("Slack meta-alert sent successfully",
+		"title", alert.Title,
+		"channel", slackMsg.Channel,
+	)
+
+	return nil
+}
+
+// post delivers a message using the Web API in bot token mode, or the incoming webhook otherwise.
+// The returned response is nil for webhook deliveries.
+func (n *Notifier) post(msg SlackMessage) (*postMessageResponse, error) {
+	creds := n.credentials()
+	if creds.BotToken != "" && (msg.Channel != "" || creds.Channel != "") {
+		if msg.Channel == "" {
+			msg.Channel = creds.Channel
+		}
+		return n.postWebAPI(msg)
+	}
+
+	return nil, n.postWebhook(msg)
+}
+
+// postWebhook sends a message to the incoming webhook. Legacy webhooks honor the
+// channel field; newer app webhooks ignore it and post to their configured channel.
+func (n *Notifier) postWebhook(msg SlackMessage) error {
+	jsonData, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("failed to marshal Slack message: %w", err)
 	}
 
-	resp, err := n.httpClient.Post(n.webhookURL, "application/json", bytes.NewBuffer(jsonData))
+	resp, err := n.httpClient.Post(n.credentials().WebhookURL, "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return fmt.Errorf("failed to send Slack notification: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Slack webhook returned status code: %d", resp.StatusCode)
+		return notify.StatusError("Slack webhook", resp)
 	}
 
-	n.logger.Info("Slack alert sent successfully",
-		"pod", alert.PodName,
-		"namespace", alert.Namespace,
-		"reason", alert.Reason,
-		"restarts", alert.RestartCount,
-	)
+	return nil
+}
+
+// postWebAPI sends a message via chat.postMessage and returns the API response,
+// which carries the resolved channel ID and message timestamp
+func (n *Notifier) postWebAPI(msg SlackMessage) (*postMessageResponse, error) {
+	var result postMessageResponse
+	if err := n.callWebAPI(http.MethodPost, "chat.postMessage", nil, msg, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// callWebAPI invokes a Slack Web API method with the bot token. GET requests pass their
+// parameters as a query string, POST requests as a JSON body. out must embed the ok/error fields.
+func (n *Notifier) callWebAPI(httpMethod, apiMethod string, query url.Values, body any, out apiResult) error {
+	endpoint := webAPIBaseURL + apiMethod
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal Slack message: %w", err)
+		}
+		reader = bytes.NewBuffer(jsonData)
+	}
+
+	req, err := http.NewRequest(httpMethod, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to build Slack request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	req.Header.Set("Authorization", "Bearer "+n.credentials().BotToken)
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call Slack API %s: %w", apiMethod, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return notify.StatusError("Slack API", resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode Slack API response: %w", err)
+	}
+	if err := out.err(); err != nil {
+		return err
+	}
 
 	return nil
 }
 
 // formatAlertMessage formats the pod alert into a readable Slack message
-func (n *Notifier) formatAlertMessage(alert PodAlert) string {
+func (n *Notifier) formatAlertMessage(alert notify.PodAlert) string {
 	emoji := n.getEmojiForReason(alert.Reason)
 
-	return fmt.Sprintf(`%s *Kube-SlackGenie Alert:*
+	workload := ""
+	if alert.WorkloadName != "" {
+		workload = fmt.Sprintf("*Workload:* %s\n", alert.Workload())
+	}
 
-*Pod:* %s (namespace: %s)
+	message := fmt.Sprintf(`%s *Kube-SlackGenie Alert:*
+
+%s*Pod:* %s (namespace: %s)
 *Container:* %s
 *Image:* %s
 *Reason:* %s
@@ -122,6 +430,7 @@ func (n *Notifier) formatAlertMessage(alert PodAlert) string {
 *Restarts:* %d
 *Time:* %s`,
 		emoji,
+		workload,
 		alert.PodName,
 		alert.Namespace,
 		alert.ContainerName,
@@ -131,6 +440,53 @@ func (n *Notifier) formatAlertMessage(alert PodAlert) string {
 		alert.RestartCount,
 		alert.Timestamp.Format(time.RFC3339),
 	)
+
+	if exit := alert.ExitSummary(); exit != "" {
+		message += fmt.Sprintf("\n*Exit code:* %s", exit)
+	}
+	if node := alert.NodeSummary(); node != "" {
+		message += fmt.Sprintf("\n*Node:* %s", node)
+	}
+	if alert.Team != "" {
+		message += fmt.Sprintf("\n*Owner:* %s", alert.Team)
+	}
+	if alert.LogURL != "" {
+		message += fmt.Sprintf("\n*Logs:* <%s|View recent logs>", alert.LogURL)
+	}
+	if alert.KnownIssue != nil {
+		message += fmt.Sprintf("\n*Tracked in:* %s %s", knownIssueLink(alert.KnownIssue), alert.KnownIssue.Title)
+	}
+
+	return message
+}
+
+// maxLinkButtons is the most elements Slack accepts in an actions block
+const maxLinkButtons = 25
+
+// actionOpenLink is the action ID prefix of link buttons. Slack reports their clicks, which
+// need no handling, so the ID only has to be unique within the message.
+const actionOpenLink = "open_link_"
+
+// linksBlock renders an alert's dashboard links as buttons
+func linksBlock(links []notify.Link) Block {
+	block := Block{Type: "actions"}
+	for i, link := range links[:min(len(links), maxLinkButtons)] {
+		button := NewButton(truncate(link.Name, 75), fmt.Sprintf("%s%d", actionOpenLink, i), "", "")
+		button.URL = link.URL
+		block.Elements = append(block.Elements, button)
+	}
+	return block
+}
+
+// maxSectionText is the most characters Slack accepts in the text of a section or context
+// block element; longer blocks fail the whole message with invalid_blocks
+const maxSectionText = 3000
+
+// traceBlock renders the routing trace of an alert as a context block, within Slack's
+// 3000 character limit for a text element
+func traceBlock(trace []string) Block {
+	text := ":mag_right: *Why this alert was sent here*\n• " + strings.Join(trace, "\n• ")
+	return Block{Type: "context", Elements: []BlockElement{BlockText{Type: "mrkdwn", Text: truncate(text, maxSectionText)}}}
 }
 
 // getEmojiForReason returns appropriate emoji based on failure reason
@@ -146,70 +502,32 @@ func (n *Notifier) getEmojiForReason(reason string) string {
 		return "💥"
 	case "FailedScheduling":
 		return "⏰"
+	case "PendingTimeout":
+		return "⏳"
+	case "StuckTerminating":
+		return "🗑️"
+	case "Evicted":
+		return "🚪"
+	case "NodeDrain":
+		return "🔧"
+	case "CreateContainerConfigError":
+		return "🔑"
+	case "LivenessProbeFailed", "ReadinessProbeFailed":
+		return "🩺"
+	case "FailedPostStartHook", "FailedPreStopHook":
+		return "🪝"
 	default:
 		return "⚠️"
 	}
 }
 
-// CreatePodAlertFromPod extracts alert information from a Pod resource
-func CreatePodAlertFromPod(pod *corev1.Pod) *PodAlert {
-	if pod == nil {
-		return nil
-	}
-
-	// Find the first container with issues
-	var containerName, image, reason, message string
-	var restartCount int32
-
-	for _, containerStatus := range pod.Status.ContainerStatuses {
-		if containerStatus.State.Waiting != nil {
-			containerName = containerStatus.Name
-			image = containerStatus.Image
-			reason = containerStatus.State.Waiting.Reason
-			message = containerStatus.State.Waiting.Message
-			restartCount = containerStatus.RestartCount
-			break
-		}
-		if containerStatus.State.Terminated != nil && containerStatus.State.Terminated.ExitCode != 0 {
-			containerName = containerStatus.Name
-			image = containerStatus.Image
-			reason = containerStatus.State.Terminated.Reason
-			message = containerStatus.State.Terminated.Message
-			restartCount = containerStatus.RestartCount
-			break
-		}
-	}
-
-	// If no container status found, check init containers
-	if containerName == "" {
-		for _, containerStatus := range pod.Status.InitContainerStatuses {
-			if containerStatus.State.Waiting != nil {
-				containerName = containerStatus.Name
-				image = containerStatus.Image
-				reason = containerStatus.State.Waiting.Reason
-				message = containerStatus.State.Waiting.Message
-				restartCount = containerStatus.RestartCount
-				break
-			}
-		}
-	}
-
-	// Fallback to pod-level information
-	if containerName == "" && len(pod.Spec.Containers) > 0 {
-		containerName = pod.Spec.Containers[0].Name
-		image = pod.Spec.Containers[0].Image
-		reason = string(pod.Status.Phase)
-		message = pod.Status.Message
-	}
+// SetDeadLetters sets the handler of alerts lost after the notifier accepted them, i.e.
+// alerts coalesced by the rate limit whose summary could not be posted
+func (n *Notifier) SetDeadLetters(handler notify.DeadLetterHandler) {
+	n.deadLetters = handler
+}
 
-	return &PodAlert{
-		PodName:       pod.Name,
-		Namespace:     pod.Namespace,
-		ContainerName: containerName,
-		Image:         image,
-		Reason:        reason,
-		Message:       message,
-		RestartCount:  restartCount,
-		Timestamp:     time.Now(),
-	}
+// SetTransport implements notify.TransportSetter
+func (n *Notifier) SetTransport(transport http.RoundTripper) {
+	n.httpClient.Transport = transport
 }
@@ -0,0 +1,96 @@
+package slack
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-logr/logr"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/internal/config"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// postedBlocks is the part of a posted message the tests inspect. Block elements are left
+// out, as buttons do not decode into BlockText.
+type postedBlocks struct {
+	Blocks []struct {
+		Type string     `json:"type"`
+		Text *BlockText `json:"text"`
+	} `json:"blocks"`
+}
+
+// TestSendPodAlertSectionLimit checks that alert sections stay within the 3000 characters
+// Slack accepts, both at the default enrichment budgets and beyond them
+func TestSendPodAlertSectionLimit(t *testing.T) {
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatalf("loading default config: %v", err)
+	}
+	budgets := cfg.Enrichment.Budgets
+	logs := strings.Repeat("x", budgets.Logs-1) + "!"
+	enriched := strings.Repeat("d", budgets.Describe) + "\n\n" + strings.Repeat("e", budgets.Events) +
+		"\n\n📜 Last 20 log lines of previous instance of container " + strings.Repeat("c", 63) +
+		":\n```\n" + logs + "\n```"
+
+	tests := []struct {
+		name    string
+		message string
+		// complete reports whether the whole message must fit without truncation
+		complete bool
+	}{
+		{name: "default maximum enrichment", message: enriched, complete: true},
+		{name: "oversized enrichment", message: strings.Repeat("ü", 8192)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posted postedBlocks
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if err := json.NewDecoder(req.Body).Decode(&posted); err != nil {
+					t.Errorf("decoding posted message: %v", err)
+				}
+			}))
+			defer server.Close()
+
+			t.Setenv("SLACK_RATE_LIMIT", "0")
+			n, err := NewNotifierWithCredentials(logr.Discard(), Credentials{WebhookURL: server.URL})
+			if err != nil {
+				t.Fatalf("creating notifier: %v", err)
+			}
+			err = n.SendPodAlert(notify.PodAlert{
+				PodName:       strings.Repeat("p", 253),
+				Namespace:     strings.Repeat("n", 63),
+				ContainerName: strings.Repeat("c", 63),
+				Image:         "registry.example.com/" + strings.Repeat("i", 200) + ":v1.2.3",
+				Reason:        "CrashLoopBackOff",
+				Message:       tt.message,
+				RestartCount:  42,
+				Timestamp:     time.Now(),
+			})
+			if err != nil {
+				t.Fatalf("sending alert: %v", err)
+			}
+
+			var sections int
+			for _, block := range posted.Blocks {
+				if block.Type != "section" || block.Text == nil {
+					continue
+				}
+				sections++
+				if length := utf8.RuneCountInString(block.Text.Text); length > maxSectionText {
+					t.Errorf("section text has %d characters, want at most %d", length, maxSectionText)
+				}
+				if tt.complete && !strings.Contains(block.Text.Text, logs) {
+					t.Errorf("section text was truncated at the default enrichment budgets")
+				}
+			}
+			if sections == 0 {
+				t.Fatalf("no section block posted")
+			}
+		})
+	}
+}
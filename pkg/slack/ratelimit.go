@@ -0,0 +1,162 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// Defaults of the per-channel rate limit, following Slack's guidance of about one message per
+// second per channel, with a little room for short bursts
+const (
+	defaultRateLimit = 1.0
+	defaultRateBurst = 3
+)
+
+// coalesceWindow is how long alerts over the rate limit are gathered before their summary is posted
+const coalesceWindow = 5 * time.Second
+
+// maxCoalescedLines bounds the alerts listed individually in a summary
+const maxCoalescedLines = 20
+
+// tokenBucket holds the tokens of one channel
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// coalescedAlerts are alerts held back from a channel until their summary is posted. All of
+// them are kept, so they can be dead-lettered if the summary cannot be delivered.
+type coalescedAlerts struct {
+	alerts []notify.PodAlert
+}
+
+// channelLimiter rate limits pod alerts per channel with a token bucket. Alerts over the limit
+// are coalesced and handed to flush as a single summary once the window has passed, so a
+// mass failure produces one message per channel instead of getting the app rate limited.
+type channelLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[string]*tokenBucket
+	pending map[string]*coalescedAlerts
+	flush   func(channel string, alerts []notify.PodAlert)
+}
+
+func newChannelLimiter(rate float64, burst int, flush func(string, []notify.PodAlert)) *channelLimiter {
+	return &channelLimiter{
+		rate:    rate,
+		burst:   float64(max(burst, 1)),
+		buckets: make(map[string]*tokenBucket),
+		pending: make(map[string]*coalescedAlerts),
+		flush:   flush,
+	}
+}
+
+// allow takes a token for the channel, or coalesces the alert and returns false when the
+// channel is over its limit or already has alerts waiting for a summary
+func (l *channelLimiter) allow(channel string, alert notify.PodAlert, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Keep alerts in order: once a summary is pending, later alerts join it
+	if waiting, ok := l.pending[channel]; ok {
+		waiting.add(alert)
+		return false
+	}
+
+	bucket, ok := l.buckets[channel]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, updated: now}
+		l.buckets[channel] = bucket
+	}
+	bucket.tokens = min(l.burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*l.rate)
+	bucket.updated = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true
+	}
+
+	waiting := &coalescedAlerts{}
+	waiting.add(alert)
+	l.pending[channel] = waiting
+	time.AfterFunc(coalesceWindow, func() { l.flushChannel(channel) })
+	return false
+}
+
+// flushChannel hands the coalesced alerts of a channel to the flush function
+func (l *channelLimiter) flushChannel(channel string) {
+	l.mu.Lock()
+	waiting, ok := l.pending[channel]
+	delete(l.pending, channel)
+	if bucket, exists := l.buckets[channel]; exists {
+		// The summary itself is a message on the channel
+		bucket.tokens--
+	}
+	l.mu.Unlock()
+
+	if ok {
+		l.flush(channel, waiting.alerts)
+	}
+}
+
+// add records an alert
+func (c *coalescedAlerts) add(alert notify.PodAlert) {
+	c.alerts = append(c.alerts, alert)
+}
+
+// sendCoalesced posts the summary of alerts that were held back by the rate limit, listing at
+// most maxCoalescedLines of them. The queue has already moved on from these alerts, so when
+// the summary fails each of them is dead-lettered.
+func (n *Notifier) sendCoalesced(channel string, alerts []notify.PodAlert) {
+	total := len(alerts)
+	listed := alerts[:min(total, maxCoalescedLines)]
+	lines := make([]string, 0, len(listed)+1)
+	for _, alert := range listed {
+		line := fmt.Sprintf("• %s *%s* `%s/%s`", n.getEmojiForReason(alert.Reason), alert.Reason, alert.Namespace, alert.PodName)
+		if alert.ContainerName != "" {
+			line += fmt.Sprintf(" container `%s`", alert.ContainerName)
+		}
+		lines = append(lines, line)
+	}
+	if total > len(listed) {
+		lines = append(lines, fmt.Sprintf("_…and %d more_", total-len(listed)))
+	}
+
+	summary := fmt.Sprintf(":rotating_light: *%d pod alerts* in %s, combined to stay within Slack's rate limit", total, coalesceWindow)
+	msg := SlackMessage{
+		Channel: channel,
+		Text:    summary,
+		Blocks: []Block{
+			{Type: "section", Text: &BlockText{Type: "mrkdwn", Text: summary}},
+			{Type: "section", Text: &BlockText{Type: "mrkdwn", Text: truncate(strings.Join(lines, "\n"), maxSectionText)}},
+		},
+	}
+
+	if _, err := n.post(msg); err != nil {
+		n.logger.Error(err, "Failed to send coalesced alert summary", "channel", channel, "alerts", total)
+		failedAt := time.Now()
+		for _, alert := range alerts {
+			notify.RecordDeadLetter(n.deadLetters, notify.DeadLetter{
+				Kind:     notify.DeadLetterPod,
+				PodAlert: &alert,
+				Err:      err,
+				FailedAt: failedAt,
+			})
+		}
+		return
+	}
+	n.logger.Info("Slack coalesced alert summary sent", "channel", channel, "alerts", total)
+}
+
+// truncate shortens text to at most limit bytes
+func truncate(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	return text[:limit-3] + "..."
+}
@@ -0,0 +1,127 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// Check verifies that Slack accepts the notifier's credentials, without posting a message.
+// A bot token is checked with auth.test. A webhook is sent an empty payload, which Slack
+// rejects as invalid for a working webhook, and with 403, 404 or 410 for a revoked one or one
+// whose channel is gone.
+func (n *Notifier) Check(ctx context.Context) error {
+	creds := n.credentials()
+	if creds.BotToken != "" {
+		var result apiResponse
+		if err := n.callWebAPI(http.MethodPost, "auth.test", nil, nil, &result); err != nil {
+			return fmt.Errorf("bot token: %w", err)
+		}
+	}
+	if creds.WebhookURL == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, creds.WebhookURL, bytes.NewBufferString("{}"))
+	if err != nil {
+		return fmt.Errorf("failed to build Slack webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		// The URL is the webhook's secret, so leave it out of the error
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to reach Slack webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusBadRequest:
+		return nil
+	default:
+		return fmt.Errorf("webhook: %w", notify.StatusError("Slack webhook", resp))
+	}
+}
+
+// failedCheckInterval bounds how long a failed check is reused, so fixed credentials make the
+// operator ready again soon
+const failedCheckInterval = 30 * time.Second
+
+// errNotChecked is reported until the first check completes
+var errNotChecked = errors.New("slack credentials not checked yet")
+
+// ReadinessCheck checks the Slack credentials for the readiness probe. Slack is asked in the
+// background every interval, so probes neither call Slack every few seconds nor wait for it.
+type ReadinessCheck struct {
+	Notifier *Notifier
+	Interval time.Duration
+
+	mu sync.Mutex
+	// err is the result of the last check
+	err error
+}
+
+// NewReadinessCheck creates a check that fails until it is started and Slack was first asked
+func NewReadinessCheck(notifier *Notifier, interval time.Duration) *ReadinessCheck {
+	return &ReadinessCheck{Notifier: notifier, Interval: interval, err: errNotChecked}
+}
+
+// NeedLeaderElection checks on every replica, as each one reports its own readiness
+func (c *ReadinessCheck) NeedLeaderElection() bool {
+	return false
+}
+
+// Start checks the credentials right away and then every interval until ctx is cancelled
+func (c *ReadinessCheck) Start(ctx context.Context) error {
+	for {
+		err := c.refresh(ctx)
+
+		interval := c.Interval
+		if err != nil && interval > failedCheckInterval {
+			interval = failedCheckInterval
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// refresh asks Slack and records the result
+func (c *ReadinessCheck) refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	err := c.Notifier.Check(ctx)
+
+	if err != nil {
+		slackDegraded.Set(1)
+		c.Notifier.logger.Error(err, "Slack rejected the credentials or is unreachable, reporting not ready")
+	} else {
+		slackDegraded.Set(0)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+	return err
+}
+
+// Check is a healthz.Checker reporting the last result of Notifier.Check
+func (c *ReadinessCheck) Check(_ *http.Request) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
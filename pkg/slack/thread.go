@@ -0,0 +1,215 @@
+package slack
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// defaultThreadUpdateBudget is the default number of thread replies allowed per incident per window
+const defaultThreadUpdateBudget = 6
+
+// incidentThread tracks the Slack thread opened for an incident and its update budget
+type incidentThread struct {
+	// channel is the channel alerts were routed to, often a #name; channelID is the ID Slack
+	// resolved it to, which the Web API needs to read the message back
+	channel     string
+	channelID   string
+	ts          string
+	windowStart time.Time
+	updates     int
+	aggregated  int
+}
+
+// threadTracker maps incident keys to their Slack threads and enforces a time-sliced
+// budget of thread replies, so a flapping pod cannot flood a thread with updates.
+type threadTracker struct {
+	mu      sync.Mutex
+	threads map[string]*incidentThread
+	budget  int
+	window  time.Duration
+}
+
+func newThreadTracker(budget int, window time.Duration) *threadTracker {
+	return &threadTracker{
+		threads: make(map[string]*incidentThread),
+		budget:  budget,
+		window:  window,
+	}
+}
+
+// next decides how the next update for an incident should be posted. It returns the parent
+// thread timestamp (empty when a new thread must be started), the number of updates that were
+// aggregated since the last posted reply, and whether posting is allowed within the budget.
+func (t *threadTracker) next(key, channel string, now time.Time) (string, int, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	thread, exists := t.threads[key]
+	if !exists || thread.channel != channel {
+		return "", 0, true
+	}
+
+	if now.Sub(thread.windowStart) >= t.window {
+		thread.windowStart = now
+		thread.updates = 0
+	}
+
+	if thread.updates >= t.budget {
+		thread.aggregated++
+		return thread.ts, thread.aggregated, false
+	}
+
+	aggregated := thread.aggregated
+	thread.updates++
+	thread.aggregated = 0
+	return thread.ts, aggregated, true
+}
+
+// started records the parent message of a newly opened incident thread, posted to the channel
+// with the given ID
+func (t *threadTracker) started(key, channel, channelID, ts string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.threads[key] = &incidentThread{
+		channel:     channel,
+		channelID:   channelID,
+		ts:          ts,
+		windowStart: now,
+	}
+}
+
+// failed returns a reserved update to the budget after a failed post
+func (t *threadTracker) failed(key string, aggregated int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if thread, exists := t.threads[key]; exists {
+		if thread.updates > 0 {
+			thread.updates--
+		}
+		thread.aggregated += aggregated
+	}
+}
+
+// parent returns the channel ID and timestamp of the message that opened an incident's thread
+func (t *threadTracker) parent(key string) (string, string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	thread, exists := t.threads[key]
+	if !exists {
+		return "", "", false
+	}
+	return thread.channelID, thread.ts, true
+}
+
+// forget drops all threads whose key starts with the given prefix
+func (t *threadTracker) forget(prefix string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key := range t.threads {
+		if strings.HasPrefix(key, prefix) {
+			delete(t.threads, key)
+		}
+	}
+}
+
+// sendThreaded posts the first alert of an incident as a new message and later alerts as
+// replies in its thread, aggregating replies beyond the hourly budget into the next update.
+func (n *Notifier) sendThreaded(alert notify.PodAlert, msg SlackMessage, variant string) error {
+	creds := n.credentials()
+	if msg.Channel == "" {
+		msg.Channel = creds.Channel
+	}
+	if creds.BotToken == "" || msg.Channel == "" {
+		// Threading requires the Web API; incoming webhooks cannot reply in threads
+		_, err := n.post(msg)
+		return err
+	}
+
+	now := time.Now()
+	parentTS, aggregated, allowed := n.threads.next(alert.Key, msg.Channel, now)
+	if !allowed {
+		n.logger.V(1).Info("Thread update budget exhausted, aggregating update",
+			"pod", alert.PodName,
+			"namespace", alert.Namespace,
+			"reason", alert.Reason,
+			"aggregated", aggregated,
+		)
+		return nil
+	}
+
+	if parentTS != "" {
+		msg.ThreadTS = parentTS
+		if aggregated > 0 {
+			note := fmt.Sprintf("_%d more update(s) were aggregated since the last reply (budget: %d per %s)_",
+				aggregated, n.threads.budget, n.threads.window)
+			msg.Text = msg.Text + "\n\n" + note
+			msg.Blocks = append(msg.Blocks, Block{
+				Type: "section",
+				Text: &BlockText{Type: "mrkdwn", Text: note},
+			})
+		}
+	}
+
+	resp, err := n.postWebAPI(msg)
+	if err != nil {
+		if parentTS != "" {
+			n.threads.failed(alert.Key, aggregated)
+		}
+		return err
+	}
+	n.experiment.sent(resp, variant)
+	n.recordCompact(resp, alert)
+
+	if parentTS == "" {
+		n.threads.started(alert.Key, msg.Channel, resp.Channel, resp.TS, now)
+	}
+
+	n.logger.Info("Slack alert sent successfully",
+		"pod", alert.PodName,
+		"namespace", alert.Namespace,
+		"reason", alert.Reason,
+		"restarts", alert.RestartCount,
+		"channel", msg.Channel,
+		"threaded", parentTS != "",
+	)
+
+	return nil
+}
+
+// ForgetThreads drops thread state for incidents whose key starts with the given prefix,
+// e.g. when the pod they belong to is deleted
+func (n *Notifier) ForgetThreads(prefix string) {
+	if n.threads != nil {
+		n.threads.forget(prefix)
+	}
+}
+
+// Acknowledged reports whether anyone reacted to the message that opened an incident's thread.
+// Only threaded alerts sent in bot token mode can be checked (reactions:read scope); any other
+// alert is never acknowledged.
+func (n *Notifier) Acknowledged(key string) (bool, error) {
+	if n.threads == nil {
+		return false, nil
+	}
+	channel, ts, ok := n.threads.parent(key)
+	if !ok {
+		return false, nil
+	}
+
+	var result reactionsResponse
+	query := url.Values{"channel": {channel}, "timestamp": {ts}}
+	if err := n.callWebAPI(http.MethodGet, "reactions.get", query, nil, &result); err != nil {
+		return false, err
+	}
+	return len(result.Message.Reactions) > 0, nil
+}
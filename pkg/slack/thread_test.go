@@ -0,0 +1,104 @@
+package slack
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+// rewriteTransport sends every request to a test server instead of the Slack API
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// TestAcknowledgedUsesChannelID checks that reactions are read from the channel ID returned by
+// chat.postMessage rather than the configured channel name, and that later alerts still reply
+// in the thread opened under that name
+func TestAcknowledgedUsesChannelID(t *testing.T) {
+	const (
+		channelName = "#alerts"
+		channelID   = "C0123ABCD"
+		parentTS    = "1700000000.000100"
+	)
+
+	// Only the routing fields are decoded, as button elements do not decode into BlockElement
+	type postedMessage struct {
+		Channel  string `json:"channel"`
+		ThreadTS string `json:"thread_ts"`
+	}
+	var posted []postedMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/chat.postMessage":
+			var msg postedMessage
+			if err := json.NewDecoder(req.Body).Decode(&msg); err != nil {
+				t.Errorf("decoding posted message: %v", err)
+			}
+			posted = append(posted, msg)
+			_, _ = w.Write([]byte(`{"ok":true,"channel":"` + channelID + `","ts":"` + parentTS + `"}`))
+		case "/api/reactions.get":
+			if got := req.URL.Query().Get("channel"); got != channelID {
+				_, _ = w.Write([]byte(`{"ok":false,"error":"channel_not_found"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"message":{"reactions":[{"name":"eyes","count":1}]}}`))
+		default:
+			t.Errorf("unexpected request to %s", req.URL.Path)
+		}
+	}))
+	defer server.Close()
+	target, _ := url.Parse(server.URL)
+
+	t.Setenv("SLACK_THREADED", "true")
+	t.Setenv("SLACK_RATE_LIMIT", "0")
+	n, err := NewNotifierWithCredentials(logr.Discard(), Credentials{BotToken: "xoxb-test", Channel: channelName})
+	if err != nil {
+		t.Fatalf("creating notifier: %v", err)
+	}
+	n.SetTransport(rewriteTransport{target: target})
+
+	alert := notify.PodAlert{
+		PodName:   "api-0",
+		Namespace: "payments",
+		Reason:    "CrashLoopBackOff",
+		Key:       "payments/api-0/api",
+		Timestamp: time.Now(),
+	}
+	for range 2 {
+		if err := n.SendPodAlert(alert); err != nil {
+			t.Fatalf("sending alert: %v", err)
+		}
+	}
+
+	if len(posted) != 2 {
+		t.Fatalf("posted %d messages, want 2", len(posted))
+	}
+	if posted[0].Channel != channelName || posted[0].ThreadTS != "" {
+		t.Errorf("first alert posted to %q in thread %q, want a new message in %q", posted[0].Channel, posted[0].ThreadTS, channelName)
+	}
+	if posted[1].ThreadTS != parentTS {
+		t.Errorf("second alert posted in thread %q, want %q", posted[1].ThreadTS, parentTS)
+	}
+
+	acked, err := n.Acknowledged(alert.Key)
+	if err != nil {
+		t.Fatalf("checking acknowledgement: %v", err)
+	}
+	if !acked {
+		t.Errorf("alert with a reaction was not acknowledged")
+	}
+}
@@ -0,0 +1,28 @@
+package slack
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// lookupByEmailResponse is the response of users.lookupByEmail
+type lookupByEmailResponse struct {
+	apiResponse
+	User struct {
+		ID string `json:"id"`
+	} `json:"user"`
+}
+
+// LookupUserByEmail returns the ID of the workspace member with the email address. The bot
+// token needs the users:read.email scope.
+func (n *Notifier) LookupUserByEmail(email string) (string, error) {
+	if n.credentials().BotToken == "" {
+		return "", fmt.Errorf("looking up Slack users requires a bot token")
+	}
+	var result lookupByEmailResponse
+	if err := n.callWebAPI(http.MethodGet, "users.lookupByEmail", url.Values{"email": {email}}, nil, &result); err != nil {
+		return "", err
+	}
+	return result.User.ID, nil
+}
@@ -0,0 +1,49 @@
+package slack
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// View is a Slack surface such as the App Home tab
+type View struct {
+	Type   string  `json:"type"`
+	Blocks []Block `json:"blocks"`
+}
+
+// Button is an interactive button element, used as a section accessory or in an actions block
+type Button struct {
+	Type     string     `json:"type"`
+	Text     *BlockText `json:"text"`
+	ActionID string     `json:"action_id"`
+	Value    string     `json:"value,omitempty"`
+	Style    string     `json:"style,omitempty"`
+	// URL makes the button a link, opened in the browser when clicked
+	URL string `json:"url,omitempty"`
+}
+
+func (*Button) blockElement() {}
+
+// NewButton creates a button with a plain text label. Style is empty, "primary" or "danger".
+func NewButton(label, actionID, value, style string) *Button {
+	return &Button{
+		Type:     "button",
+		Text:     &BlockText{Type: "plain_text", Text: label},
+		ActionID: actionID,
+		Value:    value,
+		Style:    style,
+	}
+}
+
+// PublishHomeView publishes the App Home tab for a user. It requires a bot token.
+func (n *Notifier) PublishHomeView(userID string, view View) error {
+	if n.credentials().BotToken == "" {
+		return fmt.Errorf("publishing the App Home tab requires SLACK_BOT_TOKEN")
+	}
+
+	var resp apiResponse
+	return n.callWebAPI(http.MethodPost, "views.publish", nil, map[string]any{
+		"user_id": userID,
+		"view":    view,
+	}, &resp)
+}
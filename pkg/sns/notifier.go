@@ -0,0 +1,196 @@
+// Package sns publishes alerts to Amazon SNS topics, so they can fan out to the SMS, email,
+// SQS and Lambda subscribers managed in AWS.
+package sns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	awssns "github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/go-logr/logr"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/filesink"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+)
+
+const (
+	// publishTimeout bounds a single publish, including fetching credentials
+	publishTimeout = 15 * time.Second
+	// maxSubjectLength is the longest subject SNS accepts for email subscribers
+	maxSubjectLength = 100
+)
+
+// Options configures the SNS notifier
+type Options struct {
+	// TopicARN receives alerts not matched by Namespaces or Severities
+	TopicARN string
+	// Namespaces maps namespaces to the topic of their alerts
+	Namespaces map[string]string
+	// Severities maps severities to the topic of their alerts, for alerts of namespaces
+	// without their own topic
+	Severities map[notify.Severity]string
+	// Region overrides the region, which is otherwise taken from the topic ARN
+	Region string
+}
+
+// Notifier publishes alerts to SNS. Credentials come from the default AWS credential chain,
+// so IAM roles for service accounts (IRSA), EKS Pod Identity and static keys all work.
+type Notifier struct {
+	client *awssns.Client
+	opts   Options
+	logger logr.Logger
+}
+
+// NewNotifier creates a new SNS notifier
+func NewNotifier(logger logr.Logger, opts Options) (*Notifier, error) {
+	topics := []string{opts.TopicARN}
+	for _, topic := range opts.Namespaces {
+		topics = append(topics, topic)
+	}
+	for _, topic := range opts.Severities {
+		topics = append(topics, topic)
+	}
+	for _, topic := range topics {
+		if !arn.IsARN(topic) {
+			return nil, fmt.Errorf("invalid SNS topic ARN %q", topic)
+		}
+	}
+
+	region := opts.Region
+	if region == "" {
+		parsed, _ := arn.Parse(opts.TopicARN)
+		region = parsed.Region
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(context.Background(), awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	return &Notifier{
+		client: awssns.NewFromConfig(cfg),
+		opts:   opts,
+		logger: logger,
+	}, nil
+}
+
+// SendPodAlert publishes a pod alert to the topic of its namespace or severity
+func (n *Notifier) SendPodAlert(alert notify.PodAlert) error {
+	record := filesink.PodRecord(filesink.KindPodAlert, alert)
+	if err := n.publish(n.topic(alert), alert.Key, record, alert.Short(), podText(alert)); err != nil {
+		return err
+	}
+	n.logger.V(1).Info("Alert published to SNS",
+		"pod", alert.PodName,
+		"namespace", alert.Namespace,
+		"reason", alert.Reason,
+	)
+	return nil
+}
+
+// ResolvePodAlert publishes a record marking the alert's incident as recovered
+func (n *Notifier) ResolvePodAlert(alert notify.PodAlert) error {
+	record := filesink.PodRecord(filesink.KindPodResolved, alert)
+	record.Timestamp = time.Now()
+	summary := "RESOLVED " + alert.Short()
+	return n.publish(n.topic(alert), alert.Key, record, summary, summary)
+}
+
+// SendMetaAlert publishes an operator message to the default topic
+func (n *Notifier) SendMetaAlert(alert notify.MetaAlert) error {
+	record := filesink.Record{
+		Kind:      filesink.KindMetaAlert,
+		Timestamp: alert.Timestamp,
+		Severity:  alert.Severity,
+		Channel:   alert.Channel,
+		Title:     alert.Title,
+		Text:      alert.Text,
+	}
+	return n.publish(n.opts.TopicARN, alert.Title, record, alert.Title, alert.Title+"\n\n"+alert.Text)
+}
+
+// topic returns the topic of the alert's namespace, else of its severity, else the default
+func (n *Notifier) topic(alert notify.PodAlert) string {
+	if topic, ok := n.opts.Namespaces[alert.Namespace]; ok {
+		return topic
+	}
+	if topic, ok := n.opts.Severities[alert.AlertSeverity()]; ok {
+		return topic
+	}
+	return n.opts.TopicARN
+}
+
+// publish sends the record as JSON to SQS, Lambda and HTTP subscribers, the summary to SMS
+// subscribers and the text to email subscribers. Severity, namespace and reason are message
+// attributes, so subscriptions can select alerts with filter policies.
+func (n *Notifier) publish(topic, key string, record filesink.Record, summary, text string) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode alert record: %w", err)
+	}
+	message, err := json.Marshal(map[string]string{
+		"default": string(data),
+		"sms":     summary,
+		"email":   text,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode SNS message: %w", err)
+	}
+
+	subject := summary
+	if len(subject) > maxSubjectLength {
+		subject = subject[:maxSubjectLength-3] + "..."
+	}
+	input := &awssns.PublishInput{
+		TopicArn:          aws.String(topic),
+		Message:           aws.String(string(message)),
+		MessageStructure:  aws.String("json"),
+		Subject:           aws.String(subject),
+		MessageAttributes: map[string]types.MessageAttributeValue{},
+	}
+	for name, value := range map[string]string{
+		"severity":  string(record.Severity),
+		"namespace": record.Namespace,
+		"reason":    record.Reason,
+		"kind":      record.Kind,
+	} {
+		if value != "" {
+			input.MessageAttributes[name] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(value)}
+		}
+	}
+	// FIFO topics order messages per group; an incident's alert and recovery share a group
+	if strings.HasSuffix(topic, ".fifo") {
+		input.MessageGroupId = aws.String(key)
+		input.MessageDeduplicationId = aws.String(fmt.Sprintf("%s-%d", record.Kind, record.Timestamp.UnixNano()))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	if _, err := n.client.Publish(ctx, input); err != nil {
+		return fmt.Errorf("failed to publish alert to SNS: %w", err)
+	}
+	return nil
+}
+
+// podText renders a pod alert as plain text for email subscribers
+func podText(alert notify.PodAlert) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", alert.Short())
+	fmt.Fprintf(&b, "Pod: %s/%s\n", alert.Namespace, alert.PodName)
+	if workload := alert.Workload(); workload != "" {
+		fmt.Fprintf(&b, "Workload: %s\n", workload)
+	}
+	fmt.Fprintf(&b, "Container: %s\n", alert.ContainerName)
+	fmt.Fprintf(&b, "Image: %s\n", alert.Image)
+	fmt.Fprintf(&b, "Restarts: %d\n", alert.RestartCount)
+	if alert.Message != "" {
+		fmt.Fprintf(&b, "\n%s\n", alert.Message)
+	}
+	return b.String()
+}
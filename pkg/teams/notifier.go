@@ -0,0 +1,192 @@
+package teams
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/version"
+)
+
+// Message is the envelope Teams webhooks expect for Adaptive Card payloads
+type Message struct {
+	Type        string       `json:"type"`
+	Attachments []Attachment `json:"attachments"`
+}
+
+// Attachment wraps a single Adaptive Card
+type Attachment struct {
+	ContentType string       `json:"contentType"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+// AdaptiveCard represents the subset of the Adaptive Card schema used for alerts
+type AdaptiveCard struct {
+	Schema  string        `json:"$schema"`
+	Type    string        `json:"type"`
+	Version string        `json:"version"`
+	Body    []CardElement `json:"body"`
+}
+
+// CardElement represents a TextBlock or FactSet element
+type CardElement struct {
+	Type   string `json:"type"`
+	Text   string `json:"text,omitempty"`
+	Size   string `json:"size,omitempty"`
+	Weight string `json:"weight,omitempty"`
+	Color  string `json:"color,omitempty"`
+	Wrap   bool   `json:"wrap,omitempty"`
+	Facts  []Fact `json:"facts,omitempty"`
+}
+
+// Fact is a title/value pair in a FactSet
+type Fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// Notifier handles Microsoft Teams notifications
+type Notifier struct {
+	webhookURL string
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+// NewNotifier creates a new Teams notifier instance from the TEAMS_WEBHOOK_URL environment variable
+func NewNotifier(logger logr.Logger) (*Notifier, error) {
+	webhookURL := os.Getenv("TEAMS_WEBHOOK_URL")
+	if webhookURL == "" {
+		return nil, fmt.Errorf("TEAMS_WEBHOOK_URL environment variable not set")
+	}
+
+	return &Notifier{
+		webhookURL: webhookURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		logger: logger,
+	}, nil
+}
+
+// SendPodAlert sends an Adaptive Card alert to the Teams webhook
+func (n *Notifier) SendPodAlert(alert notify.PodAlert) error {
+	if err := n.post(n.buildMessage(alert)); err != nil {
+		return err
+	}
+
+	n.logger.Info("Teams alert sent successfully",
+		"pod", alert.PodName,
+		"namespace", alert.Namespace,
+		"reason", alert.Reason,
+		"restarts", alert.RestartCount,
+	)
+
+	return nil
+}
+
+// SendMetaAlert sends an operator-generated message to the Teams webhook
+func (n *Notifier) SendMetaAlert(alert notify.MetaAlert) error {
+	if err := n.post(cardMessage([]CardElement{
+		{Type: "TextBlock", Text: alert.Title, Size: "Medium", Weight: "Bolder", Wrap: true},
+		{Type: "TextBlock", Text: alert.Text, Wrap: true},
+		{Type: "TextBlock", Text: version.Footer(), Size: "Small", Color: "Light", Wrap: true},
+	})); err != nil {
+		return err
+	}
+
+	n.logger.Info("Teams meta-alert sent successfully", "title", alert.Title)
+	return nil
+}
+
+// post delivers a message to the Teams webhook
+func (n *Notifier) post(msg Message) error {
+	jsonData, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Teams message: %w", err)
+	}
+
+	resp, err := n.httpClient.Post(n.webhookURL, "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to send Teams notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Workflow-based webhooks answer 202 Accepted, legacy connectors 200 OK
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("teams webhook returned status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// buildMessage renders the pod alert as an Adaptive Card
+func (n *Notifier) buildMessage(alert notify.PodAlert) Message {
+	facts := []Fact{
+		{Title: "Pod", Value: fmt.Sprintf("%s (namespace: %s)", alert.PodName, alert.Namespace)},
+		{Title: "Container", Value: alert.ContainerName},
+		{Title: "Image", Value: alert.Image},
+		{Title: "Reason", Value: alert.Reason},
+		{Title: "Restarts", Value: fmt.Sprintf("%d", alert.RestartCount)},
+		{Title: "Time", Value: alert.Timestamp.Format(time.RFC3339)},
+	}
+	if workload := alert.Workload(); workload != "" {
+		facts = append([]Fact{{Title: "Workload", Value: workload}}, facts...)
+	}
+	if exit := alert.ExitSummary(); exit != "" {
+		facts = append(facts, Fact{Title: "Exit code", Value: exit})
+	}
+	if node := alert.NodeSummary(); node != "" {
+		facts = append(facts, Fact{Title: "Node", Value: node})
+	}
+	if alert.Team != "" {
+		facts = append(facts, Fact{Title: "Owner", Value: alert.Team})
+	}
+
+	body := []CardElement{
+		{
+			Type:   "TextBlock",
+			Text:   "Kube-SlackGenie Alert: " + alert.Reason,
+			Size:   "Medium",
+			Weight: "Bolder",
+			Color:  "Attention",
+			Wrap:   true,
+		},
+		{
+			Type:  "FactSet",
+			Facts: facts,
+		},
+	}
+	if alert.Message != "" {
+		body = append(body, CardElement{Type: "TextBlock", Text: alert.Message, Wrap: true})
+	}
+
+	return cardMessage(body)
+}
+
+// cardMessage wraps card elements in an Adaptive Card message envelope
+func cardMessage(body []CardElement) Message {
+	return Message{
+		Type: "message",
+		Attachments: []Attachment{
+			{
+				ContentType: "application/vnd.microsoft.card.adaptive",
+				Content: AdaptiveCard{
+					Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+					Type:    "AdaptiveCard",
+					Version: "1.4",
+					Body:    body,
+				},
+			},
+		},
+	}
+}
+
+// SetTransport implements notify.TransportSetter
+func (n *Notifier) SetTransport(transport http.RoundTripper) {
+	n.httpClient.Transport = transport
+}
@@ -0,0 +1,171 @@
+package telegram
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/notify"
+	"github.com/ahmadrazalab/kube-slackgenie-operator/pkg/version"
+)
+
+// botAPIBaseURL is the Telegram Bot API endpoint
+const botAPIBaseURL = "https://api.telegram.org/bot"
+
+// markdownV2Escaper escapes the characters reserved by MarkdownV2
+var markdownV2Escaper = strings.NewReplacer(
+	`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`, "~", `\~`,
+	"`", "\\`", ">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`, "=", `\=`, "|", `\|`,
+	"{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+)
+
+// SendMessageRequest is the body of a Bot API sendMessage call
+type SendMessageRequest struct {
+	ChatID                string `json:"chat_id"`
+	Text                  string `json:"text"`
+	ParseMode             string `json:"parse_mode"`
+	DisableWebPagePreview bool   `json:"disable_web_page_preview"`
+}
+
+// apiResponse is the envelope of every Bot API response
+type apiResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description,omitempty"`
+}
+
+// Notifier sends alerts to a Telegram chat through a bot
+type Notifier struct {
+	token      string
+	chatID     string
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+// NewNotifier creates a new Telegram notifier from the TELEGRAM_BOT_TOKEN and
+// TELEGRAM_CHAT_ID environment variables, typically populated from a Secret
+func NewNotifier(logger logr.Logger) (*Notifier, error) {
+	token := os.Getenv("TELEGRAM_BOT_TOKEN")
+	chatID := os.Getenv("TELEGRAM_CHAT_ID")
+	if token == "" || chatID == "" {
+		return nil, fmt.Errorf("TELEGRAM_BOT_TOKEN and TELEGRAM_CHAT_ID environment variables must be set")
+	}
+
+	return &Notifier{
+		token:  token,
+		chatID: chatID,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		logger: logger,
+	}, nil
+}
+
+// SendPodAlert sends a MarkdownV2 formatted alert to the chat
+func (n *Notifier) SendPodAlert(alert notify.PodAlert) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s *%s* in `%s/%s`\n\n",
+		notify.EmojiForSeverity(alert.AlertSeverity()),
+		escape(alert.Reason), escapeCode(alert.Namespace), escapeCode(alert.PodName))
+	if workload := alert.Workload(); workload != "" {
+		fmt.Fprintf(&b, "*Workload:* %s\n", escape(workload))
+	}
+	fmt.Fprintf(&b, "*Container:* %s\n", escape(alert.ContainerName))
+	fmt.Fprintf(&b, "*Image:* `%s`\n", escapeCode(alert.Image))
+	fmt.Fprintf(&b, "*Restarts:* %d\n", alert.RestartCount)
+	fmt.Fprintf(&b, "*Time:* %s\n", escape(alert.Timestamp.Format(time.RFC3339)))
+	if exit := alert.ExitSummary(); exit != "" {
+		fmt.Fprintf(&b, "*Exit code:* %s\n", escape(exit))
+	}
+	if node := alert.NodeSummary(); node != "" {
+		fmt.Fprintf(&b, "*Node:* %s\n", escape(node))
+	}
+	if alert.Team != "" {
+		fmt.Fprintf(&b, "*Owner:* %s\n", escape(alert.Team))
+	}
+	if alert.LogURL != "" {
+		fmt.Fprintf(&b, "*Logs:* [view recent logs](%s)\n", escapeLink(alert.LogURL))
+	}
+	if alert.Message != "" {
+		fmt.Fprintf(&b, "\n```\n%s\n```", escapeCode(alert.Message))
+	}
+
+	if err := n.send(b.String()); err != nil {
+		return err
+	}
+
+	n.logger.Info("Telegram alert sent successfully",
+		"pod", alert.PodName,
+		"namespace", alert.Namespace,
+		"reason", alert.Reason,
+		"restarts", alert.RestartCount,
+	)
+
+	return nil
+}
+
+// SendMetaAlert sends an operator-generated message to the chat
+func (n *Notifier) SendMetaAlert(alert notify.MetaAlert) error {
+	text := fmt.Sprintf("%s *%s*\n\n%s\n\n_%s_", notify.EmojiForSeverity(alert.Severity), escape(alert.Title), escape(alert.Text),
+		escape(version.Footer()))
+	if err := n.send(text); err != nil {
+		return err
+	}
+
+	n.logger.Info("Telegram meta-alert sent successfully", "title", alert.Title)
+	return nil
+}
+
+// send posts a MarkdownV2 message to the chat
+func (n *Notifier) send(text string) error {
+	jsonData, err := json.Marshal(SendMessageRequest{
+		ChatID:                n.chatID,
+		Text:                  text,
+		ParseMode:             "MarkdownV2",
+		DisableWebPagePreview: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal Telegram message: %w", err)
+	}
+
+	resp, err := n.httpClient.Post(botAPIBaseURL+n.token+"/sendMessage", "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		// The request URL contains the bot token; never include it in errors
+		return fmt.Errorf("failed to send Telegram notification")
+	}
+	defer resp.Body.Close()
+
+	var result apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("telegram API returned status code: %d", resp.StatusCode)
+	}
+	if !result.OK {
+		return fmt.Errorf("telegram API error: %s", result.Description)
+	}
+	return nil
+}
+
+// escape escapes text for MarkdownV2
+func escape(text string) string {
+	return markdownV2Escaper.Replace(text)
+}
+
+// escapeCode escapes text inside MarkdownV2 code spans and blocks, where only ` and \ are reserved
+func escapeCode(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "`", "\\`").Replace(text)
+}
+
+// escapeLink escapes a URL inside a MarkdownV2 inline link, where only ) and \ are reserved
+func escapeLink(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ")", `\)`).Replace(text)
+}
+
+// SetTransport implements notify.TransportSetter
+func (n *Notifier) SetTransport(transport http.RoundTripper) {
+	n.httpClient.Transport = transport
+}
@@ -0,0 +1,66 @@
+// Package version holds build information embedded at link time, e.g.
+//
+//	go build -ldflags "-X github.com/ahmadrazalab/kube-slackgenie-operator/pkg/version.Version=v1.2.0 ..."
+package version
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Build information, set with -ldflags -X
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildDate = "unknown"
+)
+
+// Info is the build information of the running binary
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"buildDate"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
+
+// Get returns the build information of the running binary
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+}
+
+// String renders the version and short commit, e.g. "v1.2.0 (3f2c1ab)"
+func String() string {
+	commit := Commit
+	if len(commit) > 7 {
+		commit = commit[:7]
+	}
+	return fmt.Sprintf("%s (%s)", Version, commit)
+}
+
+// Footer is appended to operator-generated messages so support can tell which build sent them
+func Footer() string {
+	return "Kube-SlackGenie " + String()
+}
+
+// Collector exports the build information as the slackgenie_build_info metric
+func Collector() prometheus.Collector {
+	info := Get()
+	gauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "slackgenie_build_info",
+			Help: "Build information of the running operator; the value is always 1",
+		},
+		[]string{"version", "commit", "build_date", "go_version", "platform"},
+	)
+	gauge.WithLabelValues(info.Version, info.Commit, info.BuildDate, info.GoVersion, info.Platform).Set(1)
+	return gauge
+}